@@ -34,23 +34,126 @@ func convertResponseAbout(aboutModel *models.AboutModel, aboutHistoryModels []mo
 		if aboutModel.IntroduceContent != nil {
 			responseAbout.IntroduceContent = *aboutModel.IntroduceContent
 		}
+
+		responseAbout.Version = aboutModel.Version
 	}
 
 	for _, aboutHistoryModel := range aboutHistoryModels {
+		responseAbout.Histories = append(responseAbout.Histories, *convertResponseAboutHistoryElement(&aboutHistoryModel))
+	}
+
+	// aboutHistoryModels 는 category 순서대로 정렬되어 있다.
+	responseAbout.HistoryGroups = make([]ResponseAboutHistoryGroup, 0)
+	for _, aboutHistoryGroupModel := range models.GroupHistories(aboutHistoryModels) {
 
-		aboutHistoryelement := ResponseAboutHistoryElement{
-			Id:       aboutHistoryModel.Id,
-			Category: aboutHistoryModel.Category,
-			Duration: aboutHistoryModel.Duration,
-			Content:  aboutHistoryModel.Content,
+		responseGroup := ResponseAboutHistoryGroup{Category: aboutHistoryGroupModel.Category, Histories: make([]ResponseAboutHistoryElement, 0)}
+		for _, aboutHistoryModel := range aboutHistoryGroupModel.Histories {
+			responseGroup.Histories = append(responseGroup.Histories, *convertResponseAboutHistoryElement(&aboutHistoryModel))
 		}
 
-		responseAbout.Histories = append(responseAbout.Histories, aboutHistoryelement)
+		responseAbout.HistoryGroups = append(responseAbout.HistoryGroups, responseGroup)
 	}
 
 	return &responseAbout
 }
 
+func convertResponseAboutHistoryElement(aboutHistoryModel *models.AboutHistoryModel) *ResponseAboutHistoryElement {
+
+	aboutHistoryelement := &ResponseAboutHistoryElement{
+		Id:       aboutHistoryModel.Id,
+		Category: aboutHistoryModel.Category,
+		Duration: aboutHistoryModel.Duration,
+		Content:  aboutHistoryModel.Content,
+	}
+
+	if aboutHistoryModel.StartDate != nil {
+		aboutHistoryelement.StartDate = aboutHistoryModel.StartDate.String()
+	}
+
+	if aboutHistoryModel.IsPresent {
+		aboutHistoryelement.EndDate = models.HistoryDatePresent
+	} else if aboutHistoryModel.EndDate != nil {
+		aboutHistoryelement.EndDate = aboutHistoryModel.EndDate.String()
+	}
+
+	return aboutHistoryelement
+}
+
+// start_date, end_date ("present" 가능) 를 읽는다.
+func parseAboutHistoryContent(requestHistory *RequesAboutHistoryElement) (models.AboutHistoryContent, error) {
+
+	historyContent := models.AboutHistoryContent{
+		Category: requestHistory.Category,
+		Duration: requestHistory.Duration,
+		Content:  requestHistory.Content,
+	}
+
+	var err error
+	if requestHistory.StartDate != nil && len(*requestHistory.StartDate) > 0 {
+		historyContent.StartDate, err = models.ParseHistoryDate(*requestHistory.StartDate)
+		if err != nil {
+			return historyContent, err
+		}
+	}
+
+	if requestHistory.EndDate != nil && len(*requestHistory.EndDate) > 0 {
+		if *requestHistory.EndDate == models.HistoryDatePresent {
+			historyContent.IsPresent = true
+		} else {
+			historyContent.EndDate, err = models.ParseHistoryDate(*requestHistory.EndDate)
+			if err != nil {
+				return historyContent, err
+			}
+		}
+	}
+
+	return historyContent, historyContent.ValidateDates()
+}
+
+// POST, PATCH 에서 같이 사용한다.
+// 값이 있는 항목만 바꾸고 수정된 about 으로 응답한다. 수정하지 못했으면 false 를 반환한다.
+func updateAbout(c *gin.Context, expectedVersion *int64, profileImage *string, profileName *string, contact *string, introduceContent *string) bool {
+
+	prevProfileImage, err := aboutRepository.UpdateAboutIfMatch(expectedVersion, profileImage, profileName, contact, introduceContent)
+	if err != nil {
+		if respondVersionConflict(c, err) {
+			return false
+		}
+
+		errorMessage := fmt.Sprintf("about update error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return false
+	}
+
+	if prevProfileImage != nil && len(*prevProfileImage) > 0 {
+		reletivePath := strings.Replace(*prevProfileImage, "/images", "./assets/images", 1)
+		absPath, err := filepath.Abs(reletivePath)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			os.Remove(absPath)
+		}
+	}
+
+	aboutModel, err := aboutRepository.GetAbout()
+	if err != nil {
+		errorMessage := fmt.Sprintf("get about error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return true
+	}
+
+	responseAbout := convertResponseAbout(aboutModel, nil)
+	responsePresent, err := SuccessResponsePresent(c, responseAbout)
+	if err != nil {
+		errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return true
+	}
+
+	c.JSON(http.StatusOK, responsePresent)
+	return true
+}
+
 //func convertResponseAboutHistories(aboutHistoryModel []models.AboutHistoryModel) *
 
 func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
@@ -62,14 +165,19 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		aboutModel, err := aboutRepository.GetAbout()
 		if err != nil {
 			errorMessage := fmt.Sprintf("get about error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
 		aboutHistoryModels, err := aboutRepository.GetHistory()
 		if err != nil {
 			errorMessage := fmt.Sprintf("get about history error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.Header("ETag", versionETag(aboutModel.Version))
+		if respondNotModified(c, versionETag(aboutModel.Version)) {
 			return
 		}
 
@@ -77,7 +185,7 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		responsePresent, err := SuccessResponsePresent(c, responseAbout)
 		if err != nil {
 			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -87,7 +195,14 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 	api.POST("/about", func(c *gin.Context) {
 
 		var reqAbout RequestUpdateAbout
-		c.ShouldBindJSON(&reqAbout)
+		if !bindJSON(c, &reqAbout) {
+			return
+		}
+
+		expectedVersion, ok := aboutIfMatch(c, aboutRepository, true)
+		if !ok {
+			return
+		}
 
 		complete := false
 
@@ -117,56 +232,77 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 			}(&complete)
 		}
 
-		prevProfileImage, err := aboutRepository.UpdateAbout(storeImageUrl, reqAbout.ProfileName, reqAbout.Contact, reqAbout.IntroduceContent)
+		complete = updateAbout(c, expectedVersion, storeImageUrl, reqAbout.ProfileName, reqAbout.Contact, reqAbout.IntroduceContent)
+	})
+
+	// 수정 (JSON Merge Patch)
+	// null 이면 지운다. profile_image 는 null 로 지우는 것만 가능하다.
+	api.PATCH("/about", func(c *gin.Context) {
+
+		expectedVersion, ok := aboutIfMatch(c, aboutRepository, false)
+		if !ok {
+			return
+		}
+
+		aboutModel, err := aboutRepository.GetAbout()
 		if err != nil {
-			errorMessage := fmt.Sprintf("about update error [%v]", err)
+			errorMessage := fmt.Sprintf("get about error [%v]", err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
-		if prevProfileImage != nil {
-			reletivePath := strings.Replace(*prevProfileImage, "/images", "./assets/images", 1)
-			absPath, err := filepath.Abs(reletivePath)
-			if err != nil {
-				fmt.Println(err)
-			} else {
-				os.Remove(absPath)
+		prevPatchAbout := newPatchAbout(aboutModel)
+
+		var patchAbout PatchAbout
+		if !bindMergePatch(c, prevPatchAbout, &patchAbout) {
+			return
+		}
+
+		var profileImage *string
+		if patchAbout.ProfileImage != prevPatchAbout.ProfileImage {
+			if len(patchAbout.ProfileImage) > 0 {
+				respondValidationFailed(c, ResponseFieldError{Field: "profile_image", Message: "can only be removed (null)"})
+				return
 			}
+
+			profileImage = &patchAbout.ProfileImage
 		}
 
-		// 여기까지 오면 성공으로 간주한다.
-		complete = true
+		var profileName, contact, introduceContent *string
+		if patchAbout.ProfileName != prevPatchAbout.ProfileName {
+			profileName = &patchAbout.ProfileName
+		}
 
-		aboutModel, err := aboutRepository.GetAbout()
-		if err != nil {
-			errorMessage := fmt.Sprintf("get about error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
-			return
+		if patchAbout.Contact != prevPatchAbout.Contact {
+			contact = &patchAbout.Contact
 		}
 
-		responseAbout := convertResponseAbout(aboutModel, nil)
-		responsePresent, err := SuccessResponsePresent(c, responseAbout)
-		if err != nil {
-			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
-			return
+		if patchAbout.IntroduceContent != prevPatchAbout.IntroduceContent {
+			introduceContent = &patchAbout.IntroduceContent
 		}
 
-		c.JSON(http.StatusOK, responsePresent)
+		updateAbout(c, expectedVersion, profileImage, profileName, contact, introduceContent)
 	})
 
 	api.POST("/about-history", func(c *gin.Context) {
 
 		var reqAboutHistory RequestUpdateAboutHistory
-		c.ShouldBindJSON(&reqAboutHistory)
+		if !bindJSON(c, &reqAboutHistory) {
+			return
+		}
+
+		expectedVersion, ok := aboutIfMatch(c, aboutRepository, true)
+		if !ok {
+			return
+		}
 
 		// 추가되는 내용
 		addHistoryInfos := make([]models.AboutHistoryContent, 0)
 		for _, responseAddAboutHistory := range reqAboutHistory.AppendHistories {
-			addHistoryInfo := models.AboutHistoryContent{
-				Category: responseAddAboutHistory.Category,
-				Duration: responseAddAboutHistory.Duration,
-				Content:  responseAddAboutHistory.Content,
+			addHistoryInfo, err := parseAboutHistoryContent(&responseAddAboutHistory)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+				return
 			}
 
 			addHistoryInfos = append(addHistoryInfos, addHistoryInfo)
@@ -176,30 +312,36 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		updateHistoryInfos := make([]models.AboutHistoryIdContent, 0)
 		for _, responseUpdateAboutHistory := range reqAboutHistory.UpdateHistories {
 
+			historyContent, err := parseAboutHistoryContent(&responseUpdateAboutHistory.RequesAboutHistoryElement)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+				return
+			}
+
 			updateHistoryInfo := models.AboutHistoryIdContent{
-				Id: responseUpdateAboutHistory.Id,
-				AboutHistoryContent: models.AboutHistoryContent{
-					Category: responseUpdateAboutHistory.Category,
-					Duration: responseUpdateAboutHistory.Duration,
-					Content:  responseUpdateAboutHistory.Content,
-				},
+				Id:                  responseUpdateAboutHistory.Id,
+				AboutHistoryContent: historyContent,
 			}
 
 			updateHistoryInfos = append(updateHistoryInfos, updateHistoryInfo)
 		}
 
 		// repositoy 적용
-		err := aboutRepository.UpdateAboutHistory(reqAboutHistory.RemoveIds, updateHistoryInfos, addHistoryInfos)
+		err := aboutRepository.UpdateAboutHistoryIfMatch(expectedVersion, reqAboutHistory.RemoveIds, updateHistoryInfos, addHistoryInfos)
 		if err != nil {
+			if respondVersionConflict(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("update aboutHistory error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
 		aboutHistories, err := aboutRepository.GetHistory()
 		if err != nil {
 			errorMessage := fmt.Sprintf("get about error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -207,10 +349,94 @@ func AboutApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		responsePresent, err := SuccessResponsePresent(c, responseAbout)
 		if err != nil {
 			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
 		c.JSON(http.StatusOK, responsePresent)
 	})
+
+	// history category 와 순서 (순서를 정하지 않은 category 도 포함)
+	api.GET("/about-history-categories", func(c *gin.Context) {
+		respondHistoryCategories(c)
+	})
+
+	// names 순서대로 category 를 보여준다. (names 에 없는 category 는 그 뒤에 처음 추가된 순서대로)
+	api.PUT("/about-history-categories", func(c *gin.Context) {
+
+		var requestCategoryOrder RequestAboutHistoryCategoryOrder
+		if !bindJSON(c, &requestCategoryOrder) {
+			return
+		}
+
+		err := models.ValidateHistoryCategoryNames(requestCategoryOrder.Names)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		expectedVersion, ok := aboutIfMatch(c, aboutRepository, false)
+		if !ok {
+			return
+		}
+
+		err = aboutRepository.SetHistoryCategoryOrderIfMatch(expectedVersion, requestCategoryOrder.Names)
+		if err != nil {
+			if respondVersionConflict(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("SetHistoryCategoryOrder error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		respondHistoryCategories(c)
+	})
+}
+
+// about 수정은 potofolio, essay 처럼 If-Match 가 필요하다.
+// isLegacy 이면 기존 frontend 를 위해 /api 에서는 If-Match 를 보냈을 때만 확인한다. (/api/v2 는 필요)
+func aboutIfMatch(c *gin.Context, aboutRepository *models.AboutRepository, isLegacy bool) (*int64, bool) {
+
+	if isLegacy && !isApiV2(c) {
+		return optionalIfMatch(c)
+	}
+
+	aboutModel, err := aboutRepository.GetAbout()
+	if err != nil {
+		errorMessage := fmt.Sprintf("get about error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return nil, false
+	}
+
+	return requireIfMatch(c, aboutModel.Version)
+}
+
+func respondHistoryCategories(c *gin.Context) {
+
+	categoryModels, err := aboutRepository.GetHistoryCategories()
+	if err != nil {
+		errorMessage := fmt.Sprintf("get about history category error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	categories := make([]ResponseAboutHistoryCategoryElement, 0)
+	for _, categoryModel := range categoryModels {
+		categories = append(categories, ResponseAboutHistoryCategoryElement{
+			Name:         categoryModel.Name,
+			DisplayOrder: categoryModel.DisplayOrder,
+			HistoryCount: categoryModel.HistoryCount,
+		})
+	}
+
+	responsePresent, err := SuccessResponsePresent(c, &ResponseAboutHistoryCategoryList{List: categories})
+	if err != nil {
+		errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	c.JSON(http.StatusOK, responsePresent)
 }
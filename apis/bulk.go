@@ -0,0 +1,130 @@
+package apis
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 작업 하나를 따로 요청했을 때 받았을 status code
+func bulkResultStatus(err error) int {
+
+	switch err.(type) {
+	case *models.PotofolioNotFoundError, *models.EssayNotFoundError, *models.TrashNotFoundError:
+		return http.StatusNotFound
+	case *models.VersionConflictError:
+		return http.StatusPreconditionFailed
+	case *models.BulkActionError:
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+func convertResponseBulk(results []models.BulkResultModel, atomic bool, committed bool) *ResponseBulk {
+
+	responseBulk := &ResponseBulk{
+		Atomic:    atomic,
+		Committed: committed,
+		Results:   make([]ResponseBulkResult, 0, len(results)),
+	}
+
+	for index, result := range results {
+
+		responseResult := ResponseBulkResult{
+			Index:  index,
+			Type:   result.Operation.Type.String(),
+			Id:     result.Operation.Id,
+			Action: string(result.Operation.Action),
+			Status: http.StatusOK,
+		}
+
+		if result.Err != nil {
+			responseResult.Status = bulkResultStatus(result.Err)
+			responseResult.Error = result.Err.Error()
+			responseBulk.Failed++
+		} else {
+			responseBulk.Succeeded++
+		}
+
+		if result.Err == nil && committed {
+			version := result.Version
+			responseResult.Applied = true
+			responseResult.Version = &version
+		}
+
+		responseBulk.Results = append(responseBulk.Results, responseResult)
+	}
+
+	return responseBulk
+}
+
+func BulkApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	// 여러 potofolio, essay 를 한 transaction 으로 삭제 (휴지통), 제목 변경, image 제거한다.
+	// 작업별 결과로 응답한다. (실패한 작업이 있어도 200)
+	api.POST("/bulk", func(c *gin.Context) {
+
+		var requestBulk RequestBulk
+		if !bindJSON(c, &requestBulk) {
+			return
+		}
+
+		operations := make([]models.BulkOperation, 0, len(requestBulk.Operations))
+		for index, requestOperation := range requestBulk.Operations {
+
+			action := models.BulkAction(requestOperation.Action)
+			if action == models.BulkActionRetitle && requestOperation.Title == nil {
+				respondValidationFailed(c, ResponseFieldError{Field: fmt.Sprintf("operations[%d].title", index), Message: "is required"})
+				return
+			}
+
+			// type 은 binding (oneof) 에서 확인했다.
+			repositoryType, err := models.ParseRepositoryType(requestOperation.Type)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+				return
+			}
+
+			operations = append(operations, models.BulkOperation{
+				Type:            repositoryType,
+				Id:              requestOperation.Id,
+				Action:          action,
+				ExpectedVersion: requestOperation.Version,
+				Title:           requestOperation.Title,
+				ImageIds:        requestOperation.ImageIds,
+			})
+		}
+
+		results, committed, err := repositoryConfigure.BulkRepository.ExecuteBulk(getAuthorName(c), operations, requestBulk.Atomic)
+		if err != nil {
+			errorMessage := fmt.Sprintf("bulk error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		for _, result := range results {
+			if result.Err != nil && bulkResultStatus(result.Err) == http.StatusInternalServerError {
+				log.Printf("[error] bulk %v %v [id:%v] [%v]\n", result.Operation.Action, result.Operation.Type, result.Operation.Id, result.Err)
+			}
+
+			// 파일 지우기 (반영되었을 때만)
+			if committed {
+				models.RemoveStoredImages("./assets/images", "/images", result.RemoveImagePaths)
+			}
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, convertResponseBulk(results, requestBulk.Atomic, committed))
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
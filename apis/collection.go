@@ -0,0 +1,312 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+var collectionRepository *models.CollectionRepository
+
+func convertResponseCollectionRefs(collectionRefModels []models.CollectionRefModel) []ResponseCollectionRef {
+
+	responseCollections := make([]ResponseCollectionRef, 0)
+	for _, collectionRefModel := range collectionRefModels {
+		responseCollections = append(responseCollections, ResponseCollectionRef{Id: collectionRefModel.Id, Title: collectionRefModel.Title})
+	}
+
+	return responseCollections
+}
+
+func convertResponseCollectionElement(collectionModel *models.CollectionModel) *ResponseCollectionElement {
+
+	return &ResponseCollectionElement{
+		Id:           collectionModel.Id,
+		Title:        collectionModel.Title,
+		Description:  collectionModel.Description,
+		CoverImage:   collectionModel.CoverImage,
+		PotofolioIds: collectionModel.PotofolioIds,
+		CreatedAt:    collectionModel.CreatedAt,
+		UpdatedAt:    collectionModel.UpdatedAt,
+	}
+}
+
+func respondCollectionError(c *gin.Context, err error) bool {
+
+	switch err.(type) {
+	case *models.CollectionNotFoundError:
+		c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+		return true
+	case *models.CollectionItemError:
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return true
+	}
+
+	return false
+}
+
+// cover image 가 있으면 저장한다.
+func storeCollectionCoverImage(requestCoverImage *RequestSaveImage) (*models.StoredImageInfo, error) {
+
+	if requestCoverImage == nil {
+		return nil, nil
+	}
+
+	requestSaveImageInfo := models.RequestSaveImageInfo{
+		Filename:   requestCoverImage.Filename,
+		Base64Data: requestCoverImage.Data,
+	}
+
+	return models.StorageImage("./assets/images", "/images", &requestSaveImageInfo)
+}
+
+// collection 과 그 안의 potofolio 목록 (정해진 순서대로)
+func findCollectionResponse(collectionId int64, statuses []models.PublishStatus) (*ResponseCollectionElement, error) {
+
+	collectionModel, err := collectionRepository.FindCollection(collectionId, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	responseCollection := convertResponseCollectionElement(collectionModel)
+	responseCollection.PotofolioList = make([]ResponsePotofolioElement, 0)
+
+	potofolioModels, err := potofolioRepository.FindPotofolios(collectionModel.PotofolioIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range potofolioModels {
+		responseCollection.PotofolioList = append(responseCollection.PotofolioList, *convertResponsePotofolioElement(&potofolioModels[i]))
+	}
+
+	return responseCollection, nil
+}
+
+func CollectionApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	collectionRepository = repositoryConfigure.CollectionRepository
+
+	// 로그인하지 않았으면 published potofolio 만 넣는다.
+	api.GET("/collections", func(c *gin.Context) {
+
+		statuses, err := parseListStatuses(c, repositoryConfigure)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		collectionModels, err := collectionRepository.GetCollectionList(statuses)
+		if err != nil {
+			errorMessage := fmt.Sprintf("get collection list error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		collections := make([]ResponseCollectionElement, 0)
+		for _, collectionModel := range collectionModels {
+			collections = append(collections, *convertResponseCollectionElement(&collectionModel))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponseCollectionList{List: collections})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.GET("/collections/:id", func(c *gin.Context) {
+		strCollectionId := c.Param("id")
+
+		id, err := strconv.ParseInt(strCollectionId, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strCollectionId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		statuses, err := parseListStatuses(c, repositoryConfigure)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		responseCollection, err := findCollectionResponse(id, statuses)
+		if err != nil {
+			if respondCollectionError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("collection find occur exception [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, responseCollection)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.POST("/collections", func(c *gin.Context) {
+
+		var requestCollection RequestCreateCollection
+		if !bindJSON(c, &requestCollection) {
+			return
+		}
+
+		storedCoverImage, err := storeCollectionCoverImage(requestCollection.CoverImage)
+		if err != nil {
+			errorMessage := fmt.Sprintf("cover image save error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		coverImage := ""
+		if storedCoverImage != nil {
+			coverImage = storedCoverImage.ImageUri
+		}
+
+		insertId, err := collectionRepository.AddCollection(requestCollection.Title, requestCollection.Description, coverImage, requestCollection.PotofolioIds)
+		if err != nil {
+			if storedCoverImage != nil {
+				os.Remove(storedCoverImage.ImageStorePath)
+			}
+
+			if respondCollectionError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("AddCollection error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responseCollection, err := findCollectionResponse(insertId, nil)
+		if err != nil {
+			errorMessage := fmt.Sprintf("AddCollection after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, responseCollection)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.PUT("/collections/:id", func(c *gin.Context) {
+		strCollectionId := c.Param("id")
+
+		id, err := strconv.ParseInt(strCollectionId, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strCollectionId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		var requestCollection RequestUpdateCollection
+		if !bindJSON(c, &requestCollection) {
+			return
+		}
+
+		storedCoverImage, err := storeCollectionCoverImage(requestCollection.CoverImage)
+		if err != nil {
+			errorMessage := fmt.Sprintf("cover image save error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		var coverImage *string
+		if storedCoverImage != nil {
+			coverImage = &storedCoverImage.ImageUri
+		}
+
+		removeCoverImage, err := collectionRepository.UpdateCollection(id, requestCollection.Title, requestCollection.Description, coverImage, requestCollection.PotofolioIds)
+		if err != nil {
+			if storedCoverImage != nil {
+				os.Remove(storedCoverImage.ImageStorePath)
+			}
+
+			if respondCollectionError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("UpdateCollection error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		if len(removeCoverImage) > 0 {
+			models.RemoveStoredImages("./assets/images", "/images", []string{removeCoverImage})
+		}
+
+		responseCollection, err := findCollectionResponse(id, nil)
+		if err != nil {
+			errorMessage := fmt.Sprintf("UpdateCollection after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, responseCollection)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	// collection 만 지우고 potofolio 는 그대로 둔다.
+	api.DELETE("/collections/:id", func(c *gin.Context) {
+		strCollectionId := c.Param("id")
+
+		id, err := strconv.ParseInt(strCollectionId, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strCollectionId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		coverImage, err := collectionRepository.RemoveCollection(id)
+		if err != nil {
+			if respondCollectionError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("RemoveCollection error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		if len(coverImage) > 0 {
+			models.RemoveStoredImages("./assets/images", "/images", []string{coverImage})
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, nil)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
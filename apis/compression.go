@@ -0,0 +1,366 @@
+package apis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// 압축한 응답을 받을 수 있는 client 와 아닌 client 가 다른 응답을 받는다.
+const varyAcceptEncoding = "Accept-Encoding"
+
+// gzip.Writer 처럼 다른 응답에 다시 사용할 수 있는 압축 writer
+type encodingWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(writer io.Writer)
+}
+
+// 응답할 때 압축하는 방식 (Accept-Encoding 의 q 가 같으면 앞쪽을 고른다.)
+type responseEncoder struct {
+	encoding string
+	pool     *sync.Pool // encodingWriter
+}
+
+var responseEncoders = []responseEncoder{
+	{
+		encoding: "br",
+		pool: &sync.Pool{New: func() interface{} {
+			return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression)
+		}},
+	},
+	{
+		encoding: "gzip",
+		pool: &sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(io.Discard)
+		}},
+	},
+}
+
+// 미리 압축한 정적 파일 (이름 뒤에 붙는 확장자)
+var precompressedExtensions = []struct {
+	encoding  string
+	extension string
+}{
+	{encoding: "br", extension: ".br"},
+	{encoding: "gzip", extension: ".gz"},
+}
+
+// 이미 압축된 형식 (image 등) 은 다시 압축하지 않는다.
+func isCompressibleContentType(contentType string) bool {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/javascript", "application/xml", "application/manifest+json", "image/svg+xml":
+		return true
+	}
+
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// Accept-Encoding 의 q 값을 읽는다. (없는 encoding 은 -1, * 가 있으면 * 의 q)
+func acceptEncodingQuality(acceptEncoding string, encoding string) float64 {
+
+	quality := -1.0
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				value, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				if err == nil {
+					q = value
+				}
+			}
+		}
+
+		if name == encoding {
+			quality = q
+		} else if name == "*" {
+			wildcard = q
+		}
+	}
+
+	if quality < 0 {
+		return wildcard
+	}
+
+	return quality
+}
+
+// encodings 중 client 가 받을 수 있는 것 (q 가 가장 큰 것, 같으면 앞쪽)
+// 받을 수 있는 것이 없으면 빈 문자열
+func negotiateEncoding(acceptEncoding string, encodings []string) string {
+
+	selected := ""
+	selectedQuality := 0.0
+	for _, encoding := range encodings {
+		quality := acceptEncodingQuality(acceptEncoding, encoding)
+		if quality > selectedQuality {
+			selected = encoding
+			selectedQuality = quality
+		}
+	}
+
+	return selected
+}
+
+func addVaryHeader(header http.Header, value string) {
+
+	for _, vary := range header.Values("Vary") {
+		for _, field := range strings.Split(vary, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return
+			}
+		}
+	}
+
+	header.Add("Vary", value)
+}
+
+// minSize 까지는 모아두었다가 넘으면 압축을 시작한다.
+// 첫 Write 때 Content-Type 등을 보고 압축하지 않을 응답이면 그대로 보낸다.
+type compressResponseWriter struct {
+	gin.ResponseWriter
+	encoder *responseEncoder
+	minSize int
+
+	decided     bool
+	passthrough bool
+	buffer      bytes.Buffer
+	writer      encodingWriter
+}
+
+func (writer *compressResponseWriter) decide() {
+
+	writer.decided = true
+
+	header := writer.Header()
+	status := writer.Status()
+
+	// 부분 응답 (206), 본문 없는 응답, 이미 압축한 응답 (미리 압축한 정적 파일)
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent || status == http.StatusNotModified ||
+		len(header.Get("Content-Encoding")) > 0 || !isCompressibleContentType(header.Get("Content-Type")) {
+		writer.passthrough = true
+		return
+	}
+
+	addVaryHeader(header, varyAcceptEncoding)
+
+	if writer.encoder == nil {
+		writer.passthrough = true
+	}
+}
+
+func (writer *compressResponseWriter) startEncoding() error {
+
+	header := writer.Header()
+	header.Set("Content-Encoding", writer.encoder.encoding)
+	header.Del("Content-Length")
+
+	// 단건 ETag 는 version (If-Match 에 사용) 이라 압축해도 그대로 둔다.
+	writer.writer = writer.encoder.pool.Get().(encodingWriter)
+	writer.writer.Reset(writer.ResponseWriter)
+
+	_, err := writer.writer.Write(writer.buffer.Bytes())
+	writer.buffer.Reset()
+
+	return err
+}
+
+func (writer *compressResponseWriter) Write(data []byte) (int, error) {
+
+	if !writer.decided {
+		writer.decide()
+	}
+
+	if writer.passthrough {
+		return writer.ResponseWriter.Write(data)
+	}
+
+	if writer.writer != nil {
+		return writer.writer.Write(data)
+	}
+
+	writer.buffer.Write(data)
+	if writer.buffer.Len() < writer.minSize {
+		return len(data), nil
+	}
+
+	err := writer.startEncoding()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// header 를 먼저 보내면 Content-Encoding 을 붙일 수 없으므로 압축할 응답이면 첫 Write 까지 미룬다. (ResponseV2Middleware)
+func (writer *compressResponseWriter) WriteHeaderNow() {
+
+	if !writer.decided {
+		writer.decide()
+	}
+
+	if writer.passthrough {
+		writer.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (writer *compressResponseWriter) WriteString(data string) (int, error) {
+	return writer.Write([]byte(data))
+}
+
+// minSize 보다 작으면 압축하지 않고 그대로 보낸다.
+func (writer *compressResponseWriter) finish() {
+
+	if writer.writer != nil {
+		writer.writer.Close()
+		writer.writer.Reset(io.Discard)
+		writer.encoder.pool.Put(writer.writer)
+		writer.writer = nil
+		return
+	}
+
+	if writer.buffer.Len() > 0 {
+		writer.ResponseWriter.Write(writer.buffer.Bytes())
+		writer.buffer.Reset()
+	}
+}
+
+func (writer *compressResponseWriter) Flush() {
+
+	if writer.writer != nil {
+		writer.writer.Flush()
+	}
+
+	writer.ResponseWriter.Flush()
+}
+
+// router 전체에 사용한다.
+// 압축할 수 있는 형식 (json, html, js, css ...) 이고 minSize byte 이상이면 Accept-Encoding 에 맞춰 압축한다.
+// minSize 가 0 이면 압축하지 않는다.
+func CompressionMiddleware(minSize int) gin.HandlerFunc {
+
+	encodings := make([]string, 0, len(responseEncoders))
+	for _, encoder := range responseEncoders {
+		encodings = append(encodings, encoder.encoding)
+	}
+
+	return func(c *gin.Context) {
+
+		if minSize <= 0 || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressResponseWriter{ResponseWriter: c.Writer, minSize: minSize}
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), encodings)
+		for i := range responseEncoders {
+			if responseEncoders[i].encoding == encoding {
+				writer.encoder = &responseEncoders[i]
+			}
+		}
+
+		c.Writer = writer
+
+		defer func() {
+			writer.finish()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// fileSystem 의 name 파일을 응답한다.
+// 같은 이름에 .br, .gz 를 붙인 파일이 있고 client 가 받을 수 있으면 그 파일을 그대로 보낸다. (Content-Type 은 원래 파일 기준)
+// 파일이 없거나 directory 이면 false 를 반환한다.
+func ServePrecompressedFile(c *gin.Context, fileSystem http.FileSystem, name string) bool {
+
+	file, stat, ok := openRegularFile(fileSystem, name)
+	if !ok {
+		return false
+	}
+
+	defer file.Close()
+
+	siblings := make(map[string]string)
+	encodings := make([]string, 0)
+	for _, precompressed := range precompressedExtensions {
+		sibling, _, ok := openRegularFile(fileSystem, name+precompressed.extension)
+		if !ok {
+			continue
+		}
+
+		sibling.Close()
+		siblings[precompressed.encoding] = name + precompressed.extension
+		encodings = append(encodings, precompressed.encoding)
+	}
+
+	header := c.Writer.Header()
+	if len(encodings) > 0 {
+		addVaryHeader(header, varyAcceptEncoding)
+	}
+
+	encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), encodings)
+	if len(encoding) > 0 {
+		encodedFile, encodedStat, ok := openRegularFile(fileSystem, siblings[encoding])
+		if ok {
+			defer encodedFile.Close()
+
+			contentType := mime.TypeByExtension(path.Ext(name))
+			if len(contentType) == 0 {
+				contentType = "application/octet-stream"
+			}
+
+			header.Set("Content-Type", contentType)
+			header.Set("Content-Encoding", encoding)
+			http.ServeContent(c.Writer, c.Request, name, encodedStat.ModTime(), encodedFile)
+			return true
+		}
+	}
+
+	http.ServeContent(c.Writer, c.Request, name, stat.ModTime(), file)
+	return true
+}
+
+func openRegularFile(fileSystem http.FileSystem, name string) (http.File, os.FileInfo, bool) {
+
+	file, err := fileSystem.Open(name)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
+		file.Close()
+		return nil, nil, false
+	}
+
+	return file, stat, true
+}
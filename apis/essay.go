@@ -4,9 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/thoas/go-funk"
@@ -20,8 +18,16 @@ func convertResponseEssayThumbailElement(essayModel *models.EssayThumnailModel)
 
 	return &ResponseEssayThumbnailElement{
 		Id:             essayModel.Id,
+		Slug:           essayModel.Slug,
 		Title:          essayModel.Title,
 		ThumbnailImage: essayModel.ThumbnailImage,
+		CreatedAt:      essayModel.CreatedAt,
+		UpdatedAt:      essayModel.UpdatedAt,
+		PublishedAt:    essayModel.PublishedAt,
+		Status:         string(essayModel.Status),
+		PublishAt:      essayModel.PublishAt,
+		Tags:           convertResponseTagElements(essayModel.Tags),
+		Featured:       essayModel.Featured,
 	}
 }
 
@@ -35,23 +41,177 @@ func convertResponseEssayElement(essayModel *models.EssayModel) *ResponseEssayEl
 
 	return &ResponseEssayElement{
 		Id:             essayModel.Id,
+		Slug:           essayModel.Slug,
 		Title:          essayModel.Title,
 		ThumbnailImage: essayModel.ThumbnailImage,
 		Images:         responseImages,
 		EssayContent:   essayModel.EssayContent,
+		Version:        essayModel.Version,
+		CreatedAt:      essayModel.CreatedAt,
+		UpdatedAt:      essayModel.UpdatedAt,
+		PublishedAt:    essayModel.PublishedAt,
+		Status:         string(essayModel.Status),
+		PublishAt:      essayModel.PublishAt,
+		Tags:           convertResponseTagElements(essayModel.Tags),
+		Featured:       essayModel.Featured,
 	}
 }
 
+// PUT, PATCH 에서 같이 사용한다.
+// 요청에 있는 항목만 바꾸고 수정된 essay 로 응답한다.
+func updateEssay(c *gin.Context, id int64, expectedVersion *int64, requestUpdateEssay *RequestUpdateEssay) {
+
+	complete := false
+
+	publishOption, err := parsePublishOption(requestUpdateEssay.Status, requestUpdateEssay.PublishAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return
+	}
+
+	err = models.ValidateTagNames(requestUpdateEssay.Tags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return
+	}
+
+	// thumbnail image update
+	// 이전 thumbnail 파일은 revision 에서 참조하므로 지우지 않는다.
+	var sotredThumbnailImagePath *models.StoredImageInfo
+	var storedthumbnailUrl *string
+	if requestUpdateEssay.NewThumbnail != nil {
+
+		requestSaveImageInfo := models.RequestSaveImageInfo{
+			Filename:   requestUpdateEssay.NewThumbnail.Filename,
+			Base64Data: requestUpdateEssay.NewThumbnail.Data,
+		}
+
+		sotredThumbnailImagePath, err = models.StorageImage("./assets/images", "/images", &requestSaveImageInfo)
+		if err != nil {
+			errorMessage := fmt.Sprintf("thumbnailImage save error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		storedthumbnailUrl = &sotredThumbnailImagePath.ImageUri
+	}
+
+	// 성공/실패 여부에 따른 Thumbnail 이미지 처리
+	defer func(isComplete *bool) {
+		if !*isComplete {
+			if sotredThumbnailImagePath != nil {
+				os.Remove(sotredThumbnailImagePath.ImageStorePath)
+			}
+		}
+	}(&complete)
+
+	// images update
+	var storedImages []models.StoredImageInfo
+	var images []string
+	if requestUpdateEssay.AddImages != nil {
+
+		requestSaveImageInfos := make([]models.RequestSaveImageInfo, 0)
+		for _, reqImage := range requestUpdateEssay.AddImages {
+			requestSaveImageInfos = append(requestSaveImageInfos, models.RequestSaveImageInfo{Filename: reqImage.Filename, Base64Data: reqImage.Data})
+		}
+
+		storedImages, err = models.StorageImages("./assets/images", "/images", requestSaveImageInfos)
+		if err != nil {
+			errorMessage := fmt.Sprintf("image save error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		images = funk.Map(storedImages, func(e models.StoredImageInfo) string {
+			return e.ImageUri
+		}).([]string)
+	}
+
+	// 성공/실패 여부에 따른 Thumbnail 이미지 처리
+	defer func(isComplete *bool) {
+		if !*isComplete {
+			for _, sotredImage := range storedImages {
+				os.Remove(sotredImage.ImageStorePath)
+			}
+		}
+
+	}(&complete)
+
+	// 빠진 image 파일은 이전 revision 에서 참조하므로 essay 가 영구 삭제될 때 지운다.
+	_, err = essayRepository.UpdateEssayWithOption(getAuthorName(c),
+		id,
+		expectedVersion,
+		&models.ContentOption{Publish: publishOption, Slug: requestUpdateEssay.Slug, Tags: requestUpdateEssay.Tags, Featured: requestUpdateEssay.Featured},
+		requestUpdateEssay.Title,
+		storedthumbnailUrl,
+		requestUpdateEssay.EssayContent,
+		requestUpdateEssay.RemoveImageIds,
+		images)
+
+	if err != nil {
+		if respondVersionConflict(c, err) || respondSlugError(c, err) || respondContentNotFound(c, err) {
+			return
+		}
+
+		errorMessage := fmt.Sprintf("potofolioId = %d repository.UpdateEssay [err = %s]", id, err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	// 여기까지 오면 성공으로 간주한다.
+	complete = true
+
+	essayModel, err := essayRepository.FindEssay(id)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Update essay after error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	responseEssayElment := convertResponseEssayElement(essayModel)
+	responsePresent, err := SuccessResponsePresent(c, responseEssayElment)
+	if err != nil {
+		errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	c.JSON(http.StatusOK, responsePresent)
+}
+
 func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
 
 	essayRepository = repositoryConfigure.EssayRepository
 
 	api.GET("/essay", func(c *gin.Context) {
 
-		allEssayModels, err := essayRepository.GetEssayList()
+		pageOption, err := parseListPageOption(c, models.DefaultListSortOption())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		if pageOption.Sort.Key == models.SortByPosition {
+			errorMessage := fmt.Sprintf("essay can not be sorted by %s", models.SortByPosition)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		pageOption.Filter.Statuses, err = parseListStatuses(c, repositoryConfigure)
 		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		allEssayModels, nextCursor, err := essayRepository.GetEssayPage(pageOption)
+		if err != nil {
+			if _, ok := err.(*models.ListCursorError); ok {
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+				return
+			}
+
 			errorMessage := fmt.Sprintf("get essay list error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -62,11 +222,12 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 
 		essayList := &ResponseEssayList{}
 		essayList.List = essies
+		essayList.NextCursor = nextCursor
 
 		responsePresent, err := SuccessResponsePresent(c, essayList)
 		if err != nil {
 			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -76,26 +237,59 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 	api.GET("/essay/:id", func(c *gin.Context) {
 		strEssayId := c.Param("id")
 
+		// 숫자가 아니면 slug 로 찾는다.
+		redirected := false
 		id, err := strconv.Atoi(strEssayId)
 		if err != nil {
-			errorMessage := fmt.Sprintf("id is wroung (id = %v)", strEssayId)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
-			return
+			var essayId int64
+			essayId, redirected, err = essayRepository.ResolveEssaySlug(strEssayId)
+			if err != nil {
+				if respondSlugError(c, err) {
+					return
+				}
+
+				errorMessage := fmt.Sprintf("essay slug find occur exception [%v]", err)
+				c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+				return
+			}
+
+			id = int(essayId)
 		}
 
 		essayModel, err := essayRepository.FindEssay(int64(id))
 		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("essay find occur exception [%v]", err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
+		// 공개되지 않은 essay 는 없는 것으로 응답한다.
+		if !isVisibleContent(c, repositoryConfigure, essayModel.Status) {
+			errorMessage := fmt.Sprintf("essay not found [id:%v]", id)
+			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		if redirected {
+			redirectToSlug(c, essayModel.Slug)
+			return
+		}
+
+		c.Header("ETag", versionETag(essayModel.Version))
+		if respondNotModified(c, versionETag(essayModel.Version)) {
+			return
+		}
+
 		resEssayElement := convertResponseEssayElement(essayModel)
 
 		responsePresent, err := SuccessResponsePresent(c, resEssayElement)
 		if err != nil {
 			errorMessage := fmt.Sprintf("get one essay error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -105,7 +299,23 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 	api.POST("/essay", func(c *gin.Context) {
 
 		var reqCreateEssay RequestCreateEssay
-		c.ShouldBindJSON(&reqCreateEssay)
+		if !bindJSON(c, &reqCreateEssay) {
+			return
+		}
+
+		publishOption, err := parsePublishOption(reqCreateEssay.Status, reqCreateEssay.PublishAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		err = models.ValidateTagNames(reqCreateEssay.Tags)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		contentOption := &models.ContentOption{Publish: publishOption, Slug: reqCreateEssay.Slug, Tags: reqCreateEssay.Tags, Featured: reqCreateEssay.Featured}
 
 		requestThumbnailSaveImageInfo := models.RequestSaveImageInfo{
 			Filename:   reqCreateEssay.ThumbnailImage.Filename,
@@ -135,7 +345,9 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 			return e.ImageUri
 		})
 
-		insertId, err := essayRepository.AddEssay(
+		insertId, err := essayRepository.AddEssayWithOption(
+			getAuthorName(c),
+			contentOption,
 			reqCreateEssay.Title,
 			storedThumbnailImage.ImageUri,
 			reqCreateEssay.EssayContent,
@@ -143,6 +355,15 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		)
 
 		if err != nil {
+			os.Remove(storedThumbnailImage.ImageStorePath)
+			for _, storedImage := range storedImages {
+				os.Remove(storedImage.ImageStorePath)
+			}
+
+			if respondSlugError(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("AddPotofolio error [%v]", err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
@@ -168,141 +389,73 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 
 	api.PUT("essay/:id", func(c *gin.Context) {
 
-		complete := false
-
 		strPotofolioId := c.Param("id")
 		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{
-				"result": "failed",
-				"error":  fmt.Sprintf("id is wroung (id = %s)", strPotofolioId),
-			})
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
 		prevEssayModel, err := essayRepository.FindEssay(int64(id))
 		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("essayId = %d [err = %s]", id, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
-		var requestUpdateEssay RequestUpdateEssay
-		c.ShouldBindJSON(&requestUpdateEssay)
-
-		// thumbnail image update
-		var prevThumbnailImagePath *string
-		var sotredThumbnailImagePath *models.StoredImageInfo
-		var storedthumbnailUrl *string
-		if requestUpdateEssay.NewThumbnail != nil {
-
-			prevThumbnailImagePath = new(string)
-			*prevThumbnailImagePath = prevEssayModel.GetThumbnailImagePath("./assets/images", "/image")
-
-			requestSaveImageInfo := models.RequestSaveImageInfo{
-				Filename:   requestUpdateEssay.NewThumbnail.Filename,
-				Base64Data: requestUpdateEssay.NewThumbnail.Data,
-			}
-
-			sotredThumbnailImagePath, err = models.StorageImage("./assets/images", "/images", &requestSaveImageInfo)
-			if err != nil {
-				errorMessage := fmt.Sprintf("thumbnailImage save error [%v]", err)
-				c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
-				return
-			}
-
-			storedthumbnailUrl = &sotredThumbnailImagePath.ImageUri
+		expectedVersion, ok := requireIfMatch(c, prevEssayModel.Version)
+		if !ok {
+			return
 		}
 
-		// 성공/실패 여부에 따른 Thumbnail 이미지 처리
-		defer func(isComplete *bool) {
-			if *isComplete {
-				if prevThumbnailImagePath != nil {
-					os.Remove(*prevThumbnailImagePath)
-				}
-			} else {
-				if sotredThumbnailImagePath != nil {
-					os.Remove(sotredThumbnailImagePath.ImageStorePath)
-				}
-			}
-		}(&complete)
-
-		// images update
-		var storedImages []models.StoredImageInfo
-		var images interface{}
-		if requestUpdateEssay.AddImages != nil {
-
-			requestSaveImageInfos := make([]models.RequestSaveImageInfo, 0)
-			for _, reqImage := range requestUpdateEssay.AddImages {
-				requestSaveImageInfos = append(requestSaveImageInfos, models.RequestSaveImageInfo{Filename: reqImage.Filename, Base64Data: reqImage.Data})
-			}
-
-			storedImages, err = models.StorageImages("./assets/images", "/images", requestSaveImageInfos)
-			if err != nil {
-				errorMessage := fmt.Sprintf("image save error [%v]", err)
-				c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
-				return
-			}
-
-			images = funk.Map(storedImages, func(e models.StoredImageInfo) string {
-				return e.ImageUri
-			})
+		var requestUpdateEssay RequestUpdateEssay
+		if !bindJSON(c, &requestUpdateEssay) {
+			return
 		}
 
-		// 성공/실패 여부에 따른 Thumbnail 이미지 처리
-		defer func(isComplete *bool) {
-			if !*isComplete {
-				for _, sotredImage := range storedImages {
-					os.Remove(sotredImage.ImageStorePath)
-				}
-			}
-
-		}(&complete)
+		updateEssay(c, int64(id), expectedVersion, &requestUpdateEssay)
+	})
 
-		removeImages, err := essayRepository.UpdateEssay(int64(id),
-			requestUpdateEssay.Title,
-			storedthumbnailUrl,
-			requestUpdateEssay.EssayContent,
-			requestUpdateEssay.RemoveImageIds,
-			images.([]string))
+	// 수정 (JSON Merge Patch, 이미지는 PUT 으로 바꾼다.)
+	api.PATCH("/essay/:id", func(c *gin.Context) {
 
+		strEssayId := c.Param("id")
+		id, err := strconv.Atoi(strEssayId)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{
-				"result": "failed",
-				"error":  fmt.Sprintf("potofolioId = %d repository.UpdateEssay [err = %s]", id, err),
-			})
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strEssayId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		// 파일 지우기
-		for _, removeImage := range removeImages {
-			reletivePath := strings.Replace(removeImage, "/images", "./assets/images", 1)
-			absPath, err := filepath.Abs(reletivePath)
-			if err != nil {
-				fmt.Println(err)
-				continue
+		prevEssayModel, err := essayRepository.FindEssay(int64(id))
+		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
 			}
 
-			os.Remove(absPath)
+			errorMessage := fmt.Sprintf("essayId = %d [err = %s]", id, err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		// 여기까지 오면 성공으로 간주한다.
-		complete = true
-
-		essayModel, err := essayRepository.FindEssay(int64(id))
-		if err != nil {
-			errorMessage := fmt.Sprintf("Update essay after error [%v]", err)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		expectedVersion, ok := requireIfMatch(c, prevEssayModel.Version)
+		if !ok {
 			return
 		}
 
-		responseEssayElment := convertResponseEssayElement(essayModel)
-		responsePresent, err := SuccessResponsePresent(c, responseEssayElment)
-		if err != nil {
-			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		prevPatchEssay := newPatchEssay(prevEssayModel)
+
+		var patchEssay PatchEssay
+		if !bindMergePatch(c, prevPatchEssay, &patchEssay) {
 			return
 		}
 
-		c.JSON(http.StatusOK, responsePresent)
+		updateEssay(c, int64(id), expectedVersion, patchEssay.updateRequest(prevPatchEssay))
 	})
 
 	api.DELETE("/essay/:id", func(c *gin.Context) {
@@ -311,19 +464,36 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
 			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		_, err = essayRepository.FindEssay(int64(id))
+		essayModel, err := essayRepository.FindEssay(int64(id))
 		if err != nil {
-			errorMessage := fmt.Sprintf("essay not found (id = %s)", strPotofolioId)
+			if respondContentNotFound(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("essay find occur exception (id = %s) [%v]", strPotofolioId, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		expectedVersion, ok := requireIfMatch(c, essayModel.Version)
+		if !ok {
+			return
 		}
 
-		err = essayRepository.RemoveEssay(int64(id))
+		// 휴지통으로 보낸다. (영구 삭제는 보관 기간이 지난 뒤 PurgeExpiredTrash 에서)
+		err = essayRepository.TrashEssay(int64(id), expectedVersion)
 		if err != nil {
-			errorMessage := fmt.Sprintf("essayRepository.RemoveEssay (id = %s) [%v]", strPotofolioId, err)
+			if respondVersionConflict(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("essayRepository.TrashEssay (id = %s) [%v]", strPotofolioId, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
 		responsePresent, err := SuccessResponsePresent(c, nil)
@@ -336,4 +506,5 @@ func EssayApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		c.JSON(http.StatusOK, responsePresent)
 	})
 
+	essayRevisionApis(api, repositoryConfigure)
 }
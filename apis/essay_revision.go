@@ -0,0 +1,187 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thoas/go-funk"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+func convertResponseEssayRevisionElement(revisionModel *models.EssayRevisionModel) *ResponseEssayRevisionElement {
+
+	return &ResponseEssayRevisionElement{
+		Revision:       revisionModel.Revision,
+		Title:          revisionModel.Title,
+		ThumbnailImage: revisionModel.ThumbnailImage,
+		Images:         revisionModel.Images,
+		Author:         revisionModel.Author,
+		RestoredFrom:   revisionModel.RestoredFrom,
+		CreatedAt:      revisionModel.CreatedAt,
+	}
+}
+
+func convertResponseEssayRevisionDiff(fromRevision *models.EssayRevisionModel, toRevision *models.EssayRevisionModel) *ResponseEssayRevisionDiff {
+
+	addedImages, removedImages := funk.DifferenceString(toRevision.Images, fromRevision.Images)
+
+	lines := make([]ResponseDiffLine, 0)
+	for _, lineDiff := range models.DiffLines(fromRevision.EssayContent, toRevision.EssayContent) {
+		lines = append(lines, ResponseDiffLine{Op: string(lineDiff.Op), Line: lineDiff.Line})
+	}
+
+	return &ResponseEssayRevisionDiff{
+		From:            fromRevision.Revision,
+		To:              toRevision.Revision,
+		TitleBefore:     fromRevision.Title,
+		TitleAfter:      toRevision.Title,
+		ThumbnailBefore: fromRevision.ThumbnailImage,
+		ThumbnailAfter:  toRevision.ThumbnailImage,
+		AddedImages:     addedImages,
+		RemovedImages:   removedImages,
+		Lines:           lines,
+	}
+}
+
+func findRevision(revisionModels []models.EssayRevisionModel, revision int64) *models.EssayRevisionModel {
+	for index := range revisionModels {
+		if revisionModels[index].Revision == revision {
+			return &revisionModels[index]
+		}
+	}
+
+	return nil
+}
+
+func essayRevisionApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	// ?from=1&to=3 을 주면 두 revision 의 차이도 같이 반환한다.
+	api.GET("/essay/:id/revisions", AuthorizeHandler(repositoryConfigure), func(c *gin.Context) {
+
+		strEssayId := c.Param("id")
+		id, err := strconv.Atoi(strEssayId)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strEssayId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		_, err = essayRepository.FindEssay(int64(id))
+		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("essay find occur exception [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		revisionModels, err := essayRepository.GetEssayRevisions(int64(id))
+		if err != nil {
+			errorMessage := fmt.Sprintf("get essay revisions error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		revisionList := &ResponseEssayRevisionList{}
+		revisionList.List = make([]ResponseEssayRevisionElement, 0)
+		for _, revisionModel := range revisionModels {
+			revisionList.List = append(revisionList.List, *convertResponseEssayRevisionElement(&revisionModel))
+		}
+
+		strFrom, hasFrom := c.GetQuery("from")
+		strTo, hasTo := c.GetQuery("to")
+		if hasFrom || hasTo {
+			from, fromErr := strconv.ParseInt(strFrom, 10, 64)
+			to, toErr := strconv.ParseInt(strTo, 10, 64)
+			if fromErr != nil || toErr != nil {
+				errorMessage := fmt.Sprintf("revision is wroung (from = %s, to = %s)", strFrom, strTo)
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+				return
+			}
+
+			fromRevision := findRevision(revisionModels, from)
+			toRevision := findRevision(revisionModels, to)
+			if fromRevision == nil || toRevision == nil {
+				errorMessage := fmt.Sprintf("revision not found (from = %d, to = %d)", from, to)
+				c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+				return
+			}
+
+			revisionList.Diff = convertResponseEssayRevisionDiff(fromRevision, toRevision)
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, revisionList)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.POST("/essay/:id/revisions/:rev/restore", func(c *gin.Context) {
+
+		strEssayId := c.Param("id")
+		id, err := strconv.Atoi(strEssayId)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strEssayId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		strRevision := c.Param("rev")
+		revision, err := strconv.ParseInt(strRevision, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("revision is wroung (rev = %s)", strRevision)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		expectedVersion, ok := optionalIfMatch(c)
+		if !ok {
+			return
+		}
+
+		err = essayRepository.RestoreEssayRevision(int64(id), revision, getAuthorName(c), expectedVersion)
+		if err != nil {
+			if respondVersionConflict(c, err) {
+				return
+			}
+
+			var revisionNotFoundErr *models.EssayRevisionNotFoundError
+			if errors.As(err, &revisionNotFoundErr) {
+				c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+				return
+			}
+
+			errorMessage := fmt.Sprintf("restore essay revision error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		essayModel, err := essayRepository.FindEssay(int64(id))
+		if err != nil {
+			errorMessage := fmt.Sprintf("restore essay after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.Header("ETag", versionETag(essayModel.Version))
+
+		responsePresent, err := SuccessResponsePresent(c, convertResponseEssayElement(essayModel))
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
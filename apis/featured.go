@@ -0,0 +1,132 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 순서 변경 요청이 잘못되었으면 400 으로 응답한다.
+func respondReorderError(c *gin.Context, err error) bool {
+
+	if reorderError, ok := err.(*models.ReorderError); ok {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(reorderError.Error()))
+		return true
+	}
+
+	return false
+}
+
+func FeaturedApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	// 첫 화면에 보여줄 potofolio (직접 정한 순서) 와 essay (최근 공개 순서)
+	// limit 은 각 목록에 따로 적용한다.
+	api.GET("/featured", func(c *gin.Context) {
+
+		featured := true
+		potofolioPageOption := models.ListPageOption{Sort: models.DefaultPotofolioSortOption()}
+		essayPageOption := models.ListPageOption{Sort: models.ListSortOption{Key: models.SortByPublished, Descending: true}}
+
+		if strLimit := c.Query("limit"); len(strLimit) > 0 {
+			limit, err := strconv.Atoi(strLimit)
+			if err != nil || limit < 1 || limit > maxListPageLimit {
+				errorMessage := fmt.Sprintf("limit is wrong (limit = %s, 1 ~ %d)", strLimit, maxListPageLimit)
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+				return
+			}
+
+			potofolioPageOption.Limit = limit
+			essayPageOption.Limit = limit
+		}
+
+		statuses, err := parseListStatuses(c, repositoryConfigure)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		potofolioPageOption.Filter = models.ListFilter{Featured: &featured, Statuses: statuses}
+		essayPageOption.Filter = models.ListFilter{Featured: &featured, Statuses: statuses}
+
+		potofolioModels, _, err := repositoryConfigure.PotofolioRepository.GetPotofolioPage(potofolioPageOption)
+		if err != nil {
+			errorMessage := fmt.Sprintf("get featured potofolio list error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		essayModels, _, err := repositoryConfigure.EssayRepository.GetEssayPage(essayPageOption)
+		if err != nil {
+			errorMessage := fmt.Sprintf("get featured essay list error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		featuredList := &ResponseFeaturedList{
+			PotofolioList: make([]ResponsePotofolioElement, 0),
+			EssayList:     make([]ResponseEssayThumbnailElement, 0),
+		}
+
+		for _, potofolioModel := range potofolioModels {
+			featuredList.PotofolioList = append(featuredList.PotofolioList, *convertResponsePotofolioElement(&potofolioModel))
+		}
+
+		for _, essayModel := range essayModels {
+			featuredList.EssayList = append(featuredList.EssayList, *convertResponseEssayThumbailElement(&essayModel))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, featuredList)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	// ids 를 주어진 순서대로 목록의 앞에 둔다. (나머지는 기존 순서대로 그 뒤에 둔다.)
+	api.PUT("/potofolio-order", func(c *gin.Context) {
+
+		var requestReorder RequestReorder
+		if !bindJSON(c, &requestReorder) {
+			return
+		}
+
+		err := repositoryConfigure.PotofolioRepository.ReorderPotofolios(requestReorder.Ids)
+		if err != nil {
+			if respondReorderError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("ReorderPotofolios error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		potofolioModels, err := repositoryConfigure.PotofolioRepository.GetPotofolioList()
+		if err != nil {
+			errorMessage := fmt.Sprintf("ReorderPotofolios after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		potofolios := make([]ResponsePotofolioElement, 0)
+		for _, potofolioModel := range potofolioModels {
+			potofolios = append(potofolios, *convertResponsePotofolioElement(&potofolioModel))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponsePotofolioList{List: potofolios})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
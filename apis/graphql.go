@@ -0,0 +1,472 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 조회만 하는 GraphQL (문서 읽기, 확인, 실행은 graphql-go)
+// schema 와 resolver 는 graphql_schema.go 참고
+const (
+	graphqlMaxQueryLength = 20000
+
+	// 가장 깊은 field 의 깊이 (최상위 field 가 1)
+	graphqlMaxDepth = 8
+
+	// field 마다 1, 목록 field 의 하위 field 는 목록 개수 (first) 만큼 곱한다.
+	graphqlMaxComplexity = 5000
+)
+
+// source 에서 json 이름이 name 인 field 를 찾는다. (embedded struct 포함)
+func graphqlSourceField(source interface{}, name string) (interface{}, error) {
+
+	reflectValue := reflect.ValueOf(source)
+	for reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return nil, nil
+		}
+
+		reflectValue = reflectValue.Elem()
+	}
+
+	if reflectValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can not read %s from %T", name, source)
+	}
+
+	fieldValue, ok := graphqlStructField(reflectValue, name)
+	if !ok {
+		return nil, fmt.Errorf("can not read %s from %T", name, source)
+	}
+
+	return fieldValue.Interface(), nil
+}
+
+func graphqlStructField(reflectValue reflect.Value, name string) (reflect.Value, bool) {
+
+	reflectType := reflectValue.Type()
+	for index := 0; index < reflectType.NumField(); index++ {
+
+		structField := reflectType.Field(index)
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			if fieldValue, ok := graphqlStructField(reflectValue.Field(index), name); ok {
+				return fieldValue, true
+			}
+			continue
+		}
+
+		if len(structField.PkgPath) > 0 {
+			continue
+		}
+
+		if strings.SplitN(structField.Tag.Get("json"), ",", 2)[0] == name {
+			return reflectValue.Field(index), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// 요청 하나의 실행 상태 (resolver 는 context 에서 꺼낸다.)
+type graphqlExecution struct {
+	c                   *gin.Context
+	repositoryConfigure *models.RepositoryConfigure
+
+	// resolver 가 같은 요청 안에서 다시 조회하지 않도록 둔다.
+	cache *graphqlContentCache
+}
+
+type graphqlExecutionKey struct{}
+
+func graphqlExecutionFrom(p graphql.ResolveParams) *graphqlExecution {
+	return p.Context.Value(graphqlExecutionKey{}).(*graphqlExecution)
+}
+
+func graphqlLocation(node *ast.Location) []ResponseGraphqlLocation {
+
+	if node == nil {
+		return nil
+	}
+
+	sourceLocation := location.GetLocation(node.Source, node.Start)
+	return []ResponseGraphqlLocation{{Line: sourceLocation.Line, Column: sourceLocation.Column}}
+}
+
+// 실행 전에 깊이와 complexity 를 센다. (문서는 graphql-go 가 확인한 뒤)
+type graphqlLimitChecker struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+
+	complexity int
+	errors     []ResponseGraphqlError
+}
+
+func (checker *graphqlLimitChecker) addError(message string, node *ast.Location) {
+	checker.errors = append(checker.errors, ResponseGraphqlError{Message: message, Locations: graphqlLocation(node)})
+}
+
+// 요청한 first (값, 변수) 가 있으면 그 개수, 없으면 기본 개수
+func (checker *graphqlLimitChecker) listCount(field *ast.Field, defaultCount int) int {
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+
+		var value interface{}
+		switch argumentValue := argument.Value.(type) {
+		case *ast.IntValue:
+			value = argumentValue.Value
+		case *ast.Variable:
+			value = checker.variables[argumentValue.Name.Value]
+		}
+
+		var first int
+		switch number := value.(type) {
+		case string:
+			first, _ = strconv.Atoi(number)
+		case float64:
+			first = int(number)
+		case int:
+			first = number
+		}
+
+		if first > 0 {
+			return first
+		}
+	}
+
+	return defaultCount
+}
+
+func (checker *graphqlLimitChecker) fieldDefinition(objectType *graphql.Object, name string) *graphql.FieldDefinition {
+
+	switch name {
+	case graphql.SchemaMetaFieldDef.Name:
+		return graphql.SchemaMetaFieldDef
+	case graphql.TypeMetaFieldDef.Name:
+		return graphql.TypeMetaFieldDef
+	}
+
+	return objectType.Fields()[name]
+}
+
+// selection 의 complexity 를 반환한다. 최대를 넘으면 더 보지 않는다.
+func (checker *graphqlLimitChecker) selectionSet(objectType *graphql.Object, selectionSet *ast.SelectionSet, depth int) int {
+
+	if selectionSet == nil {
+		return 0
+	}
+
+	complexity := 0
+	for _, selection := range selectionSet.Selections {
+
+		if len(checker.errors) > 0 || checker.complexity+complexity > graphqlMaxComplexity {
+			break
+		}
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			complexity += checker.field(objectType, selection, depth)
+
+		// schema 에 interface, union 이 없으므로 fragment 는 objectType 에 펼쳐진다.
+		case *ast.FragmentSpread:
+			if fragment, ok := checker.fragments[selection.Name.Value]; ok {
+				complexity += checker.selectionSet(objectType, fragment.SelectionSet, depth)
+			}
+
+		case *ast.InlineFragment:
+			complexity += checker.selectionSet(objectType, selection.SelectionSet, depth)
+		}
+	}
+
+	return complexity
+}
+
+func (checker *graphqlLimitChecker) field(objectType *graphql.Object, field *ast.Field, depth int) int {
+
+	if depth > graphqlMaxDepth {
+		checker.addError(fmt.Sprintf("query is too deep (max depth %d)", graphqlMaxDepth), field.Loc)
+		return 1
+	}
+
+	definition := checker.fieldDefinition(objectType, field.Name.Value)
+	if definition == nil {
+		return 1
+	}
+
+	childType, isObject := graphql.GetNamed(definition.Type).(*graphql.Object)
+	if !isObject {
+		return 1
+	}
+
+	multiplier := 1
+	if defaultCount, ok := graphqlListFieldCounts[objectType.Name()+"."+definition.Name]; ok {
+		multiplier = checker.listCount(field, defaultCount)
+	}
+
+	return 1 + multiplier*checker.selectionSet(childType, field.SelectionSet, depth+1)
+}
+
+// 요청한 operation (operationName 이 없으면 하나만 있어야 한다.)
+func graphqlOperation(document *ast.Document, operationName string) (*ast.OperationDefinition, error) {
+
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+
+		documentOperation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if len(operationName) == 0 || (documentOperation.Name != nil && documentOperation.Name.Value == operationName) {
+			if operation != nil {
+				return nil, fmt.Errorf("operationName is required when document has several operations")
+			}
+
+			operation = documentOperation
+		}
+	}
+
+	if operation == nil {
+		return nil, fmt.Errorf("unknown operation %s", operationName)
+	}
+
+	return operation, nil
+}
+
+// 변수 기본값 (query($first: Int = 5)) 도 complexity 에 사용한다.
+func graphqlVariables(operation *ast.OperationDefinition, input map[string]interface{}) map[string]interface{} {
+
+	variables := make(map[string]interface{})
+	for _, definition := range operation.VariableDefinitions {
+		name := definition.Variable.Name.Value
+		if value, ok := input[name]; ok {
+			variables[name] = value
+		} else if defaultValue, ok := definition.DefaultValue.(*ast.IntValue); ok {
+			variables[name] = defaultValue.Value
+		}
+	}
+
+	return variables
+}
+
+func convertResponseGraphqlErrors(formattedErrors []gqlerrors.FormattedError) []ResponseGraphqlError {
+
+	responseErrors := make([]ResponseGraphqlError, 0, len(formattedErrors))
+	for _, formattedError := range formattedErrors {
+
+		responseError := ResponseGraphqlError{Message: formattedError.Message, Path: formattedError.Path}
+		for _, sourceLocation := range formattedError.Locations {
+			responseError.Locations = append(responseError.Locations, ResponseGraphqlLocation{Line: sourceLocation.Line, Column: sourceLocation.Column})
+		}
+
+		responseErrors = append(responseErrors, responseError)
+	}
+
+	return responseErrors
+}
+
+func graphqlErrorResponse(message string, locations ...ResponseGraphqlLocation) *ResponseGraphql {
+	return &ResponseGraphql{Errors: []ResponseGraphqlError{{Message: message, Locations: locations}}}
+}
+
+// 문서가 잘못되었으면 (읽기, 확인, 변수 오류) 400 과 errors 만, 실행했으면 200 과 data (errors) 를 반환한다.
+func executeGraphql(c *gin.Context, repositoryConfigure *models.RepositoryConfigure, schema *graphql.Schema, requestGraphql *RequestGraphql) (int, *ResponseGraphql) {
+
+	if len(strings.TrimSpace(requestGraphql.Query)) == 0 {
+		return http.StatusBadRequest, graphqlErrorResponse("query is required")
+	}
+
+	if len(requestGraphql.Query) > graphqlMaxQueryLength {
+		return http.StatusBadRequest, graphqlErrorResponse(fmt.Sprintf("query is too long (max %d)", graphqlMaxQueryLength))
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(requestGraphql.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return http.StatusBadRequest, &ResponseGraphql{Errors: convertResponseGraphqlErrors(gqlerrors.FormatErrors(err))}
+	}
+
+	operation, err := graphqlOperation(document, requestGraphql.OperationName)
+	if err != nil {
+		return http.StatusBadRequest, graphqlErrorResponse(err.Error())
+	}
+
+	if operation.Operation != ast.OperationTypeQuery {
+		return http.StatusBadRequest, graphqlErrorResponse(fmt.Sprintf("%s is not supported (read only)", operation.Operation), graphqlLocation(operation.Loc)...)
+	}
+
+	// fragment 가 자기 자신을 펼치면 graphql-go 의 다른 확인 (OverlappingFieldsCanBeMerged) 이 끝나지 않아서 먼저 확인한다.
+	validationResult := graphql.ValidateDocument(schema, document, []graphql.ValidationRuleFn{graphql.NoFragmentCyclesRule})
+	if validationResult.IsValid {
+		validationResult = graphql.ValidateDocument(schema, document, nil)
+	}
+
+	if !validationResult.IsValid {
+		return http.StatusBadRequest, &ResponseGraphql{Errors: convertResponseGraphqlErrors(validationResult.Errors)}
+	}
+
+	checker := &graphqlLimitChecker{fragments: make(map[string]*ast.FragmentDefinition), variables: graphqlVariables(operation, requestGraphql.Variables)}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			checker.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	checker.complexity = checker.selectionSet(schema.QueryType(), operation.SelectionSet, 1)
+	if len(checker.errors) == 0 && checker.complexity > graphqlMaxComplexity {
+		checker.addError(fmt.Sprintf("query is too complex (max complexity %d)", graphqlMaxComplexity), operation.Loc)
+	}
+
+	if len(checker.errors) > 0 {
+		return http.StatusBadRequest, &ResponseGraphql{Errors: checker.errors}
+	}
+
+	execution := &graphqlExecution{c: c, repositoryConfigure: repositoryConfigure, cache: newGraphqlContentCache()}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        *schema,
+		AST:           document,
+		OperationName: requestGraphql.OperationName,
+		Args:          requestGraphql.Variables,
+		Context:       context.WithValue(c.Request.Context(), graphqlExecutionKey{}, execution),
+	})
+
+	responseErrors := convertResponseGraphqlErrors(result.Errors)
+
+	// 변수 오류는 field 를 실행하기 전에 나서 path 가 없다.
+	if result.Data == nil && len(responseErrors) > 0 && len(responseErrors[0].Path) == 0 {
+		return http.StatusBadRequest, &ResponseGraphql{Errors: responseErrors}
+	}
+
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		return http.StatusInternalServerError, graphqlErrorResponse(fmt.Sprintf("response error [%v]", err))
+	}
+
+	if len(responseErrors) == 0 {
+		responseErrors = nil
+	}
+
+	return http.StatusOK, &ResponseGraphql{Data: data, Errors: responseErrors}
+}
+
+// SDL 로 쓴다. (GET /api/graphql/schema)
+// graphql-go 에는 schema 를 쓰는 기능이 없다. type, field, argument 는 이름 순서
+func graphqlSchemaSdl(schema *graphql.Schema) string {
+
+	typeNames := make([]string, 0)
+	for name, namedType := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		if _, isObject := namedType.(*graphql.Object); isObject && name != schema.QueryType().Name() {
+			typeNames = append(typeNames, name)
+		}
+	}
+
+	sort.Strings(typeNames)
+	typeNames = append([]string{schema.QueryType().Name()}, typeNames...)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%q\nscalar %s\n", graphqlTime.Description(), graphqlTime.Name()))
+
+	for _, typeName := range typeNames {
+
+		fields := schema.Type(typeName).(*graphql.Object).Fields()
+
+		fieldNames := make([]string, 0, len(fields))
+		for name := range fields {
+			fieldNames = append(fieldNames, name)
+		}
+
+		sort.Strings(fieldNames)
+
+		builder.WriteString(fmt.Sprintf("\ntype %s {\n", typeName))
+		for _, fieldName := range fieldNames {
+			field := fields[fieldName]
+			builder.WriteString("  " + field.Name)
+
+			if len(field.Args) > 0 {
+				arguments := make([]string, 0, len(field.Args))
+				for _, argument := range field.Args {
+					text := argument.Name() + ": " + argument.Type.String()
+					if argument.DefaultValue != nil {
+						defaultValue, _ := json.Marshal(argument.DefaultValue)
+						text += " = " + string(defaultValue)
+					}
+					arguments = append(arguments, text)
+				}
+
+				sort.Strings(arguments)
+				builder.WriteString("(" + strings.Join(arguments, ", ") + ")")
+			}
+
+			builder.WriteString(": " + field.Type.String() + "\n")
+		}
+		builder.WriteString("}\n")
+	}
+
+	return builder.String()
+}
+
+func GraphqlApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	schema, err := newContentGraphqlSchema()
+	if err != nil {
+		panic(fmt.Sprintf("graphql schema error [%v]", err))
+	}
+
+	sdl := graphqlSchemaSdl(&schema)
+
+	// GET /api/graphql?query={about{profile_name}}&variables={"id":1}
+	api.GET("/graphql", func(c *gin.Context) {
+
+		requestGraphql := RequestGraphql{
+			Query:         c.Query("query"),
+			OperationName: c.Query("operationName"),
+		}
+
+		if strVariables := c.Query("variables"); len(strVariables) > 0 {
+			err := json.Unmarshal([]byte(strVariables), &requestGraphql.Variables)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, graphqlErrorResponse(fmt.Sprintf("variables is wrong [%v]", err)))
+				return
+			}
+		}
+
+		c.JSON(executeGraphql(c, repositoryConfigure, &schema, &requestGraphql))
+	})
+
+	api.POST("/graphql", func(c *gin.Context) {
+
+		var requestGraphql RequestGraphql
+		err := c.ShouldBindJSON(&requestGraphql)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, graphqlErrorResponse(fmt.Sprintf("request body is wrong [%v]", err)))
+			return
+		}
+
+		c.JSON(executeGraphql(c, repositoryConfigure, &schema, &requestGraphql))
+	})
+
+	api.GET("/graphql/schema", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(sdl))
+	})
+}
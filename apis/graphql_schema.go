@@ -0,0 +1,643 @@
+package apis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// potofolios, essays 의 first 기본값
+const graphqlDefaultListCount = 20
+
+// images(first:) 가 없을 때 complexity 에 곱할 image 개수
+const graphqlDefaultImageCount = 10
+
+// 같은 요청 안에서 다시 조회하지 않도록 둔다.
+type graphqlContentCache struct {
+	essays      map[int64]*models.EssayModel
+	essayImages *graphqlImageLoader
+	histories   []models.AboutHistoryModel
+}
+
+func newGraphqlContentCache() *graphqlContentCache {
+	return &graphqlContentCache{
+		essays:      make(map[int64]*models.EssayModel),
+		essayImages: &graphqlImageLoader{dependencyType: models.EssayType, images: make(map[int64][]models.ImageModel)},
+	}
+}
+
+// 목록에 있는 content 의 image 를 처음 요청될 때 한 번에 가져온다.
+type graphqlImageLoader struct {
+	dependencyType models.RepositoryType
+	pending        []int64
+	images         map[int64][]models.ImageModel
+}
+
+// 곧 image 를 요청할 수 있는 id (목록 조회)
+func (loader *graphqlImageLoader) expect(ids []int64) {
+	for _, id := range ids {
+		if _, ok := loader.images[id]; !ok {
+			loader.pending = append(loader.pending, id)
+		}
+	}
+}
+
+func (loader *graphqlImageLoader) prime(id int64, images []models.ImageModel) {
+	loader.images[id] = images
+}
+
+func (loader *graphqlImageLoader) load(imageRepository *models.ImageRepository, id int64) ([]models.ImageModel, error) {
+
+	if images, ok := loader.images[id]; ok {
+		return images, nil
+	}
+
+	ids := make([]int64, 0, len(loader.pending)+1)
+	ids = append(ids, id)
+	for _, pendingId := range loader.pending {
+		if _, ok := loader.images[pendingId]; !ok && pendingId != id {
+			ids = append(ids, pendingId)
+		}
+	}
+
+	imagesById, err := imageRepository.GetImagesByDependencyIds(loader.dependencyType, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for imageId, images := range imagesById {
+		loader.images[imageId] = images
+	}
+
+	loader.pending = nil
+
+	return loader.images[id], nil
+}
+
+func (execution *graphqlExecution) findEssay(essayId int64) (*models.EssayModel, error) {
+
+	if essayModel, ok := execution.cache.essays[essayId]; ok {
+		return essayModel, nil
+	}
+
+	essayModel, err := execution.repositoryConfigure.EssayRepository.FindEssay(essayId)
+	if err != nil {
+		return nil, err
+	}
+
+	execution.cache.essays[essayId] = essayModel
+	execution.cache.essayImages.prime(essayId, essayModel.Images)
+
+	return essayModel, nil
+}
+
+func (execution *graphqlExecution) getHistories() ([]models.AboutHistoryModel, error) {
+
+	if execution.cache.histories != nil {
+		return execution.cache.histories, nil
+	}
+
+	aboutHistoryModels, err := execution.repositoryConfigure.AboutRepository.GetHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	if aboutHistoryModels == nil {
+		aboutHistoryModels = make([]models.AboutHistoryModel, 0)
+	}
+
+	execution.cache.histories = aboutHistoryModels
+
+	return aboutHistoryModels, nil
+}
+
+// 로그인하지 않았으면 status 와 관계 없이 공개된 것만
+func (execution *graphqlExecution) listStatuses(args map[string]interface{}) ([]models.PublishStatus, error) {
+
+	if !isAuthorizedRequest(execution.c, execution.repositoryConfigure) {
+		return []models.PublishStatus{models.PublishStatusPublished}, nil
+	}
+
+	strStatuses, _ := args["status"].([]interface{})
+	if len(strStatuses) == 0 {
+		return nil, nil
+	}
+
+	statuses := make([]models.PublishStatus, 0, len(strStatuses))
+	for _, strStatus := range strStatuses {
+		status, err := models.ParsePublishStatus(strStatus.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// REST 목록 조회 query 와 같은 조건 (first 가 limit, after 가 cursor)
+func graphqlListPageOption(args map[string]interface{}, defaultSortOption models.ListSortOption) (models.ListPageOption, error) {
+
+	var pageOption models.ListPageOption
+
+	sort, _ := args["sort"].(string)
+	order, _ := args["order"].(string)
+	sortOption, err := models.ParseListSortOptionOrDefault(sort, order, defaultSortOption)
+	if err != nil {
+		return pageOption, err
+	}
+
+	pageOption.Sort = sortOption
+
+	// first: null 이면 기본 개수
+	first, ok := args["first"].(int)
+	if !ok {
+		first = graphqlDefaultListCount
+	}
+
+	if first < 1 || first > maxListPageLimit {
+		return pageOption, fmt.Errorf("first is wrong (first = %d, 1 ~ %d)", first, maxListPageLimit)
+	}
+
+	pageOption.Limit = first
+	pageOption.Cursor, _ = args["after"].(string)
+	pageOption.Filter.TitleContains, _ = args["title"].(string)
+	pageOption.Filter.Tag, _ = args["tag"].(string)
+
+	if featured, ok := args["featured"].(bool); ok {
+		pageOption.Filter.Featured = &featured
+	}
+
+	return pageOption, nil
+}
+
+// id 또는 slug 로 찾는다. (redirect 된 slug 도 찾는다.)
+func graphqlContentId(args map[string]interface{}, resolveSlug func(slug string) (int64, bool, error)) (int64, bool, error) {
+
+	if strId, ok := args["id"].(string); ok {
+		id, err := strconv.ParseInt(strId, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("id is wrong (id = %s)", strId)
+		}
+
+		return id, true, nil
+	}
+
+	slug, ok := args["slug"].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("id or slug is required")
+	}
+
+	id, _, err := resolveSlug(slug)
+	if err != nil {
+		if _, ok := err.(*models.SlugNotFoundError); ok {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+func graphqlSliceImages(images []models.ImageModel, args map[string]interface{}) []ResponseImage {
+
+	if first, ok := args["first"].(int); ok && first >= 0 && first < len(images) {
+		images = images[:first]
+	}
+
+	responseImages := make([]ResponseImage, 0, len(images))
+	for _, image := range images {
+		responseImages = append(responseImages, ResponseImage{Id: image.Id, ImageUrl: image.Path})
+	}
+
+	return responseImages
+}
+
+// 목록 source (REST 응답의 list 는 값이라 pointer 로 둔다.)
+type graphqlPotofolioList struct {
+	List       []*ResponsePotofolioElement `json:"list"`
+	NextCursor string                      `json:"next_cursor"`
+}
+
+type graphqlEssayList struct {
+	List       []*ResponseEssayThumbnailElement `json:"list"`
+	NextCursor string                           `json:"next_cursor"`
+}
+
+func resolveGraphqlAbout(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	aboutModel, err := execution.repositoryConfigure.AboutRepository.GetAbout()
+	if err != nil {
+		return nil, err
+	}
+
+	// history 는 요청될 때 가져온다.
+	return convertResponseAbout(aboutModel, nil), nil
+}
+
+func resolveGraphqlPotofolios(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	pageOption, err := graphqlListPageOption(p.Args, models.DefaultPotofolioSortOption())
+	if err != nil {
+		return nil, err
+	}
+
+	pageOption.Filter.Statuses, err = execution.listStatuses(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	// image 는 목록 조회에서 한 번에 가져온다.
+	potofolioModels, nextCursor, err := execution.repositoryConfigure.PotofolioRepository.GetPotofolioPage(pageOption)
+	if err != nil {
+		return nil, err
+	}
+
+	potofolioList := &graphqlPotofolioList{List: make([]*ResponsePotofolioElement, 0, len(potofolioModels)), NextCursor: nextCursor}
+	for index := range potofolioModels {
+		potofolioList.List = append(potofolioList.List, convertResponsePotofolioElement(&potofolioModels[index]))
+	}
+
+	return potofolioList, nil
+}
+
+func resolveGraphqlPotofolio(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	potofolioRepository := execution.repositoryConfigure.PotofolioRepository
+
+	potofolioId, ok, err := graphqlContentId(p.Args, potofolioRepository.ResolvePotofolioSlug)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	potofolioModel, err := potofolioRepository.FindPotofolio(potofolioId)
+	if err != nil {
+		if _, ok := err.(*models.PotofolioNotFoundError); ok {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// 공개되지 않은 potofolio 는 없는 것으로 응답한다.
+	if !isVisibleContent(execution.c, execution.repositoryConfigure, potofolioModel.Status) {
+		return nil, nil
+	}
+
+	return convertResponsePotofolioElement(potofolioModel), nil
+}
+
+func resolveGraphqlEssays(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	pageOption, err := graphqlListPageOption(p.Args, models.DefaultListSortOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if pageOption.Sort.Key == models.SortByPosition {
+		return nil, fmt.Errorf("essay can not be sorted by %s", models.SortByPosition)
+	}
+
+	pageOption.Filter.Statuses, err = execution.listStatuses(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	essayModels, nextCursor, err := execution.repositoryConfigure.EssayRepository.GetEssayPage(pageOption)
+	if err != nil {
+		return nil, err
+	}
+
+	essayList := &graphqlEssayList{List: make([]*ResponseEssayThumbnailElement, 0, len(essayModels)), NextCursor: nextCursor}
+
+	essayIds := make([]int64, 0, len(essayModels))
+	for index := range essayModels {
+		essayList.List = append(essayList.List, convertResponseEssayThumbailElement(&essayModels[index]))
+		essayIds = append(essayIds, essayModels[index].Id)
+	}
+
+	execution.cache.essayImages.expect(essayIds)
+
+	return essayList, nil
+}
+
+func resolveGraphqlEssay(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	essayId, ok, err := graphqlContentId(p.Args, execution.repositoryConfigure.EssayRepository.ResolveEssaySlug)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	essayModel, err := execution.findEssay(essayId)
+	if err != nil {
+		if _, ok := err.(*models.EssayNotFoundError); ok {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// 공개되지 않은 essay 는 없는 것으로 응답한다.
+	if !isVisibleContent(execution.c, execution.repositoryConfigure, essayModel.Status) {
+		return nil, nil
+	}
+
+	return convertResponseEssayThumbailElement(&models.EssayThumnailModel{
+		Id:             essayModel.Id,
+		Slug:           essayModel.Slug,
+		Title:          essayModel.Title,
+		ThumbnailImage: essayModel.ThumbnailImage,
+		CreatedAt:      essayModel.CreatedAt,
+		UpdatedAt:      essayModel.UpdatedAt,
+		PublishedAt:    essayModel.PublishedAt,
+		Status:         essayModel.Status,
+		PublishAt:      essayModel.PublishAt,
+		Tags:           essayModel.Tags,
+		Featured:       essayModel.Featured,
+	}), nil
+}
+
+func resolveGraphqlPotofolioImages(p graphql.ResolveParams) (interface{}, error) {
+
+	images := p.Source.(*ResponsePotofolioElement).Images
+	if first, ok := p.Args["first"].(int); ok && first >= 0 && first < len(images) {
+		images = images[:first]
+	}
+
+	return images, nil
+}
+
+func resolveGraphqlEssayImages(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	images, err := execution.cache.essayImages.load(execution.repositoryConfigure.ImageRepository, p.Source.(*ResponseEssayThumbnailElement).Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlSliceImages(images, p.Args), nil
+}
+
+// 본문, version 은 목록에 없어서 essay 를 찾는다.
+func resolveGraphqlEssayDetail(read func(essayModel *models.EssayModel) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+
+		execution := graphqlExecutionFrom(p)
+
+		essayModel, err := execution.findEssay(p.Source.(*ResponseEssayThumbnailElement).Id)
+		if err != nil {
+			return nil, err
+		}
+
+		return read(essayModel), nil
+	}
+}
+
+func resolveGraphqlHistories(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	aboutHistoryModels, err := execution.getHistories()
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]ResponseAboutHistoryElement, 0, len(aboutHistoryModels))
+	for index := range aboutHistoryModels {
+		histories = append(histories, *convertResponseAboutHistoryElement(&aboutHistoryModels[index]))
+	}
+
+	return histories, nil
+}
+
+func resolveGraphqlHistoryGroups(p graphql.ResolveParams) (interface{}, error) {
+
+	execution := graphqlExecutionFrom(p)
+
+	aboutHistoryModels, err := execution.getHistories()
+	if err != nil {
+		return nil, err
+	}
+
+	// aboutHistoryModels 는 category 순서대로 정렬되어 있다.
+	historyGroups := make([]ResponseAboutHistoryGroup, 0)
+	for _, aboutHistoryGroupModel := range models.GroupHistories(aboutHistoryModels) {
+
+		responseGroup := ResponseAboutHistoryGroup{Category: aboutHistoryGroupModel.Category, Histories: make([]ResponseAboutHistoryElement, 0)}
+		for index := range aboutHistoryGroupModel.Histories {
+			responseGroup.Histories = append(responseGroup.Histories, *convertResponseAboutHistoryElement(&aboutHistoryGroupModel.Histories[index]))
+		}
+
+		historyGroups = append(historyGroups, responseGroup)
+	}
+
+	return historyGroups, nil
+}
+
+// RFC 3339 문자열로 응답한다. (입력으로는 받지 않는다.)
+var graphqlTime = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Time",
+	Description: "RFC 3339 형식의 시간",
+	Serialize: func(value interface{}) interface{} {
+		switch timeValue := value.(type) {
+		case time.Time:
+			return timeValue.Format(time.RFC3339)
+		case *time.Time:
+			if timeValue != nil {
+				return timeValue.Format(time.RFC3339)
+			}
+		}
+
+		return nil
+	},
+})
+
+// "[Image!]!"
+func graphqlNonNullList(itemType graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType)))
+}
+
+// Resolve 가 없는 field 는 source (struct) 에서 json 이름이 같은 field 를 읽는다.
+// graphql-go 의 기본 resolver 는 embedded struct (ResponsePotofolioMetadata) 를 읽지 못한다.
+func newGraphqlObject(name string, fields graphql.Fields) *graphql.Object {
+
+	for fieldName, field := range fields {
+		field.Name = fieldName
+		if field.Resolve == nil {
+			field.Resolve = resolveGraphqlSourceField
+		}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: fields})
+}
+
+func resolveGraphqlSourceField(p graphql.ResolveParams) (interface{}, error) {
+	return graphqlSourceField(p.Source, p.Info.FieldName)
+}
+
+func graphqlListArguments() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first":    {Type: graphql.Int, DefaultValue: graphqlDefaultListCount},
+		"after":    {Type: graphql.String},
+		"sort":     {Type: graphql.String},
+		"order":    {Type: graphql.String},
+		"title":    {Type: graphql.String},
+		"tag":      {Type: graphql.String},
+		"featured": {Type: graphql.Boolean},
+		"status":   {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	}
+}
+
+func graphqlImageArguments() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{"first": {Type: graphql.Int}}
+}
+
+func graphqlContentArguments() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{"id": {Type: graphql.ID}, "slug": {Type: graphql.String}}
+}
+
+// 목록을 반환하는 field ("type.field") 와 first 가 없을 때 하위 field 의 complexity 에 곱할 개수
+var graphqlListFieldCounts = map[string]int{
+	"Query.potofolios": graphqlDefaultListCount,
+	"Query.essays":     graphqlDefaultListCount,
+	"Potofolio.images": graphqlDefaultImageCount,
+	"Essay.images":     graphqlDefaultImageCount,
+}
+
+// field 이름은 REST 응답 json 이름과 같다.
+func newContentGraphqlSchema() (graphql.Schema, error) {
+
+	imageType := newGraphqlObject("Image", graphql.Fields{
+		"id":    {Type: graphql.NewNonNull(graphql.ID)},
+		"image": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	tagType := newGraphqlObject("Tag", graphql.Fields{
+		"id":   {Type: graphql.NewNonNull(graphql.ID)},
+		"name": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	collectionRefType := newGraphqlObject("CollectionRef", graphql.Fields{
+		"id":    {Type: graphql.NewNonNull(graphql.ID)},
+		"title": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	dimensionsType := newGraphqlObject("Dimensions", graphql.Fields{
+		"width":  {Type: graphql.NewNonNull(graphql.Float)},
+		"height": {Type: graphql.NewNonNull(graphql.Float)},
+		"depth":  {Type: graphql.NewNonNull(graphql.Float)},
+		"unit":   {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	creditType := newGraphqlObject("Credit", graphql.Fields{
+		"name": {Type: graphql.NewNonNull(graphql.String)},
+		"role": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	linkType := newGraphqlObject("Link", graphql.Fields{
+		"title": {Type: graphql.NewNonNull(graphql.String)},
+		"url":   {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	potofolioType := newGraphqlObject("Potofolio", graphql.Fields{
+		"id":           {Type: graphql.NewNonNull(graphql.ID)},
+		"slug":         {Type: graphql.NewNonNull(graphql.String)},
+		"title":        {Type: graphql.NewNonNull(graphql.String)},
+		"images":       {Type: graphqlNonNullList(imageType), Args: graphqlImageArguments(), Resolve: resolveGraphqlPotofolioImages},
+		"version":      {Type: graphql.NewNonNull(graphql.Int)},
+		"created_at":   {Type: graphql.NewNonNull(graphqlTime)},
+		"updated_at":   {Type: graphql.NewNonNull(graphqlTime)},
+		"published_at": {Type: graphqlTime},
+		"status":       {Type: graphql.NewNonNull(graphql.String)},
+		"publish_at":   {Type: graphqlTime},
+		"tags":         {Type: graphqlNonNullList(tagType)},
+		"position":     {Type: graphql.NewNonNull(graphql.Int)},
+		"featured":     {Type: graphql.NewNonNull(graphql.Boolean)},
+		"collections":  {Type: graphqlNonNullList(collectionRefType)},
+		"year":         {Type: graphql.Int},
+		"medium":       {Type: graphql.NewNonNull(graphql.String)},
+		"dimensions":   {Type: dimensionsType},
+		"description":  {Type: graphql.NewNonNull(graphql.String)},
+		"credits":      {Type: graphqlNonNullList(creditType)},
+		"client":       {Type: graphql.NewNonNull(graphql.String)},
+		"links":        {Type: graphqlNonNullList(linkType)},
+	})
+
+	potofolioListType := newGraphqlObject("PotofolioList", graphql.Fields{
+		"list":        {Type: graphqlNonNullList(potofolioType)},
+		"next_cursor": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	essayType := newGraphqlObject("Essay", graphql.Fields{
+		"id":            {Type: graphql.NewNonNull(graphql.ID)},
+		"slug":          {Type: graphql.NewNonNull(graphql.String)},
+		"title":         {Type: graphql.NewNonNull(graphql.String)},
+		"thumbnail":     {Type: graphql.NewNonNull(graphql.String)},
+		"images":        {Type: graphqlNonNullList(imageType), Args: graphqlImageArguments(), Resolve: resolveGraphqlEssayImages},
+		"essay_content": {Type: graphql.NewNonNull(graphql.String), Resolve: resolveGraphqlEssayDetail(func(essayModel *models.EssayModel) interface{} { return essayModel.EssayContent })},
+		"version":       {Type: graphql.NewNonNull(graphql.Int), Resolve: resolveGraphqlEssayDetail(func(essayModel *models.EssayModel) interface{} { return essayModel.Version })},
+		"created_at":    {Type: graphql.NewNonNull(graphqlTime)},
+		"updated_at":    {Type: graphql.NewNonNull(graphqlTime)},
+		"published_at":  {Type: graphqlTime},
+		"status":        {Type: graphql.NewNonNull(graphql.String)},
+		"publish_at":    {Type: graphqlTime},
+		"tags":          {Type: graphqlNonNullList(tagType)},
+		"featured":      {Type: graphql.NewNonNull(graphql.Boolean)},
+	})
+
+	essayListType := newGraphqlObject("EssayList", graphql.Fields{
+		"list":        {Type: graphqlNonNullList(essayType)},
+		"next_cursor": {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	aboutHistoryType := newGraphqlObject("AboutHistory", graphql.Fields{
+		"id":         {Type: graphql.NewNonNull(graphql.ID)},
+		"category":   {Type: graphql.NewNonNull(graphql.String)},
+		"duration":   {Type: graphql.NewNonNull(graphql.String)},
+		"content":    {Type: graphql.NewNonNull(graphql.String)},
+		"start_date": {Type: graphql.NewNonNull(graphql.String)},
+		"end_date":   {Type: graphql.NewNonNull(graphql.String)},
+	})
+
+	aboutHistoryGroupType := newGraphqlObject("AboutHistoryGroup", graphql.Fields{
+		"category":     {Type: graphql.NewNonNull(graphql.String)},
+		"history_list": {Type: graphqlNonNullList(aboutHistoryType)},
+	})
+
+	aboutType := newGraphqlObject("About", graphql.Fields{
+		"profile_image":     {Type: graphql.NewNonNull(graphql.String)},
+		"profile_name":      {Type: graphql.NewNonNull(graphql.String)},
+		"contact":           {Type: graphql.NewNonNull(graphql.String)},
+		"introduce_content": {Type: graphql.NewNonNull(graphql.String)},
+		"version":           {Type: graphql.NewNonNull(graphql.Int)},
+		"history_list":      {Type: graphqlNonNullList(aboutHistoryType), Resolve: resolveGraphqlHistories},
+		"history_groups":    {Type: graphqlNonNullList(aboutHistoryGroupType), Resolve: resolveGraphqlHistoryGroups},
+	})
+
+	queryType := newGraphqlObject("Query", graphql.Fields{
+		"about":      {Type: graphql.NewNonNull(aboutType), Resolve: resolveGraphqlAbout},
+		"potofolios": {Type: graphql.NewNonNull(potofolioListType), Args: graphqlListArguments(), Resolve: resolveGraphqlPotofolios},
+		"potofolio":  {Type: potofolioType, Args: graphqlContentArguments(), Resolve: resolveGraphqlPotofolio},
+		"essays":     {Type: graphql.NewNonNull(essayListType), Args: graphqlListArguments(), Resolve: resolveGraphqlEssays},
+		"essay":      {Type: essayType, Args: graphqlContentArguments(), Resolve: resolveGraphqlEssay},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -0,0 +1,220 @@
+package apis
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+const contentRevisionContextKey = "contentRevision"
+
+// 로그인한 요청은 공개되지 않은 content 가 들어 있을 수 있어서 공유 캐시에 두지 않는다.
+const authorizedCacheControl = "private, no-cache"
+
+// 저장된 image 파일 이름은 sha256 이라 같은 이름으로 다른 내용이 저장되지 않는다. (models.StorageImage)
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+var storedImageNameRegex = regexp.MustCompile(`^[0-9a-f]{64}(\.[0-9A-Za-z]+)?$`)
+
+// 공개 content 전체의 변경 번호로 만든 ETag
+// 로그인 여부에 따라 응답이 달라서 같이 넣는다.
+func contentRevisionETag(revision *models.ContentRevisionModel, authorized bool) string {
+
+	scope := "p"
+	if authorized {
+		scope = "a"
+	}
+
+	return fmt.Sprintf("W/\"r%d-%s\"", revision.Revision, scope)
+}
+
+// If-None-Match 의 ETag 중 하나라도 같은가 (W/ 는 무시한다.)
+func etagMatches(ifNoneMatch string, etag string) bool {
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// If-Modified-Since 이후 바뀌지 않았는가 (header 가 없거나 잘못되었으면 false)
+func notModifiedSince(c *gin.Context, lastModified time.Time) bool {
+
+	ifModifiedSince, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// 단건 조회 (ETag 가 version) 에서 사용한다.
+// ETag 가 같고 If-Modified-Since 이후 다른 변경 (tag 이름 등) 도 없으면 304 로 응답한다.
+func respondNotModified(c *gin.Context, etag string) bool {
+
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if len(ifNoneMatch) == 0 || !etagMatches(ifNoneMatch, etag) {
+		return false
+	}
+
+	if len(c.GetHeader("If-Modified-Since")) > 0 {
+		value, ok := c.Get(contentRevisionContextKey)
+		if !ok || !notModifiedSince(c, value.(*models.ContentRevisionModel).UpdatedAt) {
+			return false
+		}
+	}
+
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// 응답 status code 가 정해질 때 캐시 header 를 붙인다.
+// handler 가 먼저 붙인 header (단건 ETag 등) 는 그대로 두고, 캐시하면 안되는 응답 (오류) 이면 캐시 header 를 뺀다.
+type cacheResponseWriter struct {
+	gin.ResponseWriter
+	headers map[string]string
+
+	// 이 status code 이상이면 캐시하지 않는다.
+	uncacheableStatus int
+
+	// gin 의 static handler 는 404 를 먼저 정했다가 파일을 찾으면 200 으로 다시 정한다.
+	isNoStore bool
+}
+
+func (writer *cacheResponseWriter) WriteHeader(statusCode int) {
+
+	header := writer.Header()
+	if statusCode < writer.uncacheableStatus {
+		if writer.isNoStore {
+			header.Del("Cache-Control")
+			writer.isNoStore = false
+		}
+
+		for key, value := range writer.headers {
+			if len(header.Get(key)) == 0 {
+				header.Set(key, value)
+			}
+		}
+	} else {
+		for key := range writer.headers {
+			header.Del(key)
+		}
+
+		header.Del("ETag")
+		header.Set("Cache-Control", "no-store")
+		writer.isNoStore = true
+	}
+
+	writer.ResponseWriter.WriteHeader(statusCode)
+}
+
+// "/essay=public;max-age=60" 을 route 와 Cache-Control 로 나눈다.
+// (명령행 값은 , 로 나뉘어서 Cache-Control 항목은 ; 로 구분한다.)
+// Cache-Control 이 비어 있으면 그 route 는 캐시 header 를 보내지 않는다.
+func ParseCacheControlPolicy(value string) (string, string, error) {
+
+	parts := strings.SplitN(value, "=", 2)
+	route := strings.TrimSpace(parts[0])
+	if len(parts) != 2 || !strings.HasPrefix(route, "/") {
+		return "", "", fmt.Errorf("cache control policy is wrong (policy = %s, /route=directive;directive)", value)
+	}
+
+	directives := make([]string, 0)
+	for _, directive := range strings.Split(parts[1], ";") {
+		directive = strings.TrimSpace(directive)
+		if len(directive) > 0 {
+			directives = append(directives, directive)
+		}
+	}
+
+	return route, strings.Join(directives, ", "), nil
+}
+
+// basePath (/api, /api/v2) group 에 사용한다.
+// CacheControlPolicies 에 있는 GET route 에 ETag, Last-Modified, Cache-Control 을 붙이고
+// 바뀐 content 가 없으면 handler 를 실행하지 않고 304 로 응답한다.
+func HttpCacheMiddleware(basePath string, repositoryConfigure *models.RepositoryConfigure) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		cacheControl, ok := repositoryConfigure.CacheControlPolicies[strings.TrimPrefix(c.FullPath(), basePath)]
+		if !ok || len(cacheControl) == 0 {
+			c.Next()
+			return
+		}
+
+		revision, err := repositoryConfigure.ContentRevisionRepository.GetContentRevision()
+		if err != nil {
+			log.Printf("[error] get content revision [%v]\n", err)
+			c.Next()
+			return
+		}
+
+		authorized := isAuthorizedRequest(c, repositoryConfigure)
+		if authorized {
+			cacheControl = authorizedCacheControl
+		}
+
+		etag := contentRevisionETag(revision, authorized)
+
+		c.Set(contentRevisionContextKey, revision)
+		c.Writer = &cacheResponseWriter{
+			ResponseWriter: c.Writer,
+			headers: map[string]string{
+				"Cache-Control": cacheControl,
+				"Vary":          "Cookie",
+				"Last-Modified": revision.UpdatedAt.UTC().Format(http.TimeFormat),
+				"ETag":          etag,
+			},
+			uncacheableStatus: http.StatusInternalServerError,
+		}
+
+		// If-None-Match 가 있으면 If-Modified-Since 는 보지 않는다.
+		ifNoneMatch := c.GetHeader("If-None-Match")
+		if (len(ifNoneMatch) > 0 && etagMatches(ifNoneMatch, etag)) || (len(ifNoneMatch) == 0 && notModifiedSince(c, revision.UpdatedAt)) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// /images 에 사용한다.
+// 저장된 image (이름이 sha256) 는 바뀌지 않으므로 다시 확인하지 않도록 한다.
+func ImmutableImageMiddleware() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		if !storedImageNameRegex.MatchString(path.Base(c.Request.URL.Path)) {
+			c.Next()
+			return
+		}
+
+		// 파일이 없으면 (404) 붙이지 않는다.
+		c.Writer = &cacheResponseWriter{
+			ResponseWriter:    c.Writer,
+			headers:           map[string]string{"Cache-Control": immutableCacheControl},
+			uncacheableStatus: http.StatusBadRequest,
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package apis
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+const maxListPageLimit = 100
+
+// 목록 조회 query (sort, order, limit, cursor, title, min_id, max_id, has_images, tag, featured) 를 읽는다.
+// sort 가 없으면 defaultSortOption 으로 정렬한다.
+func parseListPageOption(c *gin.Context, defaultSortOption models.ListSortOption) (models.ListPageOption, error) {
+
+	var pageOption models.ListPageOption
+
+	sortOption, err := models.ParseListSortOptionOrDefault(c.Query("sort"), c.Query("order"), defaultSortOption)
+	if err != nil {
+		return pageOption, err
+	}
+
+	pageOption.Sort = sortOption
+	pageOption.Cursor = c.Query("cursor")
+
+	if strLimit := c.Query("limit"); len(strLimit) > 0 {
+		limit, err := strconv.Atoi(strLimit)
+		if err != nil || limit < 1 || limit > maxListPageLimit {
+			return pageOption, fmt.Errorf("limit is wrong (limit = %s, 1 ~ %d)", strLimit, maxListPageLimit)
+		}
+
+		pageOption.Limit = limit
+	}
+
+	pageOption.Filter.TitleContains = c.Query("title")
+	pageOption.Filter.Tag = c.Query("tag")
+
+	pageOption.Filter.MinId, err = parseQueryInt64(c, "min_id")
+	if err != nil {
+		return pageOption, err
+	}
+
+	pageOption.Filter.MaxId, err = parseQueryInt64(c, "max_id")
+	if err != nil {
+		return pageOption, err
+	}
+
+	if strHasImages := c.Query("has_images"); len(strHasImages) > 0 {
+		hasImages, err := strconv.ParseBool(strHasImages)
+		if err != nil {
+			return pageOption, fmt.Errorf("has_images is wrong (has_images = %s)", strHasImages)
+		}
+
+		pageOption.Filter.HasImages = &hasImages
+	}
+
+	if strFeatured := c.Query("featured"); len(strFeatured) > 0 {
+		featured, err := strconv.ParseBool(strFeatured)
+		if err != nil {
+			return pageOption, fmt.Errorf("featured is wrong (featured = %s)", strFeatured)
+		}
+
+		pageOption.Filter.Featured = &featured
+	}
+
+	return pageOption, nil
+}
+
+func parseQueryInt64(c *gin.Context, key string) (*int64, error) {
+
+	strValue := c.Query(key)
+	if len(strValue) == 0 {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseInt(strValue, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is wrong (%s = %s)", key, key, strValue)
+	}
+
+	return &value, nil
+}
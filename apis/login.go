@@ -45,6 +45,72 @@ func CheckAuthentication(c *gin.Context) (bool, error) {
 	return true, nil
 }
 
+// access-token 의 사용자 이름 (revision 작성자 기록용)
+// 인증을 사용하지 않거나 사용자를 찾을 수 없으면 빈 문자열
+func getAuthorName(c *gin.Context) string {
+
+	accessToken, err := c.Cookie("access-token")
+	if err != nil || len(accessToken) == 0 {
+		return ""
+	}
+
+	// 만료된 token 이어도 이미 인증 middleware 를 통과했으므로 claims 만 사용한다.
+	userClaims, _ := models.ParseToken(accessToken)
+	if userClaims == nil || userRepository == nil {
+		return ""
+	}
+
+	userModel, err := userRepository.GetUserModel(userClaims.UserId)
+	if err != nil {
+		return ""
+	}
+
+	return userModel.UserName
+}
+
+// GET 요청은 vertifyTokenMiddleware 에서 인증 없이 통과되므로
+// 관리자만 볼 수 있는 GET route 에 개별로 붙인다.
+func AuthorizeHandler(repositoryConfigure *models.RepositoryConfigure) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		if !repositoryConfigure.IsCheckAuthorize {
+			c.Next()
+			return
+		}
+
+		isAuthentication, err := CheckAuthentication(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, FailedResponsePreset(err.Error()))
+			c.Abort()
+			return
+		}
+
+		if !isAuthentication {
+			c.JSON(http.StatusUnauthorized, FailedResponsePreset(""))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// 로그인 없이도 볼 수 있는 GET route 에서 관리자 요청인지 확인한다. (실패해도 요청은 계속 처리한다.)
+func isAuthorizedRequest(c *gin.Context, repositoryConfigure *models.RepositoryConfigure) bool {
+
+	if !repositoryConfigure.IsCheckAuthorize {
+		return true
+	}
+
+	isAuthentication, err := CheckAuthentication(c)
+	if err != nil {
+		return false
+	}
+
+	return isAuthentication
+}
+
 func LoginApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
 
 	userRepository = repositoryConfigure.UserRepository
@@ -53,13 +119,15 @@ func LoginApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 	api.POST("/login", func(c *gin.Context) {
 
 		var reqLogin RequestLogin
-		c.ShouldBindJSON(&reqLogin)
+		if !bindJSON(c, &reqLogin) {
+			return
+		}
 
 		userModel, err := userRepository.GetUserModelFromUserName(reqLogin.UserName)
 
 		if err != nil && !errors.Is(err, &models.UserNotExistError{}) {
 			errorMessage := fmt.Sprintf("login error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -94,7 +162,7 @@ func LoginApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfi
 		responsePresent, err := SuccessResponsePresent(c, responseLogin)
 		if err != nil {
 			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
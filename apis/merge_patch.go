@@ -0,0 +1,310 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// RFC 7396 JSON Merge Patch
+// patch 의 null 은 지우고, object 는 재귀로 합치고, 그 외 (배열 포함) 는 바꾼다.
+func applyMergePatch(target interface{}, patch interface{}) interface{} {
+
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = applyMergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+func decodeJSONWithNumber(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+// 현재 내용 (current) 에 요청 body 의 merge patch 를 적용해서 document 로 읽고 확인한다.
+// current 에 없는 항목은 바꿀 수 없다. 응답을 이미 보냈으면 false 를 반환한다.
+func bindMergePatch(c *gin.Context, current interface{}, document interface{}) bool {
+
+	contentType := c.ContentType()
+	if contentType != mergePatchContentType && contentType != binding.MIMEJSON {
+		errorMessage := fmt.Sprintf("content type is wrong (Content-Type = %s, %s)", contentType, mergePatchContentType)
+		c.JSON(http.StatusUnsupportedMediaType, FailedResponsePreset(errorMessage))
+		return false
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(fmt.Sprintf("request body is wrong [%v]", err)))
+		return false
+	}
+
+	var patch interface{}
+	err = decodeJSONWithNumber(body, &patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(fmt.Sprintf("request body is wrong [%v]", err)))
+		return false
+	}
+
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset("merge patch must be a json object"))
+		return false
+	}
+
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(fmt.Sprintf("merge patch target error [%v]", err)))
+		return false
+	}
+
+	var currentObject map[string]interface{}
+	err = decodeJSONWithNumber(currentBytes, &currentObject)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(fmt.Sprintf("merge patch target error [%v]", err)))
+		return false
+	}
+
+	fields := make([]ResponseFieldError, 0)
+	for key := range patchObject {
+		if _, ok := currentObject[key]; !ok {
+			fields = append(fields, ResponseFieldError{Field: key, Message: "cannot be patched"})
+		}
+	}
+
+	if len(fields) > 0 {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		respondValidationFailed(c, fields...)
+		return false
+	}
+
+	mergedBytes, err := json.Marshal(applyMergePatch(currentObject, patchObject))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(fmt.Sprintf("request body is wrong [%v]", err)))
+		return false
+	}
+
+	err = json.Unmarshal(mergedBytes, document)
+	if err == nil {
+		err = binding.Validator.ValidateStruct(document)
+	}
+
+	if err != nil {
+		respondBindError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func equalTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(*b)
+}
+
+func tagNames(tagModels []models.TagModel) []string {
+	names := make([]string, 0, len(tagModels))
+	for _, tagModel := range tagModels {
+		names = append(names, tagModel.Name)
+	}
+
+	return names
+}
+
+// null 이 된 목록은 빈 목록으로 바꿔서 모두 지운다.
+func emptyIfNil(names []string) []string {
+	if names == nil {
+		return make([]string, 0)
+	}
+
+	return names
+}
+
+func newPatchPotofolio(potofolioModel *models.PotofolioModel) *PatchPotofolio {
+
+	metadata := convertResponsePotofolioMetadata(&potofolioModel.Metadata)
+
+	patchPotofolio := &PatchPotofolio{
+		Title:       potofolioModel.Title,
+		Status:      string(potofolioModel.Status),
+		PublishAt:   potofolioModel.PublishAt,
+		Slug:        potofolioModel.Slug,
+		Tags:        tagNames(potofolioModel.Tags),
+		Featured:    potofolioModel.Featured,
+		Year:        metadata.Year,
+		Medium:      metadata.Medium,
+		Description: metadata.Description,
+		Client:      metadata.Client,
+		Credits:     make([]RequestCredit, 0),
+		Links:       make([]RequestLink, 0),
+	}
+
+	if metadata.Dimensions != nil {
+		patchPotofolio.Dimensions = (*RequestDimensions)(metadata.Dimensions)
+	}
+
+	for _, credit := range metadata.Credits {
+		patchPotofolio.Credits = append(patchPotofolio.Credits, RequestCredit(credit))
+	}
+
+	for _, link := range metadata.Links {
+		patchPotofolio.Links = append(patchPotofolio.Links, RequestLink(link))
+	}
+
+	return patchPotofolio
+}
+
+// prev 와 달라진 항목만 수정 요청으로 만든다.
+func (patchPotofolio *PatchPotofolio) updateRequest(prev *PatchPotofolio) *RequestUpdatePotofolio {
+
+	request := &RequestUpdatePotofolio{}
+
+	if patchPotofolio.Title != prev.Title {
+		request.Title = &patchPotofolio.Title
+	}
+
+	if patchPotofolio.Status != prev.Status || !equalTime(patchPotofolio.PublishAt, prev.PublishAt) {
+		request.Status = &patchPotofolio.Status
+		request.PublishAt = patchPotofolio.PublishAt
+	}
+
+	if patchPotofolio.Slug != prev.Slug {
+		request.Slug = &patchPotofolio.Slug
+	}
+
+	if !reflect.DeepEqual(patchPotofolio.Tags, prev.Tags) {
+		request.Tags = emptyIfNil(patchPotofolio.Tags)
+	}
+
+	if patchPotofolio.Featured != prev.Featured {
+		request.Featured = &patchPotofolio.Featured
+	}
+
+	// 작품 정보는 0, 빈 값으로 지운다. (RequestPotofolioMetadata 참고)
+	if !reflect.DeepEqual(patchPotofolio.Year, prev.Year) {
+		year := 0
+		if patchPotofolio.Year != nil {
+			year = *patchPotofolio.Year
+		}
+
+		request.Year = &year
+	}
+
+	if patchPotofolio.Medium != prev.Medium {
+		request.Medium = &patchPotofolio.Medium
+	}
+
+	if !reflect.DeepEqual(patchPotofolio.Dimensions, prev.Dimensions) {
+		request.Dimensions = &RequestDimensions{}
+		if patchPotofolio.Dimensions != nil {
+			request.Dimensions = patchPotofolio.Dimensions
+		}
+	}
+
+	if patchPotofolio.Description != prev.Description {
+		request.Description = &patchPotofolio.Description
+	}
+
+	if patchPotofolio.Client != prev.Client {
+		request.Client = &patchPotofolio.Client
+	}
+
+	if !reflect.DeepEqual(patchPotofolio.Credits, prev.Credits) {
+		request.Credits = make([]RequestCredit, 0)
+		request.Credits = append(request.Credits, patchPotofolio.Credits...)
+	}
+
+	if !reflect.DeepEqual(patchPotofolio.Links, prev.Links) {
+		request.Links = make([]RequestLink, 0)
+		request.Links = append(request.Links, patchPotofolio.Links...)
+	}
+
+	return request
+}
+
+func newPatchEssay(essayModel *models.EssayModel) *PatchEssay {
+	return &PatchEssay{
+		Title:        essayModel.Title,
+		EssayContent: essayModel.EssayContent,
+		Status:       string(essayModel.Status),
+		PublishAt:    essayModel.PublishAt,
+		Slug:         essayModel.Slug,
+		Tags:         tagNames(essayModel.Tags),
+		Featured:     essayModel.Featured,
+	}
+}
+
+// prev 와 달라진 항목만 수정 요청으로 만든다.
+func (patchEssay *PatchEssay) updateRequest(prev *PatchEssay) *RequestUpdateEssay {
+
+	request := &RequestUpdateEssay{}
+
+	if patchEssay.Title != prev.Title {
+		request.Title = &patchEssay.Title
+	}
+
+	if patchEssay.EssayContent != prev.EssayContent {
+		request.EssayContent = &patchEssay.EssayContent
+	}
+
+	if patchEssay.Status != prev.Status || !equalTime(patchEssay.PublishAt, prev.PublishAt) {
+		request.Status = &patchEssay.Status
+		request.PublishAt = patchEssay.PublishAt
+	}
+
+	if patchEssay.Slug != prev.Slug {
+		request.Slug = &patchEssay.Slug
+	}
+
+	if !reflect.DeepEqual(patchEssay.Tags, prev.Tags) {
+		request.Tags = emptyIfNil(patchEssay.Tags)
+	}
+
+	if patchEssay.Featured != prev.Featured {
+		request.Featured = &patchEssay.Featured
+	}
+
+	return request
+}
+
+func newPatchAbout(aboutModel *models.AboutModel) *PatchAbout {
+
+	responseAbout := convertResponseAbout(aboutModel, nil)
+
+	return &PatchAbout{
+		ProfileImage:     responseAbout.ProfileImage,
+		ProfileName:      responseAbout.ProfileName,
+		Contact:          responseAbout.Contact,
+		IntroduceContent: responseAbout.IntroduceContent,
+	}
+}
@@ -0,0 +1,605 @@
+package apis
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// OpenAPI 문서는 /api 에 등록된 route 와 아래 apiOperations 설명으로 만든다.
+// route 를 추가하면 apiOperations 에도 추가해야 한다. (main_openapi_test.go 에서 확인)
+const openApiBasePath = "/api"
+
+// openapi.json, docs 는 문서에 넣지 않는다.
+var openApiInternalPaths = map[string]bool{
+	"/openapi.json":              true,
+	"/docs":                      true,
+	"/docs/swagger-ui.css":       true,
+	"/docs/swagger-ui-bundle.js": true,
+
+	// graphql 은 /api/graphql/schema 로 문서를 본다.
+	"/graphql":        true,
+	"/graphql/schema": true,
+}
+
+type apiParameter struct {
+	Name        string
+	In          string // query, path
+	Type        string // string, integer, boolean
+	Description string
+	Enum        []string
+}
+
+type apiOperation struct {
+	Summary  string
+	Tag      string
+	Request  interface{} // 요청 body packet
+	Response interface{} // 응답 data packet (없으면 nil)
+
+	Parameters []apiParameter
+
+	// required: 428 로 응답, optional: 보내면 확인
+	IfMatch string
+
+	// GET 이어도 로그인이 필요한 api
+	Authorize bool
+
+	// 요청 body 가 application/merge-patch+json (Request 는 적용한 결과 문서)
+	MergePatch bool
+}
+
+var listStatusParameter = apiParameter{Name: "status", In: "query", Type: "string", Description: "공개 상태 (쉼표 구분, 로그인했을 때만 사용)"}
+
+var listPageParameters = []apiParameter{
+	{Name: "sort", In: "query", Type: "string", Enum: []string{"id", "created", "updated", "published", "title", "position"}},
+	{Name: "order", In: "query", Type: "string", Enum: []string{"asc", "desc"}},
+	{Name: "limit", In: "query", Type: "integer", Description: "1 ~ 100"},
+	{Name: "cursor", In: "query", Type: "string", Description: "이전 응답의 next_cursor"},
+	{Name: "title", In: "query", Type: "string", Description: "title 에 포함된 문자열"},
+	{Name: "tag", In: "query", Type: "string"},
+	{Name: "min_id", In: "query", Type: "integer"},
+	{Name: "max_id", In: "query", Type: "integer"},
+	{Name: "has_images", In: "query", Type: "boolean"},
+	{Name: "featured", In: "query", Type: "boolean"},
+	listStatusParameter,
+}
+
+var idOrSlugParameter = apiParameter{Name: "id", In: "path", Type: "string", Description: "id 또는 slug"}
+
+// "METHOD /path" (gin 형식, /api 를 뺀 경로) 별 설명
+var apiOperations = map[string]apiOperation{
+	// login
+	"POST /login":         {Summary: "로그인", Tag: "login", Request: RequestLogin{}, Response: ResponseLogin{}},
+	"GET /authentication": {Summary: "로그인 상태 확인", Tag: "login", Authorize: true},
+	"GET /logout":         {Summary: "로그아웃", Tag: "login"},
+
+	// potofolio
+	"GET /potofolio":        {Summary: "potofolio 목록", Tag: "potofolio", Response: ResponsePotofolioList{}, Parameters: listPageParameters},
+	"GET /potofolio/:id":    {Summary: "potofolio 조회", Tag: "potofolio", Response: ResponsePotofolioElement{}, Parameters: []apiParameter{idOrSlugParameter}},
+	"POST /potofolio":       {Summary: "potofolio 추가", Tag: "potofolio", Request: RequestCreatePotofolio{}, Response: ResponsePotofolioElement{}},
+	"PUT /potofolio/:id":    {Summary: "potofolio 수정", Tag: "potofolio", Request: RequestUpdatePotofolio{}, Response: ResponsePotofolioElement{}, IfMatch: "required"},
+	"PATCH /potofolio/:id":  {Summary: "potofolio 수정 (merge patch)", Tag: "potofolio", Request: PatchPotofolio{}, Response: ResponsePotofolioElement{}, IfMatch: "required", MergePatch: true},
+	"DELETE /potofolio/:id": {Summary: "potofolio 삭제 (휴지통)", Tag: "potofolio", IfMatch: "required"},
+	"PUT /potofolio-order":  {Summary: "potofolio 순서 변경", Tag: "potofolio", Request: RequestReorder{}, Response: ResponsePotofolioList{}},
+
+	// essay
+	"GET /essay":        {Summary: "essay 목록", Tag: "essay", Response: ResponseEssayList{}, Parameters: listPageParameters},
+	"GET /essay/:id":    {Summary: "essay 조회", Tag: "essay", Response: ResponseEssayElement{}, Parameters: []apiParameter{idOrSlugParameter}},
+	"POST /essay":       {Summary: "essay 추가", Tag: "essay", Request: RequestCreateEssay{}, Response: ResponseEssayElement{}},
+	"PUT /essay/:id":    {Summary: "essay 수정", Tag: "essay", Request: RequestUpdateEssay{}, Response: ResponseEssayElement{}, IfMatch: "required"},
+	"PATCH /essay/:id":  {Summary: "essay 수정 (merge patch)", Tag: "essay", Request: PatchEssay{}, Response: ResponseEssayElement{}, IfMatch: "required", MergePatch: true},
+	"DELETE /essay/:id": {Summary: "essay 삭제 (휴지통)", Tag: "essay", IfMatch: "required"},
+
+	"GET /essay/:id/revisions": {Summary: "essay 수정 이력", Tag: "essay", Response: ResponseEssayRevisionList{}, Authorize: true,
+		Parameters: []apiParameter{
+			{Name: "from", In: "query", Type: "integer", Description: "diff 시작 revision"},
+			{Name: "to", In: "query", Type: "integer", Description: "diff 끝 revision"},
+		}},
+	"POST /essay/:id/revisions/:rev/restore": {Summary: "essay revision 복구", Tag: "essay", Response: ResponseEssayElement{}, IfMatch: "optional"},
+
+	// about
+	"GET /about":                    {Summary: "about 조회", Tag: "about", Response: ResponseAbout{}},
+	"POST /about":                   {Summary: "about 수정", Tag: "about", Request: RequestUpdateAbout{}, Response: ResponseAbout{}, IfMatch: "optional"},
+	"PATCH /about":                  {Summary: "about 수정 (merge patch)", Tag: "about", Request: PatchAbout{}, Response: ResponseAbout{}, IfMatch: "required", MergePatch: true},
+	"POST /about-history":           {Summary: "about 경력 추가, 수정, 삭제", Tag: "about", Request: RequestUpdateAboutHistory{}, Response: ResponseAbout{}, IfMatch: "optional"},
+	"GET /about-history-categories": {Summary: "경력 category 목록", Tag: "about", Response: ResponseAboutHistoryCategoryList{}},
+	"PUT /about-history-categories": {Summary: "경력 category 순서 변경", Tag: "about", Request: RequestAboutHistoryCategoryOrder{}, Response: ResponseAboutHistoryCategoryList{}, IfMatch: "required"},
+
+	// bulk
+	"POST /bulk": {Summary: "potofolio, essay 일괄 삭제, 제목 변경, image 제거", Tag: "bulk", Request: RequestBulk{}, Response: ResponseBulk{}},
+
+	// trash
+	"GET /trash": {Summary: "휴지통 목록", Tag: "trash", Response: ResponseTrashList{}, Authorize: true},
+	"POST /trash/:type/:id/restore": {Summary: "휴지통에서 복구", Tag: "trash",
+		Parameters: []apiParameter{{Name: "type", In: "path", Type: "string", Enum: []string{"potofolio", "essay", "history"}}}},
+
+	// tag
+	"GET /tags":        {Summary: "tag 목록 (사용 횟수)", Tag: "tag", Response: ResponseTagList{}},
+	"POST /tags":       {Summary: "tag 추가", Tag: "tag", Request: RequestTag{}, Response: ResponseTagElement{}},
+	"PUT /tags/:id":    {Summary: "tag 이름 변경", Tag: "tag", Request: RequestTag{}, Response: ResponseTagElement{}},
+	"DELETE /tags/:id": {Summary: "tag 삭제", Tag: "tag"},
+
+	// search
+	"GET /search": {Summary: "검색", Tag: "search", Response: ResponseSearchList{},
+		Parameters: []apiParameter{
+			{Name: "q", In: "query", Type: "string", Description: "검색어 (필수)"},
+			{Name: "type", In: "query", Type: "string", Description: "potofolio, essay, history (쉼표 구분)"},
+			{Name: "limit", In: "query", Type: "integer", Description: "1 ~ 100"},
+		}},
+
+	// featured
+	"GET /featured": {Summary: "첫 화면 목록", Tag: "featured", Response: ResponseFeaturedList{},
+		Parameters: []apiParameter{
+			{Name: "limit", In: "query", Type: "integer"},
+			listStatusParameter,
+		}},
+
+	// collection
+	"GET /collections":        {Summary: "collection 목록", Tag: "collection", Response: ResponseCollectionList{}, Parameters: []apiParameter{listStatusParameter}},
+	"GET /collections/:id":    {Summary: "collection 조회", Tag: "collection", Response: ResponseCollectionElement{}, Parameters: []apiParameter{listStatusParameter}},
+	"POST /collections":       {Summary: "collection 추가", Tag: "collection", Request: RequestCreateCollection{}, Response: ResponseCollectionElement{}},
+	"PUT /collections/:id":    {Summary: "collection 수정", Tag: "collection", Request: RequestUpdateCollection{}, Response: ResponseCollectionElement{}},
+	"DELETE /collections/:id": {Summary: "collection 삭제", Tag: "collection"},
+
+	// cache
+	"GET /cache/stats": {Summary: "조회 응답 캐시 통계", Tag: "cache", Response: ResponseCacheStats{}, Authorize: true},
+}
+
+// 문서에 넣을 /api route ("METHOD /path")
+// /api/v2 는 같은 api 이므로 넣지 않는다.
+func openApiRouteKeys(routes gin.RoutesInfo) []string {
+
+	keys := make([]string, 0)
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, openApiBasePath+"/") || strings.HasPrefix(route.Path, openApiBasePath+"/v2/") {
+			continue
+		}
+
+		path := strings.TrimPrefix(route.Path, openApiBasePath)
+		if openApiInternalPaths[path] {
+			continue
+		}
+
+		keys = append(keys, route.Method+" "+path)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// 설명이 없는 route, route 가 없는 설명을 찾는다.
+func OpenApiRouteProblems(routes gin.RoutesInfo) []string {
+
+	problems := make([]string, 0)
+
+	registered := make(map[string]bool)
+	for _, key := range openApiRouteKeys(routes) {
+		registered[key] = true
+
+		if _, ok := apiOperations[key]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is not described (apis/openapi.go)", key))
+		}
+	}
+
+	for key := range apiOperations {
+		if !registered[key] {
+			problems = append(problems, fmt.Sprintf("%s is described but not registered", key))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+type openApiSchema map[string]interface{}
+
+// packet struct 를 json schema 로 바꾼다.
+// struct 는 components.schemas 에 넣고 $ref 로 참조한다.
+type openApiSchemaBuilder struct {
+	schemas map[string]openApiSchema
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func (builder *openApiSchemaBuilder) schema(t reflect.Type) openApiSchema {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return openApiSchema{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return openApiSchema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return openApiSchema{"type": "string"}
+	case reflect.Bool:
+		return openApiSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return openApiSchema{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return openApiSchema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return openApiSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return openApiSchema{"type": "array", "items": builder.schema(t.Elem())}
+	case reflect.Map:
+		return openApiSchema{"type": "object", "additionalProperties": builder.schema(t.Elem())}
+	case reflect.Struct:
+		return builder.structRef(t)
+	}
+
+	return openApiSchema{}
+}
+
+func (builder *openApiSchemaBuilder) structRef(t reflect.Type) openApiSchema {
+
+	ref := openApiSchema{"$ref": "#/components/schemas/" + t.Name()}
+	if _, ok := builder.schemas[t.Name()]; ok {
+		return ref
+	}
+
+	// 재귀 참조를 막기 위해 먼저 자리를 잡는다.
+	builder.schemas[t.Name()] = openApiSchema{}
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	builder.addStructFields(t, properties, &required)
+
+	schema := openApiSchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	builder.schemas[t.Name()] = schema
+	return ref
+}
+
+func (builder *openApiSchemaBuilder) addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+
+		// json 이름이 없는 embedded struct 는 field 를 펼친다.
+		if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+			builder.addStructFields(field.Type, properties, required)
+			continue
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		schema, isRequired := builder.fieldSchema(field)
+		properties[name] = schema
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// binding tag 를 schema 제약으로 옮긴다. (dive 다음 규칙은 items 에 적용)
+func (builder *openApiSchemaBuilder) fieldSchema(field reflect.StructField) (openApiSchema, bool) {
+
+	schema := builder.schema(field.Type)
+
+	rules := strings.Split(field.Tag.Get("binding"), ",")
+	itemRules := []string{}
+	for i, rule := range rules {
+		if rule == "dive" {
+			itemRules = rules[i+1:]
+			rules = rules[:i]
+			break
+		}
+	}
+
+	isRequired := applyBindingRules(schema, rules)
+
+	if items, ok := schema["items"].(openApiSchema); ok && len(itemRules) > 0 {
+		applyBindingRules(items, itemRules)
+	}
+
+	return schema, isRequired
+}
+
+func applyBindingRules(schema openApiSchema, rules []string) bool {
+
+	// $ref 옆에는 제약을 쓸 수 없다.
+	if _, ok := schema["$ref"]; ok {
+		for _, rule := range rules {
+			if rule == "required" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	isArray := schema["type"] == "array"
+
+	isRequired := false
+	for _, rule := range rules {
+		ruleName := rule
+		param := ""
+		if index := strings.Index(rule, "="); index >= 0 {
+			ruleName = rule[:index]
+			param = rule[index+1:]
+		}
+
+		value, _ := strconv.Atoi(param)
+
+		switch ruleName {
+		case "required":
+			isRequired = true
+		case "notblank":
+			schema["minLength"] = 1
+		case "base64":
+			schema["format"] = "byte"
+		case "oneof":
+			schema["enum"] = strings.Fields(param)
+		case "max":
+			if isArray {
+				schema["maxItems"] = value
+			} else if schema["type"] == "string" {
+				schema["maxLength"] = value
+			} else {
+				schema["maximum"] = value
+			}
+		case "min":
+			if isArray {
+				schema["minItems"] = value
+			} else if schema["type"] == "string" {
+				schema["minLength"] = value
+			} else {
+				schema["minimum"] = value
+			}
+		}
+	}
+
+	return isRequired
+}
+
+var openApiPathParamRegex = regexp.MustCompile(`:([^/]+)`)
+var openApiOperationIdRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func buildOpenApiOperation(builder *openApiSchemaBuilder, method string, path string, operation apiOperation) openApiSchema {
+
+	parameters := make([]interface{}, 0)
+
+	describedParameters := make(map[string]bool)
+	for _, parameter := range operation.Parameters {
+		describedParameters[parameter.In+":"+parameter.Name] = true
+	}
+
+	// 설명하지 않은 path parameter 는 정수 id 로 본다.
+	for _, match := range openApiPathParamRegex.FindAllStringSubmatch(path, -1) {
+		if !describedParameters["path:"+match[1]] {
+			parameters = append(parameters, buildOpenApiParameter(apiParameter{Name: match[1], In: "path", Type: "integer"}))
+		}
+	}
+
+	for _, parameter := range operation.Parameters {
+		parameters = append(parameters, buildOpenApiParameter(parameter))
+	}
+
+	switch operation.IfMatch {
+	case "required":
+		parameters = append(parameters, openApiSchema{"name": "If-Match", "in": "header", "required": true, "schema": openApiSchema{"type": "string"}, "description": `"<version>" 또는 * (없으면 428)`})
+	case "optional":
+		parameters = append(parameters, openApiSchema{"name": "If-Match", "in": "header", "schema": openApiSchema{"type": "string"}, "description": `"<version>" (다르면 412)`})
+	}
+
+	responseSchema := openApiSchema{"$ref": "#/components/schemas/ResponseEnvelope"}
+	if operation.Response != nil {
+		responseSchema = openApiSchema{
+			"type":       "object",
+			"properties": openApiSchema{"data": builder.schema(reflect.TypeOf(operation.Response))},
+		}
+	}
+
+	result := openApiSchema{
+		"operationId": openApiOperationId(method, path),
+		"summary":     operation.Summary,
+		"tags":        []string{operation.Tag},
+		"parameters":  parameters,
+		"responses": openApiSchema{
+			"200": openApiSchema{
+				"description": "success",
+				"content":     openApiSchema{"application/json": openApiSchema{"schema": responseSchema}},
+			},
+			"default": openApiSchema{"$ref": "#/components/responses/Error"},
+		},
+	}
+
+	if operation.Request != nil {
+		requestContentType := "application/json"
+		if operation.MergePatch {
+			requestContentType = mergePatchContentType
+		}
+
+		result["requestBody"] = openApiSchema{
+			"required": true,
+			"content":  openApiSchema{requestContentType: openApiSchema{"schema": builder.schema(reflect.TypeOf(operation.Request))}},
+		}
+	}
+
+	// vertifyTokenMiddleware 와 같은 기준 (GET 과 login 을 빼고 인증 필요)
+	if operation.Authorize || (method != http.MethodGet && path != "/login") {
+		result["security"] = []interface{}{openApiSchema{"cookieAuth": []string{}}}
+	}
+
+	return result
+}
+
+func buildOpenApiParameter(parameter apiParameter) openApiSchema {
+
+	schema := openApiSchema{"type": parameter.Type}
+	if len(parameter.Enum) > 0 {
+		schema["enum"] = parameter.Enum
+	}
+
+	result := openApiSchema{"name": parameter.Name, "in": parameter.In, "schema": schema}
+	if parameter.In == "path" {
+		result["required"] = true
+	}
+
+	if len(parameter.Description) > 0 {
+		result["description"] = parameter.Description
+	}
+
+	return result
+}
+
+// "POST /essay/:id/revisions/:rev/restore" -> "postEssayIdRevisionsRevRestore"
+func openApiOperationId(method string, path string) string {
+
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(method))
+
+	for _, word := range openApiOperationIdRegex.Split(path, -1) {
+		if len(word) == 0 {
+			continue
+		}
+
+		builder.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	return builder.String()
+}
+
+// 등록된 route 로 OpenAPI 3 문서를 만든다.
+func BuildOpenApiDocument(routes gin.RoutesInfo) openApiSchema {
+
+	builder := &openApiSchemaBuilder{schemas: make(map[string]openApiSchema)}
+	builder.schema(reflect.TypeOf(ResponseEnvelope{}))
+
+	paths := make(map[string]openApiSchema)
+	for _, key := range openApiRouteKeys(routes) {
+		operation, ok := apiOperations[key]
+		if !ok {
+			continue
+		}
+
+		method, path := splitOperationKey(key)
+		openApiPath := openApiPathParamRegex.ReplaceAllString(path, "{$1}")
+
+		if _, ok := paths[openApiPath]; !ok {
+			paths[openApiPath] = openApiSchema{}
+		}
+
+		paths[openApiPath][strings.ToLower(method)] = buildOpenApiOperation(builder, method, path, operation)
+	}
+
+	return openApiSchema{
+		"openapi": "3.0.3",
+		"info": openApiSchema{
+			"title":   "chomakers-web api",
+			"version": "2",
+			"description": "`/api/v2` 는 `{\"data\": ...}`, `{\"error\": ...}` 로 응답한다. " +
+				"`/api` 는 같은 api 를 기존 형식 (`{\"result\", \"header\", \"error\", \"data\"}`, data 는 json 문자열) 으로 응답한다.",
+		},
+		"servers": []interface{}{
+			openApiSchema{"url": openApiBasePath + "/v2", "description": "v2 응답 형식"},
+			openApiSchema{"url": openApiBasePath, "description": "기존 응답 형식"},
+		},
+		"paths": paths,
+		"components": openApiSchema{
+			"schemas": builder.schemas,
+			"responses": openApiSchema{
+				"Error": openApiSchema{
+					"description": "error (error.code 는 readme 의 API v2 참고)",
+					"content":     openApiSchema{"application/json": openApiSchema{"schema": openApiSchema{"$ref": "#/components/schemas/ResponseEnvelope"}}},
+				},
+			},
+			"securitySchemes": openApiSchema{
+				"cookieAuth": openApiSchema{"type": "apiKey", "in": "cookie", "name": "access-token"},
+			},
+		},
+	}
+}
+
+func splitOperationKey(key string) (string, string) {
+	parts := strings.SplitN(key, " ", 2)
+	return parts[0], parts[1]
+}
+
+//go:embed openapi_docs.html
+var openApiDocsHtml []byte
+
+// 문서 화면의 swagger-ui 는 외부 cdn 이 아니라 go.mod 에 고정된 swaggo/files 의 파일을 보낸다.
+var openApiDocsAssets = map[string]string{
+	"swagger-ui.css":       "text/css; charset=utf-8",
+	"swagger-ui-bundle.js": "application/javascript; charset=utf-8",
+}
+
+// /api/openapi.json 과 (serveDocs 이면) /api/docs 를 등록한다.
+// 모든 api 를 등록한 다음에 호출해야 한다.
+func OpenApiApis(api *gin.RouterGroup, routes func() gin.RoutesInfo, serveDocs bool) {
+
+	var once sync.Once
+	var document []byte
+	var documentErr error
+
+	api.GET("/openapi.json", func(c *gin.Context) {
+
+		once.Do(func() {
+			document, documentErr = json.Marshal(BuildOpenApiDocument(routes()))
+		})
+
+		if documentErr != nil {
+			errorMessage := fmt.Sprintf("openapi document create error [%v]", documentErr)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json; charset=utf-8", document)
+	})
+
+	if !serveDocs {
+		return
+	}
+
+	api.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", openApiDocsHtml)
+	})
+
+	for name, contentType := range openApiDocsAssets {
+		asset, err := fs.ReadFile(swaggerFiles.FS, name)
+		if err != nil {
+			log.Printf("[error] api docs asset [%v]\n", err)
+			continue
+		}
+
+		contentType := contentType
+		api.GET("/docs/"+name, func(c *gin.Context) {
+			c.Data(http.StatusOK, contentType, asset)
+		})
+	}
+}
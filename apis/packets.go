@@ -3,14 +3,15 @@ package apis
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Save Image Common
 type RequestSaveImage struct {
-	Filename string `json:"filename"`
-	Data     string `json:"data"`
+	Filename string `json:"filename" binding:"required,notblank,max=255"`
+	Data     string `json:"data" binding:"required,base64"`
 }
 
 type ResponseImage struct {
@@ -18,83 +19,263 @@ type ResponseImage struct {
 	ImageUrl string `json:"image"`
 }
 
+// Tag Common
+type ResponseTagElement struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
 // Login
 type RequestLogin struct {
-	UserName string `json:"username"`
-	Password string `json:"password"`
+	UserName string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
 type ResponseLogin struct {
 	LoginResult int `json:"result"` // 0: Sucess, 1: wroung username, 2; wroung password, 3: token generate fail
 }
 
+// Potofolio 작품 크기
+type ResponseDimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Depth  float64 `json:"depth"`
+	Unit   string  `json:"unit"`
+}
+
+type ResponseCredit struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type ResponseLink struct {
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// Potofolio 작품 정보
+type ResponsePotofolioMetadata struct {
+	Year        *int                `json:"year"`
+	Medium      string              `json:"medium"`
+	Dimensions  *ResponseDimensions `json:"dimensions"`
+	Description string              `json:"description"`
+	Credits     []ResponseCredit    `json:"credits"`
+	Client      string              `json:"client"`
+	Links       []ResponseLink      `json:"links"`
+}
+
 // Potoflio Get
 type ResponsePotofolioElement struct {
-	Id     int64           `json:"id"`
-	Title  string          `json:"title"`
-	Images []ResponseImage `json:"images"`
+	Id          int64                   `json:"id"`
+	Slug        string                  `json:"slug"`
+	Title       string                  `json:"title"`
+	Images      []ResponseImage         `json:"images"`
+	Version     int64                   `json:"version"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	PublishedAt *time.Time              `json:"published_at"`
+	Status      string                  `json:"status"`
+	PublishAt   *time.Time              `json:"publish_at"`
+	Tags        []ResponseTagElement    `json:"tags"`
+	Position    int64                   `json:"position"`
+	Featured    bool                    `json:"featured"`
+	Collections []ResponseCollectionRef `json:"collections"`
+	ResponsePotofolioMetadata
 }
 
 type ResponsePotofolioList struct {
-	List []ResponsePotofolioElement `json:"list"`
+	List       []ResponsePotofolioElement `json:"list"`
+	NextCursor string                     `json:"next_cursor"`
+}
+
+type RequestDimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Depth  float64 `json:"depth"`
+	Unit   string  `json:"unit"`
+}
+
+type RequestCredit struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type RequestLink struct {
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// Potofolio 작품 정보 (POST, PUT)
+// PUT 에서 year 가 0 이면 지우고, dimensions 가 {} 이면 지운다.
+type RequestPotofolioMetadata struct {
+	Year        *int               `json:"year"`
+	Medium      *string            `json:"medium"`
+	Dimensions  *RequestDimensions `json:"dimensions"`
+	Description *string            `json:"description"`
+	Credits     []RequestCredit    `json:"credits"`
+	Client      *string            `json:"client"`
+	Links       []RequestLink      `json:"links"`
 }
 
 // Potofolio Update (PUT)
 type RequestUpdatePotofolio struct {
-	Title          *string            `json:"title"`
+	Title          *string            `json:"title" binding:"omitempty,notblank,max=200"`
 	RemoveImageIds []int64            `json:"remove_images"`
-	AddImages      []RequestSaveImage `json:"add_images"`
+	AddImages      []RequestSaveImage `json:"add_images" binding:"max=30,dive"`
+	Status         *string            `json:"status"`
+	PublishAt      *time.Time         `json:"publish_at"`
+	Slug           *string            `json:"slug"`
+	Tags           []string           `json:"tags" binding:"max=20,dive,max=40"`
+	Featured       *bool              `json:"featured"`
+	RequestPotofolioMetadata
 }
 
 // Potofolio New (Post)
 type RequestCreatePotofolio struct {
-	Title  string             `json:"title"`
-	Images []RequestSaveImage `json:"images"`
+	Title     string             `json:"title" binding:"required,notblank,max=200"`
+	Images    []RequestSaveImage `json:"images" binding:"required,min=1,max=30,dive"`
+	Status    *string            `json:"status"`
+	PublishAt *time.Time         `json:"publish_at"`
+	Slug      *string            `json:"slug"`
+	Tags      []string           `json:"tags" binding:"max=20,dive,max=40"`
+	Featured  *bool              `json:"featured"`
+	RequestPotofolioMetadata
+}
+
+// Potofolio 순서 변경 (PUT)
+type RequestReorder struct {
+	Ids []int64 `json:"ids" binding:"required,min=1"`
 }
 
 // Essay Thumbnail
 type ResponseEssayThumbnailElement struct {
-	Id             int64  `json:"id"`
-	Title          string `json:"title"`
-	ThumbnailImage string `json:"thumbnail"`
+	Id             int64                `json:"id"`
+	Slug           string               `json:"slug"`
+	Title          string               `json:"title"`
+	ThumbnailImage string               `json:"thumbnail"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	PublishedAt    *time.Time           `json:"published_at"`
+	Status         string               `json:"status"`
+	PublishAt      *time.Time           `json:"publish_at"`
+	Tags           []ResponseTagElement `json:"tags"`
+	Featured       bool                 `json:"featured"`
 }
 
 type ResponseEssayList struct {
-	List []ResponseEssayThumbnailElement `json:"list"`
+	List       []ResponseEssayThumbnailElement `json:"list"`
+	NextCursor string                          `json:"next_cursor"`
 }
 
 // Essay
 type ResponseEssayElement struct {
-	Id             int64           `json:"id"`
-	Title          string          `json:"title"`
-	ThumbnailImage string          `json:"thumbmail"`
-	Images         []ResponseImage `json:"images"`
-	EssayContent   string          `json:"essay_content"`
+	Id             int64                `json:"id"`
+	Slug           string               `json:"slug"`
+	Title          string               `json:"title"`
+	ThumbnailImage string               `json:"thumbmail"`
+	Images         []ResponseImage      `json:"images"`
+	EssayContent   string               `json:"essay_content"`
+	Version        int64                `json:"version"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	PublishedAt    *time.Time           `json:"published_at"`
+	Status         string               `json:"status"`
+	PublishAt      *time.Time           `json:"publish_at"`
+	Tags           []ResponseTagElement `json:"tags"`
+	Featured       bool                 `json:"featured"`
 }
 
 // Essay New (Post)
 type RequestCreateEssay struct {
-	Title          string             `json:"title"`
+	Title          string             `json:"title" binding:"required,notblank,max=200"`
 	ThumbnailImage RequestSaveImage   `json:"thumbmail"`
-	Images         []RequestSaveImage `json:"images"`
-	EssayContent   string             `json:"essay_content"`
+	Images         []RequestSaveImage `json:"images" binding:"max=30,dive"`
+	EssayContent   string             `json:"essay_content" binding:"max=200000"`
+	Status         *string            `json:"status"`
+	PublishAt      *time.Time         `json:"publish_at"`
+	Slug           *string            `json:"slug"`
+	Tags           []string           `json:"tags" binding:"max=20,dive,max=40"`
+	Featured       *bool              `json:"featured"`
 }
 
 // Essay Update (PUT)
 type RequestUpdateEssay struct {
-	Title          *string            `json:"title"`
+	Title          *string            `json:"title" binding:"omitempty,notblank,max=200"`
 	NewThumbnail   *RequestSaveImage  `json:"thumbnail"`
 	RemoveImageIds []int64            `json:"remove_images"`
-	AddImages      []RequestSaveImage `json:"add_images"`
-	EssayContent   *string            `json:"essay_content"`
+	AddImages      []RequestSaveImage `json:"add_images" binding:"max=30,dive"`
+	EssayContent   *string            `json:"essay_content" binding:"omitempty,max=200000"`
+	Status         *string            `json:"status"`
+	PublishAt      *time.Time         `json:"publish_at"`
+	Slug           *string            `json:"slug"`
+	Tags           []string           `json:"tags" binding:"max=20,dive,max=40"`
+	Featured       *bool              `json:"featured"`
+}
+
+// Essay Revision
+type ResponseEssayRevisionElement struct {
+	Revision       int64     `json:"revision"`
+	Title          string    `json:"title"`
+	ThumbnailImage string    `json:"thumbnail"`
+	Images         []string  `json:"images"`
+	Author         string    `json:"author"`
+	RestoredFrom   *int64    `json:"restored_from"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ResponseDiffLine struct {
+	Op   string `json:"op"` // equal, insert, delete
+	Line string `json:"line"`
+}
+
+type ResponseEssayRevisionDiff struct {
+	From            int64              `json:"from"`
+	To              int64              `json:"to"`
+	TitleBefore     string             `json:"title_before"`
+	TitleAfter      string             `json:"title_after"`
+	ThumbnailBefore string             `json:"thumbnail_before"`
+	ThumbnailAfter  string             `json:"thumbnail_after"`
+	AddedImages     []string           `json:"added_images"`
+	RemovedImages   []string           `json:"removed_images"`
+	Lines           []ResponseDiffLine `json:"lines"`
+}
+
+type ResponseEssayRevisionList struct {
+	List []ResponseEssayRevisionElement `json:"list"`
+	Diff *ResponseEssayRevisionDiff     `json:"diff,omitempty"`
 }
 
 // About History
+// start_date, end_date 는 "2021", "2021-03", "2021-03-15" 형식 (없으면 빈 문자열, end_date 는 "present" 도 가능)
 type ResponseAboutHistoryElement struct {
-	Id       int64  `json:"id"`
-	Category string `json:"category"`
-	Duration string `json:"duration"`
-	Content  string `json:"content"`
+	Id        int64  `json:"id"`
+	Category  string `json:"category"`
+	Duration  string `json:"duration"`
+	Content   string `json:"content"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type ResponseAboutHistoryGroup struct {
+	Category  string                        `json:"category"`
+	Histories []ResponseAboutHistoryElement `json:"history_list"`
+}
+
+// About History Category
+// display_order 가 null 이면 순서를 정하지 않은 category
+type ResponseAboutHistoryCategoryElement struct {
+	Name         string `json:"name"`
+	DisplayOrder *int   `json:"display_order"`
+	HistoryCount int64  `json:"history_count"`
+}
+
+type ResponseAboutHistoryCategoryList struct {
+	List []ResponseAboutHistoryCategoryElement `json:"list"`
+}
+
+// About History Category 순서 (PUT)
+type RequestAboutHistoryCategoryOrder struct {
+	Names []string `json:"names" binding:"dive,notblank,max=50"`
 }
 
 // About
@@ -103,34 +284,251 @@ type ResponseAbout struct {
 	ProfileName      string `json:"profile_name"`
 	Contact          string `json:"contact"`
 	IntroduceContent string `json:"introduce_content"`
+	Version          int64  `json:"version"`
 
-	Histories []ResponseAboutHistoryElement `json:"history_list"`
+	Histories     []ResponseAboutHistoryElement `json:"history_list"`
+	HistoryGroups []ResponseAboutHistoryGroup   `json:"history_groups"`
 }
 
 // Update About
 type RequestUpdateAbout struct {
 	ProfileImage     *RequestSaveImage `json:"profile_image"`
-	ProfileName      *string           `json:"profile_name"`
-	Contact          *string           `json:"contact"`
-	IntroduceContent *string           `json:"introduce_content"`
+	ProfileName      *string           `json:"profile_name" binding:"omitempty,max=100"`
+	Contact          *string           `json:"contact" binding:"omitempty,max=200"`
+	IntroduceContent *string           `json:"introduce_content" binding:"omitempty,max=20000"`
 }
 
 // Update About History
+// start_date, end_date 가 없으면 duration 에서 날짜를 찾는다.
 type RequesAboutHistoryElement struct {
-	Category string `json:"category"`
-	Duration string `json:"duration"`
-	Content  string `json:"content"`
+	Category  string  `json:"category" binding:"max=50"`
+	Duration  string  `json:"duration" binding:"max=100"`
+	Content   string  `json:"content" binding:"max=2000"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
 }
 
 type RequestUpdateAboutHistoryElement struct {
-	Id int64 `json:"id"`
+	Id int64 `json:"id" binding:"required"`
 	RequesAboutHistoryElement
 }
 
 type RequestUpdateAboutHistory struct {
 	RemoveIds       []int64                            `json:"remove_id_list"`
-	AppendHistories []RequesAboutHistoryElement        `json:"append_history_list"`
-	UpdateHistories []RequestUpdateAboutHistoryElement `json:"update_history_list"`
+	AppendHistories []RequesAboutHistoryElement        `json:"append_history_list" binding:"max=100,dive"`
+	UpdateHistories []RequestUpdateAboutHistoryElement `json:"update_history_list" binding:"max=100,dive"`
+}
+
+// Trash
+type ResponseTrashElement struct {
+	Type      string    `json:"type"`
+	Id        int64     `json:"id"`
+	Title     string    `json:"title"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+type ResponseTrashList struct {
+	List []ResponseTrashElement `json:"list"`
+}
+
+// Tag (tag cloud)
+type ResponseTagCountElement struct {
+	Id             int64  `json:"id"`
+	Name           string `json:"name"`
+	Count          int64  `json:"count"`
+	PotofolioCount int64  `json:"potofolio_count"`
+	EssayCount     int64  `json:"essay_count"`
+}
+
+type ResponseTagList struct {
+	List []ResponseTagCountElement `json:"list"`
+}
+
+// Tag New (Post), Update (PUT)
+type RequestTag struct {
+	Name string `json:"name" binding:"required,notblank,max=40"`
+}
+
+// Search
+type ResponseSearchElement struct {
+	Type           string  `json:"type"`
+	Id             int64   `json:"id"`
+	Title          string  `json:"title"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+	Score          float64 `json:"score"`
+}
+
+type ResponseSearchList struct {
+	List []ResponseSearchElement `json:"list"`
+}
+
+// Collection (potofolio 가 속한 collection)
+type ResponseCollectionRef struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// Collection Get
+// potofolio_list 는 단건 조회에만 들어간다.
+type ResponseCollectionElement struct {
+	Id            int64                      `json:"id"`
+	Title         string                     `json:"title"`
+	Description   string                     `json:"description"`
+	CoverImage    string                     `json:"cover_image"`
+	PotofolioIds  []int64                    `json:"potofolio_ids"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	PotofolioList []ResponsePotofolioElement `json:"potofolio_list,omitempty"`
+}
+
+type ResponseCollectionList struct {
+	List []ResponseCollectionElement `json:"list"`
+}
+
+// Collection New (Post)
+type RequestCreateCollection struct {
+	Title        string            `json:"title" binding:"required,notblank,max=100"`
+	Description  string            `json:"description" binding:"max=2000"`
+	CoverImage   *RequestSaveImage `json:"cover_image"`
+	PotofolioIds []int64           `json:"potofolio_ids" binding:"max=200"`
+}
+
+// Collection Update (PUT)
+type RequestUpdateCollection struct {
+	Title        *string           `json:"title" binding:"omitempty,notblank,max=100"`
+	Description  *string           `json:"description" binding:"omitempty,max=2000"`
+	CoverImage   *RequestSaveImage `json:"cover_image"`
+	PotofolioIds []int64           `json:"potofolio_ids" binding:"max=200"`
+}
+
+// Featured (첫 화면)
+type ResponseFeaturedList struct {
+	PotofolioList []ResponsePotofolioElement      `json:"potofolio_list"`
+	EssayList     []ResponseEssayThumbnailElement `json:"essay_list"`
+}
+
+// Bulk (여러 potofolio, essay 를 한번에 처리)
+// retitle 은 title, remove_images 는 image_ids (없으면 모든 image) 를 사용한다.
+// version 이 있으면 If-Match 처럼 현재 version 과 같을 때만 처리한다.
+type RequestBulkOperation struct {
+	Type     string  `json:"type" binding:"required,oneof=potofolio essay"`
+	Id       int64   `json:"id" binding:"required"`
+	Action   string  `json:"action" binding:"required,oneof=delete retitle remove_images"`
+	Version  *int64  `json:"version"`
+	Title    *string `json:"title" binding:"omitempty,notblank,max=200"`
+	ImageIds []int64 `json:"image_ids" binding:"max=100"`
+}
+
+// atomic 이면 하나라도 실패했을 때 모두 되돌린다.
+type RequestBulk struct {
+	Atomic     bool                   `json:"atomic"`
+	Operations []RequestBulkOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// status 는 같은 작업을 하나씩 요청했을 때의 status code
+// applied 는 실제로 반영되었는지 (atomic 으로 되돌려지면 false)
+type ResponseBulkResult struct {
+	Index   int    `json:"index"`
+	Type    string `json:"type"`
+	Id      int64  `json:"id"`
+	Action  string `json:"action"`
+	Status  int    `json:"status"`
+	Applied bool   `json:"applied"`
+	Version *int64 `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ResponseBulk struct {
+	Atomic    bool                 `json:"atomic"`
+	Committed bool                 `json:"committed"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []ResponseBulkResult `json:"results"`
+}
+
+// GraphQL (/api/graphql)
+// GET 은 query string 으로 같은 항목을 보낸다. (variables 는 json 문자열)
+type RequestGraphql struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type ResponseGraphqlLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// path 는 오류가 난 field 의 응답 경로 (이름, 목록 index)
+type ResponseGraphqlError struct {
+	Message   string                    `json:"message"`
+	Locations []ResponseGraphqlLocation `json:"locations,omitempty"`
+	Path      []interface{}             `json:"path,omitempty"`
+}
+
+// 문서가 잘못되어 실행하지 않았으면 data 는 없다.
+type ResponseGraphql struct {
+	Data   json.RawMessage        `json:"data,omitempty"`
+	Errors []ResponseGraphqlError `json:"errors,omitempty"`
+}
+
+// 조회 응답 캐시 (/api/cache/stats)
+// 로그인한 요청, 캐시하지 않는 route 는 hits, misses 에 들어가지 않는다.
+type ResponseCacheStats struct {
+	Enabled       bool    `json:"enabled"`
+	Entries       int     `json:"entries"`
+	MaxEntries    int     `json:"max_entries"`
+	Bytes         int64   `json:"bytes"`
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Evictions     uint64  `json:"evictions"`
+	Invalidations uint64  `json:"invalidations"`
+}
+
+// Merge Patch (PATCH) 문서
+// 현재 내용을 이 형식으로 만들고 요청 (RFC 7396) 을 적용한 결과를 확인한다.
+// null 이면 지운다. (title, status, slug 처럼 꼭 필요한 항목은 지울 수 없다.)
+type PatchPotofolio struct {
+	Title     string     `json:"title" binding:"required,notblank,max=200"`
+	Status    string     `json:"status" binding:"required"`
+	PublishAt *time.Time `json:"publish_at"`
+	Slug      string     `json:"slug" binding:"required,notblank"`
+	Tags      []string   `json:"tags" binding:"max=20,dive,max=40"`
+	Featured  bool       `json:"featured"`
+
+	Year        *int               `json:"year"`
+	Medium      string             `json:"medium"`
+	Dimensions  *RequestDimensions `json:"dimensions"`
+	Description string             `json:"description"`
+	Credits     []RequestCredit    `json:"credits"`
+	Client      string             `json:"client"`
+	Links       []RequestLink      `json:"links"`
+}
+
+type PatchEssay struct {
+	Title        string     `json:"title" binding:"required,notblank,max=200"`
+	EssayContent string     `json:"essay_content" binding:"max=200000"`
+	Status       string     `json:"status" binding:"required"`
+	PublishAt    *time.Time `json:"publish_at"`
+	Slug         string     `json:"slug" binding:"required,notblank"`
+	Tags         []string   `json:"tags" binding:"max=20,dive,max=40"`
+	Featured     bool       `json:"featured"`
+}
+
+// profile_image 는 null 로 지우는 것만 가능하다. (이미지 변경은 POST /about)
+type PatchAbout struct {
+	ProfileImage     string `json:"profile_image"`
+	ProfileName      string `json:"profile_name" binding:"max=100"`
+	Contact          string `json:"contact" binding:"max=200"`
+	IntroduceContent string `json:"introduce_content" binding:"max=20000"`
+}
+
+// If-Match 가 없거나 (428) 맞지 않을 때 (412)
+type ResponseVersionConflict struct {
+	CurrentVersion int64 `json:"current_version"`
 }
 
 //
@@ -180,3 +578,41 @@ func FailedResponsePreset(err string) *ResponsePresent {
 	response := ResponsePresent{Result: "failed", Error: err}
 	return &response
 }
+
+func FailedResponsePresetWithData(err string, data interface{}) *ResponsePresent {
+	response := ResponsePresent{Result: "failed", Error: err}
+
+	bytes, marshalErr := json.Marshal(data)
+	if marshalErr == nil {
+		response.Data = string(bytes)
+	}
+
+	return &response
+}
+
+// v2 응답
+// data 를 문자열로 한번 더 감싸지 않고 json 그대로 보낸다.
+type ResponseEnvelope struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error *ResponseError  `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	// bad_request, unauthorized, forbidden, not_found, conflict, validation_failed, ...
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Fields  []ResponseFieldError `json:"fields,omitempty"`
+
+	// 오류와 같이 보내는 내용 (예: version 충돌시 current_version)
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// 요청 항목별 오류 (field 는 json 이름, 목록은 "images[1].data" 처럼 쓴다.)
+type ResponseFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ResponseValidationFailed struct {
+	Fields []ResponseFieldError `json:"fields"`
+}
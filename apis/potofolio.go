@@ -26,12 +26,195 @@ func convertResponsePotofolioElement(potofolioModel *models.PotofolioModel) *Res
 	}
 
 	return &ResponsePotofolioElement{
-		Id:     potofolioModel.Id,
-		Title:  potofolioModel.Title,
-		Images: responseImages,
+		Id:          potofolioModel.Id,
+		Slug:        potofolioModel.Slug,
+		Title:       potofolioModel.Title,
+		Images:      responseImages,
+		Version:     potofolioModel.Version,
+		CreatedAt:   potofolioModel.CreatedAt,
+		UpdatedAt:   potofolioModel.UpdatedAt,
+		PublishedAt: potofolioModel.PublishedAt,
+		Status:      string(potofolioModel.Status),
+		PublishAt:   potofolioModel.PublishAt,
+		Tags:        convertResponseTagElements(potofolioModel.Tags),
+		Position:    potofolioModel.Position,
+		Featured:    potofolioModel.Featured,
+		Collections: convertResponseCollectionRefs(potofolioModel.Collections),
+
+		ResponsePotofolioMetadata: *convertResponsePotofolioMetadata(&potofolioModel.Metadata),
 	}
 }
 
+func convertResponsePotofolioMetadata(metadata *models.PotofolioMetadata) *ResponsePotofolioMetadata {
+
+	responseMetadata := &ResponsePotofolioMetadata{
+		Medium:      metadata.Medium,
+		Description: metadata.Description,
+		Client:      metadata.Client,
+		Credits:     make([]ResponseCredit, 0),
+		Links:       make([]ResponseLink, 0),
+	}
+
+	if metadata.Year != 0 {
+		year := metadata.Year
+		responseMetadata.Year = &year
+	}
+
+	if metadata.Dimensions != nil {
+		responseMetadata.Dimensions = &ResponseDimensions{
+			Width:  metadata.Dimensions.Width,
+			Height: metadata.Dimensions.Height,
+			Depth:  metadata.Dimensions.Depth,
+			Unit:   metadata.Dimensions.Unit,
+		}
+	}
+
+	for _, credit := range metadata.Credits {
+		responseMetadata.Credits = append(responseMetadata.Credits, ResponseCredit{Name: credit.Name, Role: credit.Role})
+	}
+
+	for _, link := range metadata.Links {
+		responseMetadata.Links = append(responseMetadata.Links, ResponseLink{Title: link.Title, Url: link.Url})
+	}
+
+	return responseMetadata
+}
+
+// 요청에 있는 작품 정보만 옮긴다. (확인은 models.ValidatePotofolioMetadata)
+func parsePotofolioMetadataOption(requestMetadata *RequestPotofolioMetadata) *models.PotofolioMetadataOption {
+
+	metadataOption := &models.PotofolioMetadataOption{
+		Year:        requestMetadata.Year,
+		Medium:      requestMetadata.Medium,
+		Description: requestMetadata.Description,
+		Client:      requestMetadata.Client,
+	}
+
+	if requestMetadata.Dimensions != nil {
+		metadataOption.Dimensions = &models.PotofolioDimensions{
+			Width:  requestMetadata.Dimensions.Width,
+			Height: requestMetadata.Dimensions.Height,
+			Depth:  requestMetadata.Dimensions.Depth,
+			Unit:   requestMetadata.Dimensions.Unit,
+		}
+	}
+
+	if requestMetadata.Credits != nil {
+		metadataOption.Credits = make([]models.PotofolioCredit, 0, len(requestMetadata.Credits))
+		for _, credit := range requestMetadata.Credits {
+			metadataOption.Credits = append(metadataOption.Credits, models.PotofolioCredit{Name: credit.Name, Role: credit.Role})
+		}
+	}
+
+	if requestMetadata.Links != nil {
+		metadataOption.Links = make([]models.PotofolioLink, 0, len(requestMetadata.Links))
+		for _, link := range requestMetadata.Links {
+			metadataOption.Links = append(metadataOption.Links, models.PotofolioLink{Title: link.Title, Url: link.Url})
+		}
+	}
+
+	return metadataOption
+}
+
+// PUT, PATCH 에서 같이 사용한다.
+// 요청에 있는 항목만 바꾸고 수정된 potofolio 로 응답한다.
+func updatePotofolio(c *gin.Context, id int64, expectedVersion *int64, reqUpdatePotofolio *RequestUpdatePotofolio) {
+
+	complete := false
+
+	publishOption, err := parsePublishOption(reqUpdatePotofolio.Status, reqUpdatePotofolio.PublishAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return
+	}
+
+	err = models.ValidateTagNames(reqUpdatePotofolio.Tags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return
+	}
+
+	metadataOption := parsePotofolioMetadataOption(&reqUpdatePotofolio.RequestPotofolioMetadata)
+	err = models.ValidatePotofolioMetadata(metadataOption)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return
+	}
+
+	var storedImages []models.StoredImageInfo
+	var images []string
+	if reqUpdatePotofolio.AddImages != nil {
+
+		requestSaveImageInfos := make([]models.RequestSaveImageInfo, 0)
+		for _, reqImage := range reqUpdatePotofolio.AddImages {
+			requestSaveImageInfos = append(requestSaveImageInfos, models.RequestSaveImageInfo{Filename: reqImage.Filename, Base64Data: reqImage.Data})
+		}
+
+		storedImages, err = models.StorageImages("./assets/images", "/images", requestSaveImageInfos)
+		if err != nil {
+			errorMessage := fmt.Sprintf("image save error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		images = funk.Map(storedImages, func(e models.StoredImageInfo) string {
+			return e.ImageUri
+		}).([]string)
+	}
+
+	defer func(isComplete *bool) {
+		if !*isComplete {
+			for _, sotredImage := range storedImages {
+				os.Remove(sotredImage.ImageStorePath)
+			}
+		}
+
+	}(&complete)
+
+	removeImages, err := potofolioRepository.UpdatePotofolioWithMetadata(id, expectedVersion, &models.ContentOption{Publish: publishOption, Slug: reqUpdatePotofolio.Slug, Tags: reqUpdatePotofolio.Tags, Featured: reqUpdatePotofolio.Featured}, metadataOption, reqUpdatePotofolio.Title, reqUpdatePotofolio.RemoveImageIds, images)
+	if err != nil {
+		if respondVersionConflict(c, err) || respondSlugError(c, err) || respondContentNotFound(c, err) {
+			return
+		}
+
+		errorMessage := fmt.Sprintf("potofolioId = %d repository.UpdatePotofolio [err = %s]", id, err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	// 파일 지우기
+	for _, removeImage := range removeImages {
+		reletivePath := strings.Replace(removeImage, "/images", "./assets/images", 1)
+		absPath, err := filepath.Abs(reletivePath)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		os.Remove(absPath)
+	}
+
+	// 여기까지 오면 성공으로 간주한다.
+	complete = true
+
+	potofolioModel, err := potofolioRepository.FindPotofolio(id)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Update Potofolio after error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	responsePotofolioElment := convertResponsePotofolioElement(potofolioModel)
+	responsePresent, err := SuccessResponsePresent(c, responsePotofolioElment)
+	if err != nil {
+		errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+		c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		return
+	}
+
+	c.JSON(http.StatusOK, responsePresent)
+}
+
 func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
 
 	potofolioRepository = repositoryConfigure.PotofolioRepository
@@ -39,26 +222,59 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 	api.GET("/potofolio/:id", func(c *gin.Context) {
 		strPotofolioId := c.Param("id")
 
+		// 숫자가 아니면 slug 로 찾는다.
+		redirected := false
 		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
-			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
-			return
+			var potofolioId int64
+			potofolioId, redirected, err = potofolioRepository.ResolvePotofolioSlug(strPotofolioId)
+			if err != nil {
+				if respondSlugError(c, err) {
+					return
+				}
+
+				errorMessage := fmt.Sprintf("potofolio slug find occur exception [%v]", err)
+				c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+				return
+			}
+
+			id = int(potofolioId)
 		}
 
 		potofolioModel, err := potofolioRepository.FindPotofolio(int64(id))
 		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("potofolio find occur exception [%v]", err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
+		// 공개되지 않은 potofolio 는 없는 것으로 응답한다.
+		if !isVisibleContent(c, repositoryConfigure, potofolioModel.Status) {
+			errorMessage := fmt.Sprintf("potofolio not found [id: %v]", id)
+			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		if redirected {
+			redirectToSlug(c, potofolioModel.Slug)
+			return
+		}
+
+		c.Header("ETag", versionETag(potofolioModel.Version))
+		if respondNotModified(c, versionETag(potofolioModel.Version)) {
+			return
+		}
+
 		resPotofolioElement := convertResponsePotofolioElement(potofolioModel)
 
 		responsePresent, err := SuccessResponsePresent(c, resPotofolioElement)
 		if err != nil {
 			errorMessage := fmt.Sprintf("get one potofolio error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -67,10 +283,28 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 
 	api.GET("/potofolio", func(c *gin.Context) {
 
-		allPotofolioModels, err := potofolioRepository.GetPotofolioList()
+		// sort 가 없으면 직접 정한 순서로 보여준다.
+		pageOption, err := parseListPageOption(c, models.DefaultPotofolioSortOption())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		pageOption.Filter.Statuses, err = parseListStatuses(c, repositoryConfigure)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		allPotofolioModels, nextCursor, err := potofolioRepository.GetPotofolioPage(pageOption)
 		if err != nil {
+			if _, ok := err.(*models.ListCursorError); ok {
+				c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+				return
+			}
+
 			errorMessage := fmt.Sprintf("get potofolio list error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -81,11 +315,12 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 
 		potofolioList := &ResponsePotofolioList{}
 		potofolioList.List = potofolios
+		potofolioList.NextCursor = nextCursor
 
 		responsePresent, err := SuccessResponsePresent(c, potofolioList)
 		if err != nil {
 			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusNotFound, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
 		}
 
@@ -96,7 +331,30 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 	api.POST("/potofolio", func(c *gin.Context) {
 
 		var reqCreatePotofolio RequestCreatePotofolio
-		c.ShouldBindJSON(&reqCreatePotofolio)
+		if !bindJSON(c, &reqCreatePotofolio) {
+			return
+		}
+
+		publishOption, err := parsePublishOption(reqCreatePotofolio.Status, reqCreatePotofolio.PublishAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		err = models.ValidateTagNames(reqCreatePotofolio.Tags)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		metadataOption := parsePotofolioMetadataOption(&reqCreatePotofolio.RequestPotofolioMetadata)
+		err = models.ValidatePotofolioMetadata(metadataOption)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		contentOption := &models.ContentOption{Publish: publishOption, Slug: reqCreatePotofolio.Slug, Tags: reqCreatePotofolio.Tags, Featured: reqCreatePotofolio.Featured}
 
 		requestSaveImageInfos := make([]models.RequestSaveImageInfo, 0)
 		for _, reqImage := range reqCreatePotofolio.Images {
@@ -114,8 +372,16 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 			return e.ImageUri
 		})
 
-		insertId, err := potofolioRepository.AddPotofolio(reqCreatePotofolio.Title, images.([]string))
+		insertId, err := potofolioRepository.AddPotofolioWithMetadata(contentOption, metadataOption, reqCreatePotofolio.Title, images.([]string))
 		if err != nil {
+			for _, storedImage := range storedImages {
+				os.Remove(storedImage.ImageStorePath)
+			}
+
+			if respondSlugError(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("AddPotofolio error [%v]", err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
 			return
@@ -142,94 +408,75 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 	// 수정
 	api.PUT("/potofolio/:id", func(c *gin.Context) {
 
-		complete := false
-
 		strPotofolioId := c.Param("id")
 
 		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
 			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		_, err = potofolioRepository.FindPotofolio(int64(id))
+		prevPotofolioModel, err := potofolioRepository.FindPotofolio(int64(id))
 		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
+			}
+
 			errorMessage := fmt.Sprintf("potofolioId = %d [err = %s]", id, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		var reqUpdatePotofolio RequestUpdatePotofolio
-		c.ShouldBindJSON(&reqUpdatePotofolio)
-
-		var storedImages []models.StoredImageInfo
-		var images interface{}
-		if reqUpdatePotofolio.AddImages != nil {
-
-			requestSaveImageInfos := make([]models.RequestSaveImageInfo, 0)
-			for _, reqImage := range reqUpdatePotofolio.AddImages {
-				requestSaveImageInfos = append(requestSaveImageInfos, models.RequestSaveImageInfo{Filename: reqImage.Filename, Base64Data: reqImage.Data})
-			}
-
-			storedImages, err = models.StorageImages("./assets/images", "/images", requestSaveImageInfos)
-			if err != nil {
-				errorMessage := fmt.Sprintf("image save error [%v]", err)
-				c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
-				return
-			}
+		expectedVersion, ok := requireIfMatch(c, prevPotofolioModel.Version)
+		if !ok {
+			return
+		}
 
-			images = funk.Map(storedImages, func(e models.StoredImageInfo) string {
-				return e.ImageUri
-			})
+		var reqUpdatePotofolio RequestUpdatePotofolio
+		if !bindJSON(c, &reqUpdatePotofolio) {
+			return
 		}
 
-		defer func(isComplete *bool) {
-			if !*isComplete {
-				for _, sotredImage := range storedImages {
-					os.Remove(sotredImage.ImageStorePath)
-				}
-			}
+		updatePotofolio(c, int64(id), expectedVersion, &reqUpdatePotofolio)
+	})
+
+	// 수정 (JSON Merge Patch, 이미지는 PUT 으로 바꾼다.)
+	api.PATCH("/potofolio/:id", func(c *gin.Context) {
 
-		}(&complete)
+		strPotofolioId := c.Param("id")
 
-		removeImages, err := potofolioRepository.UpdatePotofolio(int64(id), reqUpdatePotofolio.Title, reqUpdatePotofolio.RemoveImageIds, images.([]string))
+		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{
-				"result": "failed",
-				"error":  fmt.Sprintf("potofolioId = %d repository.UpdatePotofolio [err = %s]", id, err),
-			})
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		// 파일 지우기
-		for _, removeImage := range removeImages {
-			reletivePath := strings.Replace(removeImage, "/images", "./assets/images", 1)
-			absPath, err := filepath.Abs(reletivePath)
-			if err != nil {
-				fmt.Println(err)
-				continue
+		prevPotofolioModel, err := potofolioRepository.FindPotofolio(int64(id))
+		if err != nil {
+			if respondContentNotFound(c, err) {
+				return
 			}
 
-			os.Remove(absPath)
+			errorMessage := fmt.Sprintf("potofolioId = %d [err = %s]", id, err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		// 여기까지 오면 성공으로 간주한다.
-		complete = true
-
-		potofolioModel, err := potofolioRepository.FindPotofolio(int64(id))
-		if err != nil {
-			errorMessage := fmt.Sprintf("Update Potofolio after error [%v]", err)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		expectedVersion, ok := requireIfMatch(c, prevPotofolioModel.Version)
+		if !ok {
 			return
 		}
 
-		responsePotofolioElment := convertResponsePotofolioElement(potofolioModel)
-		responsePresent, err := SuccessResponsePresent(c, responsePotofolioElment)
-		if err != nil {
-			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+		prevPatchPotofolio := newPatchPotofolio(prevPotofolioModel)
+
+		var patchPotofolio PatchPotofolio
+		if !bindMergePatch(c, prevPatchPotofolio, &patchPotofolio) {
 			return
 		}
 
-		c.JSON(http.StatusOK, responsePresent)
+		updatePotofolio(c, int64(id), expectedVersion, patchPotofolio.updateRequest(prevPatchPotofolio))
 	})
 
 	// 제거
@@ -240,19 +487,36 @@ func PotofolioApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryC
 		id, err := strconv.Atoi(strPotofolioId)
 		if err != nil {
 			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strPotofolioId)
-			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		_, err = potofolioRepository.FindPotofolio(int64(id))
+		potofolioModel, err := potofolioRepository.FindPotofolio(int64(id))
 		if err != nil {
-			errorMessage := fmt.Sprintf("potofolio not found (id = %s)", strPotofolioId)
+			if respondContentNotFound(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("potofolio find occur exception (id = %s) [%v]", strPotofolioId, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
-		err = potofolioRepository.RemovePotofolio(int64(id))
+		expectedVersion, ok := requireIfMatch(c, potofolioModel.Version)
+		if !ok {
+			return
+		}
+
+		// 휴지통으로 보낸다. (영구 삭제는 보관 기간이 지난 뒤 PurgeExpiredTrash 에서)
+		err = potofolioRepository.TrashPotofolio(int64(id), expectedVersion)
 		if err != nil {
-			errorMessage := fmt.Sprintf("potofolioRepository.RemovePotofolio (id = %s) [%v]", strPotofolioId, err)
+			if respondVersionConflict(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("potofolioRepository.TrashPotofolio (id = %s) [%v]", strPotofolioId, err)
 			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
 		}
 
 		responsePresent, err := SuccessResponsePresent(c, nil)
@@ -0,0 +1,107 @@
+package apis
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 요청의 status, publish_at 으로 공개 상태를 만든다.
+// 둘 다 없으면 nil (수정 요청에서는 공개 상태를 바꾸지 않는다.)
+// status 없이 publish_at 만 있으면 예약 공개로 본다.
+func parsePublishOption(status *string, publishAt *time.Time) (*models.PublishOption, error) {
+
+	if status == nil && publishAt == nil {
+		return nil, nil
+	}
+
+	publishOption := &models.PublishOption{Status: models.PublishStatusScheduled, PublishAt: publishAt}
+	if status != nil {
+		publishStatus, err := models.ParsePublishStatus(*status)
+		if err != nil {
+			return nil, err
+		}
+
+		publishOption.Status = publishStatus
+	}
+
+	err := publishOption.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return publishOption, nil
+}
+
+// 목록에 보여줄 공개 상태
+// 로그인하지 않았으면 published 만, 로그인했으면 status query (쉼표 구분) 또는 전체
+func parseListStatuses(c *gin.Context, repositoryConfigure *models.RepositoryConfigure) ([]models.PublishStatus, error) {
+
+	if !isAuthorizedRequest(c, repositoryConfigure) {
+		return []models.PublishStatus{models.PublishStatusPublished}, nil
+	}
+
+	strStatuses := c.Query("status")
+	if len(strStatuses) == 0 {
+		return nil, nil
+	}
+
+	statuses := make([]models.PublishStatus, 0)
+	for _, strStatus := range strings.Split(strStatuses, ",") {
+		status, err := models.ParsePublishStatus(strings.TrimSpace(strStatus))
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// 없는 potofolio, essay 이면 404 로 응답한다.
+func respondContentNotFound(c *gin.Context, err error) bool {
+
+	switch err.(type) {
+	case *models.EssayNotFoundError, *models.PotofolioNotFoundError:
+		c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+		return true
+	}
+
+	return false
+}
+
+// 로그인하지 않은 요청에 보여줄 수 있는 content 인가
+func isVisibleContent(c *gin.Context, repositoryConfigure *models.RepositoryConfigure, status models.PublishStatus) bool {
+	return status.IsPublic() || isAuthorizedRequest(c, repositoryConfigure)
+}
+
+// 예약 시간이 지난 potofolio, essay 를 공개한다.
+func PublishScheduledContents(repositoryConfigure *models.RepositoryConfigure, now time.Time) error {
+
+	potofolioIds, err := repositoryConfigure.PotofolioRepository.PublishDuePotofolios(now)
+	if err != nil {
+		return fmt.Errorf("publish potofolio [%v]", err)
+	}
+
+	for _, potofolioId := range potofolioIds {
+		log.Printf("[info] scheduled potofolio published [id:%v]\n", potofolioId)
+	}
+
+	essayIds, err := repositoryConfigure.EssayRepository.PublishDueEssays(now)
+	if err != nil {
+		return fmt.Errorf("publish essay [%v]", err)
+	}
+
+	for _, essayId := range essayIds {
+		log.Printf("[info] scheduled essay published [id:%v]\n", essayId)
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+package apis
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 이보다 큰 응답은 캐시하지 않는다.
+const responseCacheMaxBodySize = 1 << 20
+
+// 캐시한 응답이 어떤 content 변경에 지워져야 하는지 (ContentChange 로 지운다.)
+// "potofolio" 는 potofolio 가 바뀌면 모두, "potofolio:list" 는 목록이 바뀌면 (추가, 삭제, 수정 모두 목록에 보인다.),
+// "potofolio:3" 은 potofolio 3 이 바뀌면, "potofolio:items" 는 모든 potofolio 가 바뀌면 (순서 변경) 지운다.
+type responseCacheDependency func(c *gin.Context) []string
+
+// 단건 조회는 id 로 들어오면 그 content 만, slug 로 들어오면 어떤 content 인지 모르므로 모든 변경에 지운다.
+func itemDependencies(content string, others ...string) responseCacheDependency {
+
+	return func(c *gin.Context) []string {
+
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			return append([]string{content}, others...)
+		}
+
+		return append([]string{fmt.Sprintf("%s:%d", content, id), content + ":items"}, others...)
+	}
+}
+
+func listDependencies(dependencies ...string) responseCacheDependency {
+
+	return func(c *gin.Context) []string {
+		return dependencies
+	}
+}
+
+// 캐시하는 공개 GET route (/api 다음 경로)
+// potofolio 응답에는 tag, collection 이름이, essay 응답에는 tag 이름이 들어 있다.
+var responseCacheRoutes = map[string]responseCacheDependency{
+	"/potofolio":                listDependencies("potofolio:list", models.TagContent, models.CollectionContent),
+	"/potofolio/:id":            itemDependencies(models.PotofolioContent, models.TagContent, models.CollectionContent),
+	"/essay":                    listDependencies("essay:list", models.TagContent),
+	"/essay/:id":                itemDependencies(models.EssayContent, models.TagContent),
+	"/about":                    listDependencies(models.AboutContent),
+	"/about-history-categories": listDependencies(models.AboutContent),
+	"/tags":                     listDependencies(models.TagContent, "potofolio:list", "essay:list"),
+	"/featured":                 listDependencies("potofolio:list", "essay:list", models.TagContent, models.CollectionContent),
+	"/collections":              listDependencies(models.CollectionContent, "potofolio:list"),
+	"/collections/:id":          listDependencies(models.CollectionContent, "potofolio:list"),
+	"/search":                   listDependencies("potofolio:list", "essay:list", models.AboutContent),
+}
+
+// 변경된 content 에 해당하는 dependency
+func changeDependencies(change models.ContentChange) []string {
+
+	dependencies := []string{change.Content, change.Content + ":list"}
+	if change.Id == models.AllContentIds {
+		dependencies = append(dependencies, change.Content+":items")
+	} else if change.Id > 0 {
+		dependencies = append(dependencies, fmt.Sprintf("%s:%d", change.Content, change.Id))
+	}
+
+	return dependencies
+}
+
+type responseCacheEntry struct {
+	key          string
+	contentType  string
+	etag         string
+	body         []byte
+	dependencies []string
+}
+
+// 공개 GET 응답을 최근 사용한 순서로 maxEntries 개까지 둔다.
+// repository 의 변경 알림 (models.ContentChange) 으로 해당하는 응답만 지운다.
+type ResponseCache struct {
+	mutex sync.Mutex
+
+	maxEntries int
+	lru        *list.List // 앞쪽이 최근 사용
+	entries    map[string]*list.Element
+
+	// dependency 별 캐시 key
+	dependents map[string]map[string]bool
+
+	// 변경 알림마다 올라간다.
+	// 조회하는 중에 변경되었으면 (이전 내용일 수 있으므로) 캐시하지 않는다.
+	generation uint64
+
+	bytes         int64
+	hits          uint64
+	misses        uint64
+	evictions     uint64
+	invalidations uint64
+}
+
+// maxEntries 가 0 이면 캐시하지 않는다. (통계만 보여준다.)
+func NewResponseCache(maxEntries int) *ResponseCache {
+
+	return &ResponseCache{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		dependents: make(map[string]map[string]bool),
+	}
+}
+
+func (cache *ResponseCache) IsEnabled() bool {
+	return cache.maxEntries > 0
+}
+
+// models.ContentChangeListener
+func (cache *ResponseCache) Invalidate(changes []models.ContentChange) {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.generation++
+
+	for _, change := range changes {
+		for _, dependency := range changeDependencies(change) {
+			for key := range cache.dependents[dependency] {
+				if element, ok := cache.entries[key]; ok {
+					cache.removeElement(element)
+					cache.invalidations++
+				}
+			}
+		}
+	}
+}
+
+func (cache *ResponseCache) Stats() *ResponseCacheStats {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	stats := &ResponseCacheStats{
+		Enabled:       cache.IsEnabled(),
+		Entries:       cache.lru.Len(),
+		MaxEntries:    cache.maxEntries,
+		Bytes:         cache.bytes,
+		Hits:          cache.hits,
+		Misses:        cache.misses,
+		Evictions:     cache.evictions,
+		Invalidations: cache.invalidations,
+	}
+
+	if requests := cache.hits + cache.misses; requests > 0 {
+		stats.HitRatio = float64(cache.hits) / float64(requests)
+	}
+
+	return stats
+}
+
+// 있으면 hit, 없으면 miss 로 센다.
+// miss 이면 캐시할 때 확인할 generation 을 같이 반환한다.
+func (cache *ResponseCache) get(key string) (*responseCacheEntry, uint64) {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		cache.misses++
+		return nil, cache.generation
+	}
+
+	cache.hits++
+	cache.lru.MoveToFront(element)
+
+	return element.Value.(*responseCacheEntry), cache.generation
+}
+
+func (cache *ResponseCache) put(entry *responseCacheEntry, generation uint64) {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if generation != cache.generation {
+		return
+	}
+
+	if element, ok := cache.entries[entry.key]; ok {
+		cache.removeElement(element)
+	}
+
+	cache.entries[entry.key] = cache.lru.PushFront(entry)
+	cache.bytes += int64(len(entry.body))
+
+	for _, dependency := range entry.dependencies {
+		keys, ok := cache.dependents[dependency]
+		if !ok {
+			keys = make(map[string]bool)
+			cache.dependents[dependency] = keys
+		}
+
+		keys[entry.key] = true
+	}
+
+	for cache.lru.Len() > cache.maxEntries {
+		cache.removeElement(cache.lru.Back())
+		cache.evictions++
+	}
+}
+
+func (cache *ResponseCache) removeElement(element *list.Element) {
+
+	entry := element.Value.(*responseCacheEntry)
+
+	cache.lru.Remove(element)
+	delete(cache.entries, entry.key)
+	cache.bytes -= int64(len(entry.body))
+
+	for _, dependency := range entry.dependencies {
+		delete(cache.dependents[dependency], entry.key)
+		if len(cache.dependents[dependency]) == 0 {
+			delete(cache.dependents, dependency)
+		}
+	}
+}
+
+// 같은 route 라도 query 순서가 다르면 같은 응답이다.
+func responseCacheKey(c *gin.Context) string {
+
+	query := c.Request.URL.Query().Encode()
+	if len(query) == 0 {
+		return c.Request.URL.Path
+	}
+
+	return c.Request.URL.Path + "?" + query
+}
+
+// handler 가 쓴 응답을 같이 모아둔다.
+type responseCacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+
+	// handler 가 직접 붙인 ETag (단건 version)
+	// 뒤의 ETag 는 HttpCacheMiddleware 가 붙이므로 status code 가 정해질 때 확인한다.
+	etag string
+}
+
+func (writer *responseCacheWriter) WriteHeader(statusCode int) {
+	writer.etag = writer.Header().Get("ETag")
+	writer.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (writer *responseCacheWriter) Write(data []byte) (int, error) {
+
+	if writer.body.Len() <= responseCacheMaxBodySize {
+		writer.body.Write(data)
+	}
+
+	return writer.ResponseWriter.Write(data)
+}
+
+func (writer *responseCacheWriter) WriteString(data string) (int, error) {
+	return writer.Write([]byte(data))
+}
+
+// basePath (/api, /api/v2) group 에 HttpCacheMiddleware 다음에 사용한다.
+// 로그인하지 않은 요청의 200 응답을 캐시해두고 다음 요청은 handler 를 실행하지 않고 응답한다.
+func ResponseCacheMiddleware(basePath string, cache *ResponseCache, repositoryConfigure *models.RepositoryConfigure) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		if !cache.IsEnabled() || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		dependencies, ok := responseCacheRoutes[strings.TrimPrefix(c.FullPath(), basePath)]
+		if !ok || isAuthorizedRequest(c, repositoryConfigure) {
+			c.Next()
+			return
+		}
+
+		key := responseCacheKey(c)
+		entry, generation := cache.get(key)
+		if entry != nil {
+			if len(entry.etag) > 0 {
+				c.Header("ETag", entry.etag)
+				if respondNotModified(c, entry.etag) {
+					c.Abort()
+					return
+				}
+			}
+
+			c.Data(http.StatusOK, entry.contentType, entry.body)
+			c.Abort()
+			return
+		}
+
+		writer := &responseCacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+
+		if writer.Status() != http.StatusOK || writer.body.Len() > responseCacheMaxBodySize {
+			return
+		}
+
+		cache.put(&responseCacheEntry{
+			key:          key,
+			contentType:  writer.Header().Get("Content-Type"),
+			etag:         writer.etag,
+			body:         append([]byte(nil), writer.body.Bytes()...),
+			dependencies: dependencies(c),
+		}, generation)
+	}
+}
+
+func ResponseCacheApis(api *gin.RouterGroup, cache *ResponseCache, repositoryConfigure *models.RepositoryConfigure) {
+
+	api.GET("/cache/stats", AuthorizeHandler(repositoryConfigure), func(c *gin.Context) {
+
+		responsePresent, err := SuccessResponsePresent(c, cache.Stats())
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
@@ -0,0 +1,163 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiV2ContextKey = "apiV2"
+
+// status code 별 v2 오류 code
+var responseErrorCodes = map[int]string{
+	http.StatusBadRequest:           "bad_request",
+	http.StatusUnauthorized:         "unauthorized",
+	http.StatusForbidden:            "forbidden",
+	http.StatusNotFound:             "not_found",
+	http.StatusConflict:             "conflict",
+	http.StatusPreconditionFailed:   "version_conflict",
+	http.StatusUnsupportedMediaType: "unsupported_media_type",
+	http.StatusUnprocessableEntity:  "validation_failed",
+	http.StatusPreconditionRequired: "if_match_required",
+	http.StatusInternalServerError:  "internal_error",
+}
+
+func responseErrorCode(statusCode int) string {
+	if code, ok := responseErrorCodes[statusCode]; ok {
+		return code
+	}
+
+	if statusCode >= http.StatusInternalServerError {
+		return "internal_error"
+	}
+
+	return "bad_request"
+}
+
+// /api/v2 로 들어온 요청인지
+func isApiV2(c *gin.Context) bool {
+	return c.GetBool(apiV2ContextKey)
+}
+
+// 요청 항목 오류를 응답한다.
+// /api 는 기존처럼 400, /api/v2 는 422 로 응답한다.
+func respondValidationFailed(c *gin.Context, fields ...ResponseFieldError) {
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field.Field, field.Message))
+	}
+
+	statusCode := http.StatusBadRequest
+	if isApiV2(c) {
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(statusCode, FailedResponsePresetWithData(strings.Join(messages, ", "), ResponseValidationFailed{Fields: fields}))
+}
+
+// handler 가 쓴 응답을 모아 두었다가 v2 형식으로 바꿔서 보낸다.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (writer *bufferedResponseWriter) WriteHeader(statusCode int) {
+	writer.statusCode = statusCode
+}
+
+func (writer *bufferedResponseWriter) WriteHeaderNow() {
+}
+
+func (writer *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return writer.body.Write(data)
+}
+
+func (writer *bufferedResponseWriter) WriteString(data string) (int, error) {
+	return writer.body.WriteString(data)
+}
+
+func (writer *bufferedResponseWriter) Status() int {
+	return writer.statusCode
+}
+
+func (writer *bufferedResponseWriter) Size() int {
+	return writer.body.Len()
+}
+
+func (writer *bufferedResponseWriter) Written() bool {
+	return writer.body.Len() > 0
+}
+
+// 기존 ResponsePresent 를 ResponseEnvelope 로 바꾼다.
+// ResponsePresent 가 아니면 (redirect 등) false 를 반환한다.
+func convertResponseEnvelope(statusCode int, body []byte) (*ResponseEnvelope, bool) {
+
+	var responsePresent ResponsePresent
+	err := json.Unmarshal(body, &responsePresent)
+	if err != nil || len(responsePresent.Result) == 0 {
+		return nil, false
+	}
+
+	var data json.RawMessage
+	if json.Valid([]byte(responsePresent.Data)) {
+		data = json.RawMessage(responsePresent.Data)
+	}
+
+	if responsePresent.Result == "success" {
+		return &ResponseEnvelope{Data: data}, true
+	}
+
+	responseError := &ResponseError{
+		Code:    responseErrorCode(statusCode),
+		Message: responsePresent.Error,
+	}
+
+	if len(data) > 0 {
+		var validationFailed ResponseValidationFailed
+		if json.Unmarshal(data, &validationFailed) == nil && len(validationFailed.Fields) > 0 {
+			responseError.Fields = validationFailed.Fields
+		} else {
+			responseError.Details = data
+		}
+	}
+
+	return &ResponseEnvelope{Error: responseError}, true
+}
+
+// /api/v2 group 에 사용한다.
+// handler 는 /api 와 같고 응답 형식만 바꾼다.
+func ResponseV2Middleware() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		c.Set(apiV2ContextKey, true)
+
+		originalWriter := c.Writer
+		bufferedWriter := &bufferedResponseWriter{ResponseWriter: originalWriter, statusCode: http.StatusOK}
+		c.Writer = bufferedWriter
+
+		c.Next()
+
+		c.Writer = originalWriter
+
+		body := bufferedWriter.body.Bytes()
+		if responseEnvelope, ok := convertResponseEnvelope(bufferedWriter.statusCode, body); ok {
+			converted, err := json.Marshal(responseEnvelope)
+			if err == nil {
+				body = converted
+			}
+		}
+
+		originalWriter.WriteHeader(bufferedWriter.statusCode)
+		originalWriter.WriteHeaderNow()
+		if len(body) > 0 {
+			originalWriter.Write(body)
+		}
+	}
+}
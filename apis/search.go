@@ -0,0 +1,108 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+const (
+	defaultSearchLimit  = 20
+	maxSearchQueryRunes = 100
+)
+
+var searchRepository *models.SearchRepository
+
+func convertResponseSearchElement(searchResultModel *models.SearchResultModel) *ResponseSearchElement {
+
+	return &ResponseSearchElement{
+		Type:           searchResultModel.Type.String(),
+		Id:             searchResultModel.Id,
+		Title:          searchResultModel.Title,
+		TitleHighlight: searchResultModel.TitleHighlight,
+		Snippet:        searchResultModel.Snippet,
+		Score:          searchResultModel.Score,
+	}
+}
+
+// 검색 query (q, type, limit) 를 읽는다.
+func parseSearchOption(c *gin.Context) (models.SearchOption, error) {
+
+	searchOption := models.SearchOption{Query: strings.TrimSpace(c.Query("q")), Limit: defaultSearchLimit}
+
+	if len(searchOption.Query) == 0 {
+		return searchOption, fmt.Errorf("q is empty")
+	}
+
+	if utf8.RuneCountInString(searchOption.Query) > maxSearchQueryRunes {
+		return searchOption, fmt.Errorf("q is too long (max %d)", maxSearchQueryRunes)
+	}
+
+	if strTypes := c.Query("type"); len(strTypes) > 0 {
+		for _, strType := range strings.Split(strTypes, ",") {
+			searchType, err := models.ParseRepositoryType(strings.TrimSpace(strType))
+			if err != nil || searchType == models.AboutType {
+				return searchOption, fmt.Errorf("type is wrong (type = %s, potofolio, essay, history)", strType)
+			}
+
+			searchOption.Types = append(searchOption.Types, searchType)
+		}
+	}
+
+	if strLimit := c.Query("limit"); len(strLimit) > 0 {
+		limit, err := strconv.Atoi(strLimit)
+		if err != nil || limit < 1 || limit > maxListPageLimit {
+			return searchOption, fmt.Errorf("limit is wrong (limit = %s, 1 ~ %d)", strLimit, maxListPageLimit)
+		}
+
+		searchOption.Limit = limit
+	}
+
+	return searchOption, nil
+}
+
+func SearchApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	searchRepository = repositoryConfigure.SearchRepository
+
+	// 로그인하지 않았으면 published content 만 찾는다.
+	api.GET("/search", func(c *gin.Context) {
+
+		searchOption, err := parseSearchOption(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		if !isAuthorizedRequest(c, repositoryConfigure) {
+			searchOption.Statuses = []models.PublishStatus{models.PublishStatusPublished}
+		}
+
+		searchResultModels, err := searchRepository.Search(searchOption)
+		if err != nil {
+			errorMessage := fmt.Sprintf("search error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		searchResults := make([]ResponseSearchElement, 0)
+		for _, searchResultModel := range searchResultModels {
+			searchResults = append(searchResults, *convertResponseSearchElement(&searchResultModel))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponseSearchList{List: searchResults})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
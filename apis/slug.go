@@ -0,0 +1,37 @@
+package apis
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+// 바뀌기 전 slug 로 들어온 요청을 현재 slug 로 보낸다.
+func redirectToSlug(c *gin.Context, slug string) {
+
+	location := path.Join(path.Dir(c.Request.URL.Path), url.PathEscape(slug))
+	if len(c.Request.URL.RawQuery) > 0 {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	c.Redirect(http.StatusMovedPermanently, location)
+}
+
+// slug 관련 오류이면 응답하고 true 를 반환한다.
+func respondSlugError(c *gin.Context, err error) bool {
+
+	switch err.(type) {
+	case *models.SlugConflictError:
+		c.JSON(http.StatusConflict, FailedResponsePreset(err.Error()))
+		return true
+	case *models.SlugNotFoundError:
+		c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+		return true
+	}
+
+	return false
+}
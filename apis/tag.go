@@ -0,0 +1,211 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+var tagRepository *models.TagRepository
+
+func convertResponseTagElements(tagModels []models.TagModel) []ResponseTagElement {
+
+	responseTags := make([]ResponseTagElement, 0, len(tagModels))
+	for _, tagModel := range tagModels {
+		responseTags = append(responseTags, ResponseTagElement{Id: tagModel.Id, Name: tagModel.Name})
+	}
+
+	return responseTags
+}
+
+func convertResponseTagCountElement(tagCountModel *models.TagCountModel) *ResponseTagCountElement {
+
+	return &ResponseTagCountElement{
+		Id:             tagCountModel.Id,
+		Name:           tagCountModel.Name,
+		Count:          tagCountModel.Count(),
+		PotofolioCount: tagCountModel.PotofolioCount,
+		EssayCount:     tagCountModel.EssayCount,
+	}
+}
+
+// tag 관련 오류이면 응답하고 true 를 반환한다.
+func respondTagError(c *gin.Context, err error) bool {
+
+	switch err.(type) {
+	case *models.TagConflictError:
+		c.JSON(http.StatusConflict, FailedResponsePreset(err.Error()))
+		return true
+	case *models.TagNotFoundError:
+		c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+		return true
+	}
+
+	return false
+}
+
+func TagApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	tagRepository = repositoryConfigure.TagRepository
+
+	// tag cloud (로그인하지 않았으면 published content 만 센다.)
+	api.GET("/tags", func(c *gin.Context) {
+
+		var statuses []models.PublishStatus
+		if !isAuthorizedRequest(c, repositoryConfigure) {
+			statuses = []models.PublishStatus{models.PublishStatusPublished}
+		}
+
+		tagCountModels, err := tagRepository.GetTagCounts(statuses)
+		if err != nil {
+			errorMessage := fmt.Sprintf("get tag list error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responseTags := make([]ResponseTagCountElement, 0)
+		for _, tagCountModel := range tagCountModels {
+			// 로그인하지 않았으면 공개된 content 가 없는 tag 는 보이지 않는다.
+			if statuses != nil && tagCountModel.Count() == 0 {
+				continue
+			}
+
+			responseTags = append(responseTags, *convertResponseTagCountElement(&tagCountModel))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponseTagList{List: responseTags})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.POST("/tags", func(c *gin.Context) {
+
+		var requestTag RequestTag
+		if !bindJSON(c, &requestTag) {
+			return
+		}
+
+		err := models.ValidateTagNames([]string{requestTag.Name})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		tagId, err := tagRepository.AddTag(requestTag.Name)
+		if err != nil {
+			if respondTagError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("AddTag error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		tagModel, err := tagRepository.FindTag(tagId)
+		if err != nil {
+			errorMessage := fmt.Sprintf("AddTag after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponseTagElement{Id: tagModel.Id, Name: tagModel.Name})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.PUT("/tags/:id", func(c *gin.Context) {
+		strTagId := c.Param("id")
+
+		id, err := strconv.ParseInt(strTagId, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strTagId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		var requestTag RequestTag
+		if !bindJSON(c, &requestTag) {
+			return
+		}
+
+		err = models.ValidateTagNames([]string{requestTag.Name})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		err = tagRepository.UpdateTag(id, requestTag.Name)
+		if err != nil {
+			if respondTagError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("UpdateTag error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		tagModel, err := tagRepository.FindTag(id)
+		if err != nil {
+			errorMessage := fmt.Sprintf("UpdateTag after error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, &ResponseTagElement{Id: tagModel.Id, Name: tagModel.Name})
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	// tag 만 지우고 tag 가 붙어 있던 content 는 그대로 둔다.
+	api.DELETE("/tags/:id", func(c *gin.Context) {
+		strTagId := c.Param("id")
+
+		id, err := strconv.ParseInt(strTagId, 10, 64)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strTagId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		err = tagRepository.RemoveTag(id)
+		if err != nil {
+			if respondTagError(c, err) {
+				return
+			}
+
+			errorMessage := fmt.Sprintf("RemoveTag error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, nil)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
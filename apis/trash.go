@@ -0,0 +1,168 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+func convertResponseTrashElement(trashModel *models.TrashModel, retentionTime time.Duration) *ResponseTrashElement {
+
+	return &ResponseTrashElement{
+		Type:      trashModel.Type.String(),
+		Id:        trashModel.Id,
+		Title:     trashModel.Title,
+		DeletedAt: trashModel.DeletedAt,
+		PurgeAt:   trashModel.DeletedAt.Add(retentionTime),
+	}
+}
+
+func getTrashList(repositoryConfigure *models.RepositoryConfigure) ([]models.TrashModel, error) {
+
+	trashModels := make([]models.TrashModel, 0)
+
+	potofolioTrashModels, err := repositoryConfigure.PotofolioRepository.GetTrashedPotofolioList()
+	if err != nil {
+		return nil, err
+	}
+	trashModels = append(trashModels, potofolioTrashModels...)
+
+	essayTrashModels, err := repositoryConfigure.EssayRepository.GetTrashedEssayList()
+	if err != nil {
+		return nil, err
+	}
+	trashModels = append(trashModels, essayTrashModels...)
+
+	historyTrashModels, err := repositoryConfigure.AboutRepository.GetTrashedHistoryList()
+	if err != nil {
+		return nil, err
+	}
+	trashModels = append(trashModels, historyTrashModels...)
+
+	// 최근에 지운 것 부터
+	sort.SliceStable(trashModels, func(i, j int) bool {
+		return trashModels[i].DeletedAt.After(trashModels[j].DeletedAt)
+	})
+
+	return trashModels, nil
+}
+
+func restoreTrash(repositoryConfigure *models.RepositoryConfigure, trashType models.RepositoryType, id int64) error {
+
+	switch trashType {
+	case models.PotofolioType:
+		return repositoryConfigure.PotofolioRepository.RestorePotofolio(id)
+	case models.EssayType:
+		return repositoryConfigure.EssayRepository.RestoreEssay(id)
+	case models.AboutHistoryType:
+		return repositoryConfigure.AboutRepository.RestoreHistory(id)
+	}
+
+	return fmt.Errorf("%v can not be restored", trashType)
+}
+
+// 보관 기간이 지난 휴지통 내용을 영구 삭제하고 image 파일도 지운다.
+func PurgeExpiredTrash(repositoryConfigure *models.RepositoryConfigure, now time.Time) error {
+
+	deletedBefore := now.Add(-repositoryConfigure.TrashRetentionTime)
+
+	removeImages, err := repositoryConfigure.PotofolioRepository.PurgeTrashedPotofolios(deletedBefore)
+	if err != nil {
+		return err
+	}
+	models.RemoveStoredImages("./assets/images", "/images", removeImages)
+
+	removeImages, err = repositoryConfigure.EssayRepository.PurgeTrashedEssays(deletedBefore)
+	if err != nil {
+		return err
+	}
+	models.RemoveStoredImages("./assets/images", "/images", removeImages)
+
+	err = repositoryConfigure.AboutRepository.PurgeTrashedHistories(deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func TrashApis(api *gin.RouterGroup, repositoryConfigure *models.RepositoryConfigure) {
+
+	api.GET("/trash", AuthorizeHandler(repositoryConfigure), func(c *gin.Context) {
+
+		trashModels, err := getTrashList(repositoryConfigure)
+		if err != nil {
+			errorMessage := fmt.Sprintf("get trash list error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		trashList := &ResponseTrashList{}
+		trashList.List = make([]ResponseTrashElement, 0)
+		for _, trashModel := range trashModels {
+			trashList.List = append(trashList.List, *convertResponseTrashElement(&trashModel, repositoryConfigure.TrashRetentionTime))
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, trashList)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+
+	api.POST("/trash/:type/:id/restore", func(c *gin.Context) {
+
+		trashType, err := models.ParseRepositoryType(c.Param("type"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		if trashType == models.AboutType {
+			errorMessage := fmt.Sprintf("%v can not be restored", trashType)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		strTrashId := c.Param("id")
+		id, err := strconv.Atoi(strTrashId)
+		if err != nil {
+			errorMessage := fmt.Sprintf("id is wroung (id = %s)", strTrashId)
+			c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		err = restoreTrash(repositoryConfigure, trashType, int64(id))
+		if err != nil {
+			var notFoundErr *models.TrashNotFoundError
+			if errors.As(err, &notFoundErr) {
+				c.JSON(http.StatusNotFound, FailedResponsePreset(err.Error()))
+				return
+			}
+
+			log.Printf("[error] restore trash [%v]\n", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(err.Error()))
+			return
+		}
+
+		responsePresent, err := SuccessResponsePresent(c, nil)
+		if err != nil {
+			errorMessage := fmt.Sprintf("create SuccessResponsePresent error [%v]", err)
+			c.JSON(http.StatusInternalServerError, FailedResponsePreset(errorMessage))
+			return
+		}
+
+		c.JSON(http.StatusOK, responsePresent)
+	})
+}
@@ -0,0 +1,132 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// 요청 packet 의 binding tag 로 확인한다. (packets.go 참고)
+// 오류가 나는 field 이름은 json 이름을 사용한다.
+func init() {
+
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	// 공백만 있는 문자열도 허용하지 않는다.
+	validate.RegisterValidation("notblank", func(fieldLevel validator.FieldLevel) bool {
+		return len(strings.TrimSpace(fieldLevel.Field().String())) > 0
+	})
+}
+
+// "RequestCreatePotofolio.RequestPotofolioMetadata.links[0].url" -> "links[0].url"
+// packet 이름과 json 이름이 없는 (embedded) struct 이름은 뺀다.
+func validationFieldName(namespace string) string {
+
+	names := make([]string, 0)
+	for _, name := range strings.Split(namespace, ".") {
+		if len(name) > 0 && unicode.IsUpper([]rune(name)[0]) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ".")
+}
+
+func validationMessage(fieldError validator.FieldError) string {
+
+	unit := "characters"
+	switch fieldError.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = "items"
+	}
+
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "max":
+		return fmt.Sprintf("must be at most %s %s", fieldError.Param(), unit)
+	case "min":
+		return fmt.Sprintf("must be at least %s %s", fieldError.Param(), unit)
+	case "base64":
+		return "must be base64 encoded"
+	case "oneof":
+		return fmt.Sprintf("must be one of (%s)", fieldError.Param())
+	}
+
+	return fmt.Sprintf("is wrong (%s)", fieldError.Tag())
+}
+
+// 요청 body 를 읽고 확인한다.
+// 응답을 이미 보냈으면 (잘못된 요청) false 를 반환한다.
+func bindJSON(c *gin.Context, request interface{}) bool {
+
+	err := c.ShouldBindJSON(request)
+
+	// body 가 없으면 빈 요청으로 확인한다.
+	if errors.Is(err, io.EOF) {
+		err = binding.Validator.ValidateStruct(request)
+	}
+
+	if err == nil {
+		return true
+	}
+
+	respondBindError(c, err)
+	return false
+}
+
+// bind, 확인 오류를 응답한다.
+// 항목 오류는 respondValidationFailed, 그 외는 400 으로 응답한다.
+func respondBindError(c *gin.Context, err error) {
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]ResponseFieldError, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			fields = append(fields, ResponseFieldError{
+				Field:   validationFieldName(fieldError.Namespace()),
+				Message: validationMessage(fieldError),
+			})
+		}
+
+		respondValidationFailed(c, fields...)
+		return
+	}
+
+	var unmarshalTypeError *json.UnmarshalTypeError
+	if errors.As(err, &unmarshalTypeError) && len(unmarshalTypeError.Field) > 0 {
+		respondValidationFailed(c, ResponseFieldError{
+			Field:   unmarshalTypeError.Field,
+			Message: fmt.Sprintf("must be %s", unmarshalTypeError.Type.String()),
+		})
+		return
+	}
+
+	errorMessage := fmt.Sprintf("request body is wrong [%v]", err)
+	c.JSON(http.StatusBadRequest, FailedResponsePreset(errorMessage))
+}
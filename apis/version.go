@@ -0,0 +1,85 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+func versionETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// If-Match 헤더의 version
+// 헤더가 없거나 * 이면 nil 을 반환한다.
+func parseIfMatch(c *gin.Context) (*int64, bool, error) {
+
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if len(ifMatch) == 0 {
+		return nil, false, nil
+	}
+
+	if ifMatch == "*" {
+		return nil, true, nil
+	}
+
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	ifMatch = strings.Trim(ifMatch, "\"")
+
+	version, err := strconv.ParseInt(ifMatch, 10, 64)
+	if err != nil {
+		return nil, true, fmt.Errorf("If-Match is wroung (If-Match = %s)", c.GetHeader("If-Match"))
+	}
+
+	return &version, true, nil
+}
+
+// PUT/DELETE 처럼 If-Match 가 필요한 요청
+// 응답을 이미 보냈으면 false 를 반환한다.
+func requireIfMatch(c *gin.Context, currentVersion int64) (*int64, bool) {
+
+	expectedVersion, present, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return nil, false
+	}
+
+	if !present {
+		c.Header("ETag", versionETag(currentVersion))
+		c.JSON(http.StatusPreconditionRequired, FailedResponsePresetWithData("If-Match header is required", ResponseVersionConflict{CurrentVersion: currentVersion}))
+		return nil, false
+	}
+
+	return expectedVersion, true
+}
+
+// If-Match 를 보냈을 때만 확인하는 요청
+func optionalIfMatch(c *gin.Context) (*int64, bool) {
+
+	expectedVersion, _, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, FailedResponsePreset(err.Error()))
+		return nil, false
+	}
+
+	return expectedVersion, true
+}
+
+// repository 의 VersionConflictError 를 412 로 응답한다.
+func respondVersionConflict(c *gin.Context, err error) bool {
+
+	var versionConflictErr *models.VersionConflictError
+	if !errors.As(err, &versionConflictErr) {
+		return false
+	}
+
+	c.Header("ETag", versionETag(versionConflictErr.CurrentVersion))
+	c.JSON(http.StatusPreconditionFailed, FailedResponsePresetWithData(err.Error(), ResponseVersionConflict{CurrentVersion: versionConflictErr.CurrentVersion}))
+	return true
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+func (client *Client) GetAbout() (*apis.ResponseAbout, error) {
+
+	var about apis.ResponseAbout
+	_, err := client.do(request{method: http.MethodGet, path: "/about"}, &about)
+	if err != nil {
+		return nil, err
+	}
+
+	return &about, nil
+}
+
+// version 이 다르면 ErrVersionConflict (AnyVersion 이면 확인하지 않는다.)
+func (client *Client) UpdateAbout(version int64, requestAbout *apis.RequestUpdateAbout) (*apis.ResponseAbout, error) {
+
+	var about apis.ResponseAbout
+	_, err := client.do(request{method: http.MethodPost, path: "/about", version: versionOf(version), body: requestAbout}, &about)
+	if err != nil {
+		return nil, err
+	}
+
+	return &about, nil
+}
+
+// JSON Merge Patch (RFC 7396) 로 수정한다. (PatchPotofolio 참고)
+func (client *Client) PatchAbout(version int64, patch interface{}) (*apis.ResponseAbout, error) {
+
+	var about apis.ResponseAbout
+	_, err := client.do(request{
+		method:      http.MethodPatch,
+		path:        "/about",
+		version:     versionOf(version),
+		body:        patch,
+		contentType: mergePatchContentType,
+	}, &about)
+	if err != nil {
+		return nil, err
+	}
+
+	return &about, nil
+}
+
+// 경력을 추가, 수정, 삭제한다.
+func (client *Client) UpdateAboutHistory(version int64, requestHistory *apis.RequestUpdateAboutHistory) (*apis.ResponseAbout, error) {
+
+	var about apis.ResponseAbout
+	_, err := client.do(request{method: http.MethodPost, path: "/about-history", version: versionOf(version), body: requestHistory}, &about)
+	if err != nil {
+		return nil, err
+	}
+
+	return &about, nil
+}
+
+func (client *Client) ListAboutHistoryCategories() (*apis.ResponseAboutHistoryCategoryList, error) {
+
+	var categoryList apis.ResponseAboutHistoryCategoryList
+	_, err := client.do(request{method: http.MethodGet, path: "/about-history-categories"}, &categoryList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &categoryList, nil
+}
+
+// names 순서로 경력 category 를 보여준다. (없는 category 는 뒤에 둔다.)
+func (client *Client) SetAboutHistoryCategoryOrder(version int64, names []string) (*apis.ResponseAboutHistoryCategoryList, error) {
+
+	var categoryList apis.ResponseAboutHistoryCategoryList
+	_, err := client.do(request{
+		method:  http.MethodPut,
+		path:    "/about-history-categories",
+		version: versionOf(version),
+		body:    &apis.RequestAboutHistoryCategoryOrder{Names: names},
+	}, &categoryList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &categoryList, nil
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 여러 potofolio, essay 를 한번에 삭제 (휴지통), 제목 변경, image 제거한다.
+// 실패한 작업이 있어도 오류가 아니므로 결과의 status 를 확인한다.
+func (client *Client) Bulk(req *apis.RequestBulk) (*apis.ResponseBulk, error) {
+
+	var responseBulk apis.ResponseBulk
+	_, err := client.do(request{method: http.MethodPost, path: "/bulk", body: req}, &responseBulk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseBulk, nil
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 요청은 /api/v2 (ResponseEnvelope) 로 보낸다.
+const apiPath = "/api/v2"
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// If-Match 에 * 를 보낸다. (version 을 확인하지 않는다.)
+const AnyVersion int64 = -1
+
+// chomakers api client
+// 로그인하면 access-token cookie 를 cookie jar 에 저장한다.
+// access-token 은 서버가 갱신해서 보내주고, refresh token 까지 만료되면 (401) 다시 로그인하고 한번 더 요청한다.
+type Client struct {
+	BaseUrl    string
+	HttpClient *http.Client
+
+	mutex    sync.Mutex
+	username string
+	password string
+}
+
+// baseUrl 은 "http://localhost:8081" 처럼 /api 앞까지
+func New(baseUrl string) (*Client, error) {
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		BaseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		HttpClient: &http.Client{Jar: jar},
+	}
+
+	return client, nil
+}
+
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	version *int64
+	body    interface{}
+
+	// 없으면 application/json
+	contentType string
+}
+
+func (client *Client) credentials() (string, string, bool) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	return client.username, client.password, len(client.username) > 0
+}
+
+func (client *Client) setCredentials(username string, password string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	client.username = username
+	client.password = password
+}
+
+// 요청을 보내고 응답 data 를 response 에 읽는다.
+func (client *Client) do(req request, response interface{}) (http.Header, error) {
+
+	header, err := client.send(req, response)
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || req.path == "/login" {
+		return header, err
+	}
+
+	// refresh token 까지 만료되었으면 다시 로그인한다.
+	username, password, ok := client.credentials()
+	if !ok {
+		return header, err
+	}
+
+	loginErr := client.login(username, password)
+	if loginErr != nil {
+		return header, err
+	}
+
+	return client.send(req, response)
+}
+
+func (client *Client) send(req request, response interface{}) (http.Header, error) {
+
+	requestUrl := client.BaseUrl + apiPath + req.path
+	if len(req.query) > 0 {
+		requestUrl += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		bodyBytes, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	httpRequest, err := http.NewRequest(req.method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.body != nil {
+		contentType := req.contentType
+		if len(contentType) == 0 {
+			contentType = "application/json"
+		}
+
+		httpRequest.Header.Set("Content-Type", contentType)
+	}
+
+	if req.version != nil {
+		httpRequest.Header.Set("If-Match", ifMatch(*req.version))
+	}
+
+	httpResponse, err := client.HttpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return httpResponse.Header, err
+	}
+
+	var responseEnvelope apis.ResponseEnvelope
+	unmarshalErr := json.Unmarshal(responseBytes, &responseEnvelope)
+
+	if httpResponse.StatusCode >= http.StatusBadRequest || responseEnvelope.Error != nil {
+		return httpResponse.Header, newApiError(httpResponse.StatusCode, responseEnvelope.Error, responseBytes)
+	}
+
+	if unmarshalErr != nil {
+		return httpResponse.Header, fmt.Errorf("response is wrong [%v]", unmarshalErr)
+	}
+
+	if response != nil && len(responseEnvelope.Data) > 0 {
+		err = json.Unmarshal(responseEnvelope.Data, response)
+		if err != nil {
+			return httpResponse.Header, fmt.Errorf("response data is wrong [%v]", err)
+		}
+	}
+
+	return httpResponse.Header, nil
+}
+
+func ifMatch(version int64) string {
+	if version == AnyVersion {
+		return "*"
+	}
+
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+func versionOf(version int64) *int64 {
+	return &version
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// statuses 는 로그인했을 때만 사용한다.
+func (client *Client) ListCollections(statuses ...string) (*apis.ResponseCollectionList, error) {
+
+	var collectionList apis.ResponseCollectionList
+	_, err := client.do(request{method: http.MethodGet, path: "/collections", query: statusQuery(statuses)}, &collectionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collectionList, nil
+}
+
+func (client *Client) GetCollection(id int64, statuses ...string) (*apis.ResponseCollectionElement, error) {
+
+	var collection apis.ResponseCollectionElement
+	_, err := client.do(request{method: http.MethodGet, path: fmt.Sprintf("/collections/%d", id), query: statusQuery(statuses)}, &collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (client *Client) CreateCollection(requestCollection *apis.RequestCreateCollection) (*apis.ResponseCollectionElement, error) {
+
+	var collection apis.ResponseCollectionElement
+	_, err := client.do(request{method: http.MethodPost, path: "/collections", body: requestCollection}, &collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (client *Client) UpdateCollection(id int64, requestCollection *apis.RequestUpdateCollection) (*apis.ResponseCollectionElement, error) {
+
+	var collection apis.ResponseCollectionElement
+	_, err := client.do(request{method: http.MethodPut, path: fmt.Sprintf("/collections/%d", id), body: requestCollection}, &collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (client *Client) DeleteCollection(id int64) error {
+	_, err := client.do(request{method: http.MethodDelete, path: fmt.Sprintf("/collections/%d", id)}, nil)
+	return err
+}
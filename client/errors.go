@@ -0,0 +1,91 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// api 가 오류로 응답했을 때
+// errors.Is(err, client.ErrNotFound) 처럼 status code 로 비교할 수 있다.
+type ApiError struct {
+	StatusCode int
+	Code       string // bad_request, not_found, version_conflict, validation_failed, ...
+	Message    string
+	Fields     []apis.ResponseFieldError
+
+	// version 충돌 (412, 428) 일 때 현재 version
+	CurrentVersion *int64
+}
+
+func (e *ApiError) Error() string {
+	if len(e.Code) == 0 {
+		return fmt.Sprintf("api error [status:%d, message:%s]", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("api error [status:%d, code:%s, message:%s]", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *ApiError) Is(target error) bool {
+	targetErr, ok := target.(*ApiError)
+	if !ok {
+		return false
+	}
+
+	return targetErr.StatusCode == e.StatusCode
+}
+
+var (
+	ErrBadRequest       = &ApiError{StatusCode: http.StatusBadRequest, Code: "bad_request"}
+	ErrUnauthorized     = &ApiError{StatusCode: http.StatusUnauthorized, Code: "unauthorized"}
+	ErrForbidden        = &ApiError{StatusCode: http.StatusForbidden, Code: "forbidden"}
+	ErrNotFound         = &ApiError{StatusCode: http.StatusNotFound, Code: "not_found"}
+	ErrConflict         = &ApiError{StatusCode: http.StatusConflict, Code: "conflict"}
+	ErrVersionConflict  = &ApiError{StatusCode: http.StatusPreconditionFailed, Code: "version_conflict"}
+	ErrValidationFailed = &ApiError{StatusCode: http.StatusUnprocessableEntity, Code: "validation_failed"}
+	ErrIfMatchRequired  = &ApiError{StatusCode: http.StatusPreconditionRequired, Code: "if_match_required"}
+	ErrInternal         = &ApiError{StatusCode: http.StatusInternalServerError, Code: "internal_error"}
+)
+
+// responseError 가 없으면 (gin 의 404 등) body 를 message 로 사용한다.
+func newApiError(statusCode int, responseError *apis.ResponseError, body []byte) *ApiError {
+
+	if responseError == nil {
+		return &ApiError{StatusCode: statusCode, Message: strings.TrimSpace(string(body))}
+	}
+
+	apiErr := &ApiError{
+		StatusCode: statusCode,
+		Code:       responseError.Code,
+		Message:    responseError.Message,
+		Fields:     responseError.Fields,
+	}
+
+	if len(responseError.Details) > 0 {
+		var versionConflict apis.ResponseVersionConflict
+		if json.Unmarshal(responseError.Details, &versionConflict) == nil {
+			apiErr.CurrentVersion = &versionConflict.CurrentVersion
+		}
+	}
+
+	return apiErr
+}
+
+// 로그인 결과가 성공이 아닐 때 (apis.ResponseLogin 의 result)
+type LoginFailedError struct {
+	Result int
+}
+
+func (e *LoginFailedError) Error() string {
+	switch e.Result {
+	case 1:
+		return "login failed [wrong username]"
+	case 2:
+		return "login failed [wrong password]"
+	}
+
+	return fmt.Sprintf("login failed [result:%d]", e.Result)
+}
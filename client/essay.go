@@ -0,0 +1,140 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+func (client *Client) ListEssays(option *ListOption) (*apis.ResponseEssayList, error) {
+
+	var essayList apis.ResponseEssayList
+	_, err := client.do(request{method: http.MethodGet, path: "/essay", query: option.query()}, &essayList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essayList, nil
+}
+
+func (client *Client) GetEssay(id int64) (*apis.ResponseEssayElement, error) {
+	return client.getEssay(fmt.Sprintf("/essay/%d", id))
+}
+
+func (client *Client) GetEssayBySlug(slug string) (*apis.ResponseEssayElement, error) {
+	return client.getEssay("/essay/" + url.PathEscape(slug))
+}
+
+func (client *Client) getEssay(path string) (*apis.ResponseEssayElement, error) {
+
+	var essay apis.ResponseEssayElement
+	_, err := client.do(request{method: http.MethodGet, path: path}, &essay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essay, nil
+}
+
+func (client *Client) CreateEssay(requestEssay *apis.RequestCreateEssay) (*apis.ResponseEssayElement, error) {
+
+	var essay apis.ResponseEssayElement
+	_, err := client.do(request{method: http.MethodPost, path: "/essay", body: requestEssay}, &essay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essay, nil
+}
+
+// version 이 다르면 ErrVersionConflict (AnyVersion 이면 확인하지 않는다.)
+func (client *Client) UpdateEssay(id int64, version int64, requestEssay *apis.RequestUpdateEssay) (*apis.ResponseEssayElement, error) {
+
+	var essay apis.ResponseEssayElement
+	_, err := client.do(request{
+		method:  http.MethodPut,
+		path:    fmt.Sprintf("/essay/%d", id),
+		version: versionOf(version),
+		body:    requestEssay,
+	}, &essay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essay, nil
+}
+
+// 휴지통으로 옮긴다.
+func (client *Client) DeleteEssay(id int64, version int64) error {
+
+	_, err := client.do(request{
+		method:  http.MethodDelete,
+		path:    fmt.Sprintf("/essay/%d", id),
+		version: versionOf(version),
+	}, nil)
+
+	return err
+}
+
+// 수정 이력 (로그인 필요)
+func (client *Client) ListEssayRevisions(id int64) (*apis.ResponseEssayRevisionList, error) {
+	return client.listEssayRevisions(id, nil)
+}
+
+// 수정 이력과 두 revision 의 차이
+func (client *Client) DiffEssayRevisions(id int64, from int64, to int64) (*apis.ResponseEssayRevisionList, error) {
+
+	query := url.Values{}
+	query.Set("from", strconv.FormatInt(from, 10))
+	query.Set("to", strconv.FormatInt(to, 10))
+
+	return client.listEssayRevisions(id, query)
+}
+
+func (client *Client) listEssayRevisions(id int64, query url.Values) (*apis.ResponseEssayRevisionList, error) {
+
+	var revisionList apis.ResponseEssayRevisionList
+	_, err := client.do(request{method: http.MethodGet, path: fmt.Sprintf("/essay/%d/revisions", id), query: query}, &revisionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revisionList, nil
+}
+
+// revision 의 내용으로 되돌린다. (새 revision 이 추가된다.)
+func (client *Client) RestoreEssayRevision(id int64, revision int64, version int64) (*apis.ResponseEssayElement, error) {
+
+	var essay apis.ResponseEssayElement
+	_, err := client.do(request{
+		method:  http.MethodPost,
+		path:    fmt.Sprintf("/essay/%d/revisions/%d/restore", id, revision),
+		version: versionOf(version),
+	}, &essay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essay, nil
+}
+
+// JSON Merge Patch (RFC 7396) 로 수정한다. (PatchPotofolio 참고)
+func (client *Client) PatchEssay(id int64, version int64, patch interface{}) (*apis.ResponseEssayElement, error) {
+
+	var essay apis.ResponseEssayElement
+	_, err := client.do(request{
+		method:      http.MethodPatch,
+		path:        fmt.Sprintf("/essay/%d", id),
+		version:     versionOf(version),
+		body:        patch,
+		contentType: mergePatchContentType,
+	}, &essay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &essay, nil
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 첫 화면 목록
+// limit 이 0 이면 서버 기본값, statuses 는 로그인했을 때만 사용한다.
+func (client *Client) GetFeatured(limit int, statuses ...string) (*apis.ResponseFeaturedList, error) {
+
+	query := statusQuery(statuses)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var featuredList apis.ResponseFeaturedList
+	_, err := client.do(request{method: http.MethodGet, path: "/featured", query: query}, &featuredList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &featuredList, nil
+}
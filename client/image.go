@@ -0,0 +1,47 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 요청에 넣을 이미지 (data 는 base64 로 보낸다.)
+func NewImage(filename string, data []byte) apis.RequestSaveImage {
+	return apis.RequestSaveImage{
+		Filename: filename,
+		Data:     base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+func ReadImageFile(path string) (apis.RequestSaveImage, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apis.RequestSaveImage{}, err
+	}
+
+	return NewImage(filepath.Base(path), data), nil
+}
+
+// 응답의 이미지 경로 ("/images/...") 로 이미지를 받는다.
+func (client *Client) DownloadImage(imageUrl string) ([]byte, error) {
+
+	httpResponse, err := client.HttpClient.Get(client.BaseUrl + imageUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, &ApiError{StatusCode: httpResponse.StatusCode, Message: fmt.Sprintf("image download failed [%s]", imageUrl)}
+	}
+
+	return io.ReadAll(httpResponse.Body)
+}
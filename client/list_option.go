@@ -0,0 +1,75 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// potofolio, essay 목록 조회 query
+// 값이 없는 항목은 보내지 않는다.
+type ListOption struct {
+	Sort   string // id, created, updated, published, title, position
+	Order  string // asc, desc
+	Limit  int
+	Cursor string
+
+	Title     string
+	Tag       string
+	MinId     *int64
+	MaxId     *int64
+	HasImages *bool
+	Featured  *bool
+
+	// 로그인했을 때만 사용한다.
+	Statuses []string
+}
+
+func (option *ListOption) query() url.Values {
+
+	query := url.Values{}
+	if option == nil {
+		return query
+	}
+
+	setQuery(query, "sort", option.Sort)
+	setQuery(query, "order", option.Order)
+	setQuery(query, "cursor", option.Cursor)
+	setQuery(query, "title", option.Title)
+	setQuery(query, "tag", option.Tag)
+	setQuery(query, "status", strings.Join(option.Statuses, ","))
+
+	if option.Limit > 0 {
+		query.Set("limit", strconv.Itoa(option.Limit))
+	}
+
+	if option.MinId != nil {
+		query.Set("min_id", strconv.FormatInt(*option.MinId, 10))
+	}
+
+	if option.MaxId != nil {
+		query.Set("max_id", strconv.FormatInt(*option.MaxId, 10))
+	}
+
+	if option.HasImages != nil {
+		query.Set("has_images", strconv.FormatBool(*option.HasImages))
+	}
+
+	if option.Featured != nil {
+		query.Set("featured", strconv.FormatBool(*option.Featured))
+	}
+
+	return query
+}
+
+func setQuery(query url.Values, key string, value string) {
+	if len(value) > 0 {
+		query.Set(key, value)
+	}
+}
+
+func statusQuery(statuses []string) url.Values {
+	query := url.Values{}
+	setQuery(query, "status", strings.Join(statuses, ","))
+	return query
+}
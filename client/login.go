@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 로그인하고 access-token cookie 를 저장한다.
+// 성공하면 username, password 를 기억해 두었다가 인증이 만료되면 다시 로그인한다.
+func (client *Client) Login(username string, password string) error {
+
+	err := client.login(username, password)
+	if err != nil {
+		return err
+	}
+
+	client.setCredentials(username, password)
+	return nil
+}
+
+func (client *Client) login(username string, password string) error {
+
+	var responseLogin apis.ResponseLogin
+	_, err := client.send(request{
+		method: http.MethodPost,
+		path:   "/login",
+		body:   &apis.RequestLogin{UserName: username, Password: password},
+	}, &responseLogin)
+	if err != nil {
+		return err
+	}
+
+	if responseLogin.LoginResult != 0 {
+		return &LoginFailedError{Result: responseLogin.LoginResult}
+	}
+
+	return nil
+}
+
+// access-token 을 지우고 다시 로그인하지 않는다.
+func (client *Client) Logout() error {
+
+	client.setCredentials("", "")
+
+	_, err := client.do(request{method: http.MethodGet, path: "/logout"}, nil)
+	return err
+}
+
+// 로그인 상태인지 확인한다. (access-token 이 만료되었으면 서버가 갱신한다.)
+func (client *Client) IsAuthenticated() (bool, error) {
+
+	_, err := client.send(request{method: http.MethodGet, path: "/authentication"}, nil)
+	if errors.Is(err, ErrUnauthorized) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+func (client *Client) ListPotofolios(option *ListOption) (*apis.ResponsePotofolioList, error) {
+
+	var potofolioList apis.ResponsePotofolioList
+	_, err := client.do(request{method: http.MethodGet, path: "/potofolio", query: option.query()}, &potofolioList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolioList, nil
+}
+
+func (client *Client) GetPotofolio(id int64) (*apis.ResponsePotofolioElement, error) {
+	return client.getPotofolio(fmt.Sprintf("/potofolio/%d", id))
+}
+
+func (client *Client) GetPotofolioBySlug(slug string) (*apis.ResponsePotofolioElement, error) {
+	return client.getPotofolio("/potofolio/" + url.PathEscape(slug))
+}
+
+func (client *Client) getPotofolio(path string) (*apis.ResponsePotofolioElement, error) {
+
+	var potofolio apis.ResponsePotofolioElement
+	_, err := client.do(request{method: http.MethodGet, path: path}, &potofolio)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolio, nil
+}
+
+func (client *Client) CreatePotofolio(requestPotofolio *apis.RequestCreatePotofolio) (*apis.ResponsePotofolioElement, error) {
+
+	var potofolio apis.ResponsePotofolioElement
+	_, err := client.do(request{method: http.MethodPost, path: "/potofolio", body: requestPotofolio}, &potofolio)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolio, nil
+}
+
+// version 이 다르면 ErrVersionConflict (AnyVersion 이면 확인하지 않는다.)
+func (client *Client) UpdatePotofolio(id int64, version int64, requestPotofolio *apis.RequestUpdatePotofolio) (*apis.ResponsePotofolioElement, error) {
+
+	var potofolio apis.ResponsePotofolioElement
+	_, err := client.do(request{
+		method:  http.MethodPut,
+		path:    fmt.Sprintf("/potofolio/%d", id),
+		version: versionOf(version),
+		body:    requestPotofolio,
+	}, &potofolio)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolio, nil
+}
+
+// 휴지통으로 옮긴다.
+func (client *Client) DeletePotofolio(id int64, version int64) error {
+
+	_, err := client.do(request{
+		method:  http.MethodDelete,
+		path:    fmt.Sprintf("/potofolio/%d", id),
+		version: versionOf(version),
+	}, nil)
+
+	return err
+}
+
+// ids 순서로 potofolio 순서를 바꾼다.
+func (client *Client) ReorderPotofolios(ids []int64) (*apis.ResponsePotofolioList, error) {
+
+	var potofolioList apis.ResponsePotofolioList
+	_, err := client.do(request{method: http.MethodPut, path: "/potofolio-order", body: &apis.RequestReorder{Ids: ids}}, &potofolioList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolioList, nil
+}
+
+// JSON Merge Patch (RFC 7396) 로 수정한다.
+// patch 는 map[string]interface{} 나 json.RawMessage 이고, 값이 nil (null) 인 항목은 지운다.
+func (client *Client) PatchPotofolio(id int64, version int64, patch interface{}) (*apis.ResponsePotofolioElement, error) {
+
+	var potofolio apis.ResponsePotofolioElement
+	_, err := client.do(request{
+		method:      http.MethodPatch,
+		path:        fmt.Sprintf("/potofolio/%d", id),
+		version:     versionOf(version),
+		body:        patch,
+		contentType: mergePatchContentType,
+	}, &potofolio)
+	if err != nil {
+		return nil, err
+	}
+
+	return &potofolio, nil
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// types 는 potofolio, essay, history (없으면 전체)
+// limit 이 0 이면 서버 기본값
+func (client *Client) Search(q string, types []string, limit int) (*apis.ResponseSearchList, error) {
+
+	query := url.Values{}
+	query.Set("q", q)
+	setQuery(query, "type", strings.Join(types, ","))
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var searchList apis.ResponseSearchList
+	_, err := client.do(request{method: http.MethodGet, path: "/search", query: query}, &searchList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searchList, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// tag 목록 (사용 횟수)
+func (client *Client) ListTags() (*apis.ResponseTagList, error) {
+
+	var tagList apis.ResponseTagList
+	_, err := client.do(request{method: http.MethodGet, path: "/tags"}, &tagList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tagList, nil
+}
+
+func (client *Client) CreateTag(name string) (*apis.ResponseTagElement, error) {
+
+	var tag apis.ResponseTagElement
+	_, err := client.do(request{method: http.MethodPost, path: "/tags", body: &apis.RequestTag{Name: name}}, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (client *Client) RenameTag(id int64, name string) (*apis.ResponseTagElement, error) {
+
+	var tag apis.ResponseTagElement
+	_, err := client.do(request{method: http.MethodPut, path: fmt.Sprintf("/tags/%d", id), body: &apis.RequestTag{Name: name}}, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (client *Client) DeleteTag(id int64) error {
+	_, err := client.do(request{method: http.MethodDelete, path: fmt.Sprintf("/tags/%d", id)}, nil)
+	return err
+}
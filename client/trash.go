@@ -0,0 +1,26 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+)
+
+// 휴지통 목록 (로그인 필요)
+func (client *Client) ListTrash() (*apis.ResponseTrashList, error) {
+
+	var trashList apis.ResponseTrashList
+	_, err := client.do(request{method: http.MethodGet, path: "/trash"}, &trashList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trashList, nil
+}
+
+// contentType 은 potofolio, essay, history
+func (client *Client) RestoreTrash(contentType string, id int64) error {
+	_, err := client.do(request{method: http.MethodPost, path: fmt.Sprintf("/trash/%s/%d/restore", contentType, id)}, nil)
+	return err
+}
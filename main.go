@@ -2,15 +2,17 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"syscall"
 
 	"time"
 
 	"github.com/gin-gonic/contrib/cors"
-	"github.com/gin-gonic/contrib/static"
 	"github.com/gin-gonic/gin"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/term"
@@ -27,7 +29,7 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Set-Cookie")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		/*
 			if c.Request.Method == "OPTIONS" {
@@ -40,19 +42,32 @@ func corsMiddleware() gin.HandlerFunc {
 	}
 }
 
-func serveMiddleware(localFileSystem static.ServeFileSystem) gin.HandlerFunc {
-	fileserver := http.FileServer(http.Dir("./view"))
+// viewPath 의 build 결과 (index.html, js, css) 를 응답한다.
+// .br, .gz 로 미리 압축한 파일이 있으면 그 파일을 보낸다.
+func serveMiddleware(viewPath string) gin.HandlerFunc {
+	viewFileSystem := http.Dir(viewPath)
 
 	fileRegex, _ := regexp.Compile("^/(manager|potofolio|essay|about|edit|new)/?.?")
 
+	// api, image route 는 view 파일을 찾지 않는다. (같은 이름의 파일이 route 를 가리지 않도록)
+	routeRegex, _ := regexp.Compile("^/(api|images)(/|$)")
+
 	return func(c *gin.Context) {
 
+		if routeRegex.MatchString(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
 		if c.Request.URL.Path == "/" || fileRegex.MatchString(c.Request.URL.Path) {
 
-			fmt.Println("matched!!! [" + c.Request.URL.Path + "]")
+			if apis.ServePrecompressedFile(c, viewFileSystem, "/index.html") {
+				c.Abort()
+				return
+			}
+		}
 
-			c.Request.URL.Path = "/"
-			fileserver.ServeHTTP(c.Writer, c.Request)
+		if apis.ServePrecompressedFile(c, viewFileSystem, c.Request.URL.Path) {
 			c.Abort()
 			return
 		}
@@ -68,7 +83,13 @@ func vertifyTokenMiddleware(repoConfigure *models.RepositoryConfigure) gin.Handl
 
 	return func(c *gin.Context) {
 
-		if c.Request.URL.Path == "/api/login" {
+		if c.Request.URL.Path == "/api/login" || c.Request.URL.Path == "/api/v2/login" {
+			c.Next()
+			return
+		}
+
+		// graphql 은 조회만 한다. (POST 로도 조회)
+		if c.Request.URL.Path == "/api/graphql" {
 			c.Next()
 			return
 		}
@@ -100,8 +121,6 @@ func Setup(repoConfigure *models.RepositoryConfigure, imagePath string) *gin.Eng
 
 	//router.LoadHTMLFiles("", "manager", "potofolio", "essay", "about")
 
-	//localFileSystem := static.LocalFile("./assets/images", true)
-	//router.Use(serveMiddleware(localFileSystem))
 	//router.Use(static.Serve("/assets", static.LocalFile("./assets", true)))
 
 	//router.Use(static.Serve("/images", localFileSystem))
@@ -112,8 +131,9 @@ func Setup(repoConfigure *models.RepositoryConfigure, imagePath string) *gin.Eng
 	corHandler := cors.New(cors.Config{
 		//AllowAllOrigins: true,
 		AllowedOrigins:   []string{"http://chomakers.com", "http://www.chomakers.com"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
-		AllowedHeaders:   []string{"Origin", "Cookie"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowedHeaders:   []string{"Origin", "Cookie", "If-Match", "If-None-Match", "If-Modified-Since"},
+		ExposedHeaders:   []string{"ETag", "Last-Modified"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 		//AllowOriginFunc: func(origin string) bool {
@@ -123,41 +143,180 @@ func Setup(repoConfigure *models.RepositoryConfigure, imagePath string) *gin.Eng
 	})
 	router.Use(corHandler)
 
-	if repoConfigure.IsCheckAuthorize {
-		router.Use(vertifyTokenMiddleware(repoConfigure))
+	// 응답 압축 (api, view 모두)
+	router.Use(apis.CompressionMiddleware(repoConfigure.CompressionMinSize))
+
+	// view (미리 압축한 .br, .gz 파일이 있으면 그 파일)
+	if len(repoConfigure.ViewPath) > 0 {
+		router.Use(serveMiddleware(repoConfigure.ViewPath))
 	}
 
-	router.Static("/images", imagePath)
+	// 저장된 image 는 바뀌지 않으므로 오래 캐시한다.
+	images := router.Group("/images", apis.ImmutableImageMiddleware())
+	images.Static("/", imagePath)
 
 	// api 등록 구간
+	// /api 는 기존 응답 형식, /api/v2 는 같은 api 를 v2 응답 형식 (data 를 json 그대로, 오류 code) 으로 보낸다.
+	// 인증 실패 응답도 v2 형식으로 보내기 위해 ResponseV2Middleware 다음에 인증을 확인한다.
 	api := router.Group("api")
+	apiV2 := router.Group("api/v2", apis.ResponseV2Middleware())
+
+	// 공개 조회 응답을 메모리에 두고, repository 가 content 를 바꾸면 해당하는 응답만 지운다.
+	responseCache := apis.NewResponseCache(repoConfigure.ResponseCacheSize)
+	repoConfigure.AddContentChangeListener(responseCache.Invalidate)
+
+	for _, group := range []*gin.RouterGroup{api, apiV2} {
+
+		if repoConfigure.IsCheckAuthorize {
+			group.Use(vertifyTokenMiddleware(repoConfigure))
+		}
+
+		group.Use(apis.HttpCacheMiddleware(group.BasePath(), repoConfigure))
+		group.Use(apis.ResponseCacheMiddleware(group.BasePath(), responseCache, repoConfigure))
+
+		apis.LoginApis(group, repoConfigure)
+		apis.PotofolioApis(group, repoConfigure)
+		apis.EssayApis(group, repoConfigure)
+		apis.AboutApis(group, repoConfigure)
+		apis.TrashApis(group, repoConfigure)
+		apis.TagApis(group, repoConfigure)
+		apis.SearchApis(group, repoConfigure)
+		apis.FeaturedApis(group, repoConfigure)
+		apis.CollectionApis(group, repoConfigure)
+		apis.BulkApis(group, repoConfigure)
+		apis.ResponseCacheApis(group, responseCache, repoConfigure)
+	}
+
+	// graphql 응답은 errors 형식이 정해져 있어서 v2 (ResponseV2Middleware) 에는 두지 않는다.
+	apis.GraphqlApis(api, repoConfigure)
 
-	apis.LoginApis(api, repoConfigure)
-	apis.PotofolioApis(api, repoConfigure)
-	apis.EssayApis(api, repoConfigure)
-	apis.AboutApis(api, repoConfigure)
+	// 문서는 등록된 route 로 만들기 때문에 마지막에 등록한다.
+	apis.OpenApiApis(api, router.Routes, repoConfigure.IsServeApiDocs)
 
 	return router
 }
 
-func RunWebServer(port string) {
-	dbConnection := models.DBConnection{}
-	dbConnection.Open("./assets/data.db")
+const dataSourcePath = "./assets/data.db"
+
+func openDatabase() (*models.DBConnection, error) {
+	dbConnection := &models.DBConnection{}
+	err := dbConnection.OpenWithOptions(dataSourcePath, models.DefaultDBOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return dbConnection, nil
+}
+
+func checkDatabase(dbConnection *models.DBConnection) error {
+	problems, err := dbConnection.IntegrityCheck()
+	if err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			log.Printf("[error] integrity_check [%s]\n", problem)
+		}
+
+		return fmt.Errorf("database integrity check failed (%d problems)", len(problems))
+	}
+
+	return nil
+}
+
+// 보관 기간이 지난 휴지통 내용을 주기적으로 영구 삭제한다.
+func runTrashPurgeWorker(repositoryConfigure *models.RepositoryConfigure, interval time.Duration) {
+
+	purge := func() {
+		err := apis.PurgeExpiredTrash(repositoryConfigure, time.Now())
+		if err != nil {
+			log.Printf("[error] purge expired trash [%v]\n", err)
+		}
+	}
+
+	purge()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purge()
+	}
+}
+
+// 예약 시간이 된 content 를 공개한다.
+func runPublishScheduler(repositoryConfigure *models.RepositoryConfigure, interval time.Duration) {
+
+	publish := func() {
+		err := apis.PublishScheduledContents(repositoryConfigure, time.Now())
+		if err != nil {
+			log.Printf("[error] publish scheduled contents [%v]\n", err)
+		}
+	}
+
+	publish()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		publish()
+	}
+}
+
+func RunWebServer(port string, trashRetentionTime time.Duration, isServeApiDocs bool, cacheControlPolicies []string, responseCacheSize int, compressionMinSize int, viewPath string) {
+	dbConnection, err := openDatabase()
+	if err != nil {
+		log.Fatalf("database open error [%v]\n", err)
+	}
 	defer dbConnection.Close()
 
+	// 손상된 db 로 서비스를 시작하지 않는다.
+	err = checkDatabase(dbConnection)
+	if err != nil {
+		log.Fatalf("%v\n", err)
+	}
+
 	repositoryConfigure := &models.RepositoryConfigure{}
-	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.Init(dbConnection)
+	repositoryConfigure.TrashRetentionTime = trashRetentionTime
+	repositoryConfigure.IsServeApiDocs = isServeApiDocs
+	repositoryConfigure.ResponseCacheSize = responseCacheSize
+	repositoryConfigure.CompressionMinSize = compressionMinSize
+	repositoryConfigure.ViewPath = viewPath
+
+	for _, cacheControlPolicy := range cacheControlPolicies {
+		route, cacheControl, err := apis.ParseCacheControlPolicy(cacheControlPolicy)
+		if err != nil {
+			log.Fatalf("%v\n", err)
+		}
+
+		repositoryConfigure.CacheControlPolicies[route] = cacheControl
+	}
+
+	orphanImages, err := repositoryConfigure.ImageRepository.FindOrphanImages()
+	if err != nil {
+		log.Printf("[error] find orphan images [%v]\n", err)
+	} else if len(orphanImages) > 0 {
+		log.Printf("[warning] %d images point at missing content (run 'db repair')\n", len(orphanImages))
+	}
+
+	go runTrashPurgeWorker(repositoryConfigure, time.Hour)
+	go runPublishScheduler(repositoryConfigure, time.Minute)
 
 	Setup(repositoryConfigure, "./assets/images").Run(port)
 }
 
 func CreateUser(username, password string) error {
-	dbConnection := models.DBConnection{}
-	dbConnection.Open("./assets/data.db")
+	dbConnection, err := openDatabase()
+	if err != nil {
+		return err
+	}
 	defer dbConnection.Close()
 
-	userRepository := &models.UserRespository{DBConnect: &dbConnection}
-	err := userRepository.CreateTable()
+	userRepository := &models.UserRespository{DBConnect: dbConnection}
+	err = userRepository.CreateTable()
 	if err != nil {
 		return err
 	}
@@ -170,6 +329,104 @@ func CreateUser(username, password string) error {
 	return nil
 }
 
+func VacuumDatabase() error {
+	dbConnection, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	return dbConnection.Vacuum()
+}
+
+func CheckDatabase() error {
+	dbConnection, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	return checkDatabase(dbConnection)
+}
+
+// 소유 content 가 없는 image row 를 보고하고, fix 이면 row 와 파일을 제거한다.
+func RepairOrphanImages(fix bool) error {
+	dbConnection, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(dbConnection)
+
+	imageRepository := repositoryConfigure.ImageRepository
+
+	var orphanImages []models.OrphanImageModel
+	if fix {
+		orphanImages, err = imageRepository.RemoveOrphanImages()
+	} else {
+		orphanImages, err = imageRepository.FindOrphanImages()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, orphanImage := range orphanImages {
+		fmt.Printf("image id = %d, dependencyType = %d, dependencyId = %d, path = %s\n",
+			orphanImage.Id, orphanImage.DependencyType, orphanImage.DependencyId, orphanImage.Path)
+
+		if !fix {
+			continue
+		}
+
+		reletivePath := strings.Replace(orphanImage.Path, "/images", "./assets/images", 1)
+		absPath, err := filepath.Abs(reletivePath)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		os.Remove(absPath)
+	}
+
+	if fix {
+		fmt.Printf("%d orphan images removed\n", len(orphanImages))
+	} else {
+		fmt.Printf("%d orphan images found\n", len(orphanImages))
+	}
+
+	return nil
+}
+
+// 검색 색인을 다시 만든다.
+func ReindexSearch() error {
+	dbConnection, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(dbConnection)
+
+	searchRepository := repositoryConfigure.SearchRepository
+
+	documentCount, err := searchRepository.Reindex()
+	if err != nil {
+		return err
+	}
+
+	if searchRepository.IsFtsEnabled() {
+		fmt.Printf("%d documents indexed (fts5)\n", documentCount)
+	} else {
+		fmt.Printf("%d documents indexed (fts5 not available, build with -tags sqlite_fts5)\n", documentCount)
+	}
+
+	return nil
+}
+
 func main() {
 
 	port := ":8081"
@@ -211,6 +468,72 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "reindex",
+				Usage: "rebuild search index",
+				Action: func(c *cli.Context) error {
+					err := ReindexSearch()
+					if err != nil {
+						fmt.Println(err.Error())
+						return err
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "db",
+				Usage: "database maintenance",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "vacuum",
+						Usage: "rebuild database file",
+						Action: func(c *cli.Context) error {
+							err := VacuumDatabase()
+							if err != nil {
+								fmt.Println(err.Error())
+								return err
+							}
+
+							fmt.Println("vacuum success")
+							return nil
+						},
+					},
+					{
+						Name:  "check",
+						Usage: "run PRAGMA integrity_check",
+						Action: func(c *cli.Context) error {
+							err := CheckDatabase()
+							if err != nil {
+								fmt.Println(err.Error())
+								return err
+							}
+
+							fmt.Println("integrity check ok")
+							return nil
+						},
+					},
+					{
+						Name:  "repair",
+						Usage: "report images whose content no longer exists",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "fix",
+								Usage: "remove reported image rows and files",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							err := RepairOrphanImages(c.Bool("fix"))
+							if err != nil {
+								fmt.Println(err.Error())
+								return err
+							}
+
+							return nil
+						},
+					},
+				},
+			},
 		},
 		Flags: []cli.Flag{
 			&cli.IntFlag{
@@ -218,12 +541,40 @@ func main() {
 				Usage: "server port",
 				Value: 8081,
 			},
+			&cli.IntFlag{
+				Name:  "trash-retention",
+				Usage: "days to keep deleted content before purge",
+				Value: 30,
+			},
+			&cli.BoolFlag{
+				Name:  "api-docs",
+				Usage: "serve api docs page at /api/docs",
+			},
+			&cli.StringSliceFlag{
+				Name:  "cache-control",
+				Usage: "Cache-Control of public GET route (/essay=public;max-age=60, empty value disables)",
+			},
+			&cli.IntFlag{
+				Name:  "response-cache-size",
+				Usage: "max public GET responses kept in memory (0 disables)",
+				Value: 512,
+			},
+			&cli.IntFlag{
+				Name:  "compression-min-size",
+				Usage: "compress responses (br, gzip) of at least this many bytes (0 disables)",
+				Value: 1024,
+			},
+			&cli.StringFlag{
+				Name:  "view-path",
+				Usage: "directory of view build (index.html, js, css) to serve (empty value disables)",
+				Value: "./view",
+			},
 		},
 	}
 
 	app.Action = func(c *cli.Context) error {
 		port = fmt.Sprintf(":%d", c.Int("port"))
-		RunWebServer(port)
+		RunWebServer(port, time.Duration(c.Int("trash-retention"))*24*time.Hour, c.Bool("api-docs"), c.StringSlice("cache-control"), c.Int("response-cache-size"), c.Int("compression-min-size"), c.String("view-path"))
 		return nil
 	}
 
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type AboutHistoryTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *AboutHistoryTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:about_history_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *AboutHistoryTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *AboutHistoryTestApiSuite) request(method string, url string, body string, data interface{}) int {
+	return suite.requestIfMatch(method, url, body, "", data)
+}
+
+func (suite *AboutHistoryTestApiSuite) requestIfMatch(method string, url string, body string, ifMatch string, data interface{}) int {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	if res.StatusCode == http.StatusOK && data != nil {
+		err = json.Unmarshal([]byte(responsePresent.Data), data)
+		suite.Assert().Nil(err)
+	}
+
+	return res.StatusCode
+}
+
+func (suite *AboutHistoryTestApiSuite) TestAboutHistoryDates() {
+
+	body := `{"append_history_list": [
+		{"category": "학력", "duration": "2010 ~ 2014", "content": "학부"},
+		{"category": "전시", "start_date": "2021-05", "end_date": "present", "content": "상설 전시"},
+		{"category": "전시", "start_date": "2018", "content": "첫 개인전"}
+	]}`
+
+	var about apis.ResponseAbout
+	statusCode := suite.request(http.MethodPost, "/api/about-history", body, &about)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(about.HistoryGroups), 2)
+	suite.Assert().Equal(about.HistoryGroups[0].Category, "학력")
+	suite.Assert().Equal(about.HistoryGroups[0].Histories[0].StartDate, "2010")
+	suite.Assert().Equal(about.HistoryGroups[0].Histories[0].EndDate, "2014")
+
+	exhibitions := about.HistoryGroups[1].Histories
+	suite.Assert().Equal(exhibitions[0].Content, "상설 전시")
+	suite.Assert().Equal(exhibitions[0].EndDate, models.HistoryDatePresent)
+	suite.Assert().Equal(exhibitions[0].Duration, "2021.05 ~ 현재")
+	suite.Assert().Equal(exhibitions[1].Duration, "2018")
+
+	// category 순서 수정은 If-Match 가 필요하다.
+	var categoryList apis.ResponseAboutHistoryCategoryList
+	statusCode = suite.request(http.MethodPut, "/api/about-history-categories", `{"names": ["전시"]}`, &categoryList)
+	suite.Assert().Equal(statusCode, http.StatusPreconditionRequired)
+
+	statusCode = suite.requestIfMatch(http.MethodPut, "/api/about-history-categories", `{"names": ["전시"]}`, "*", &categoryList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(categoryList.List), 2)
+	suite.Assert().Equal(categoryList.List[0].Name, "전시")
+	suite.Assert().Equal(*categoryList.List[0].DisplayOrder, 0)
+	suite.Assert().Nil(categoryList.List[1].DisplayOrder)
+
+	statusCode = suite.request(http.MethodGet, "/api/about", "", &about)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(about.HistoryGroups[0].Category, "전시")
+	suite.Assert().Equal(about.Histories[0].Content, "상설 전시")
+}
+
+func (suite *AboutHistoryTestApiSuite) TestAboutHistoryDatesBadRequest() {
+
+	bodies := []string{
+		`{"append_history_list": [{"category": "전시", "start_date": "2021-5"}]}`,
+		`{"append_history_list": [{"category": "전시", "start_date": "2021", "end_date": "2020"}]}`,
+		`{"append_history_list": [{"category": "전시", "end_date": "present"}]}`,
+	}
+
+	for _, body := range bodies {
+		statusCode := suite.request(http.MethodPost, "/api/about-history", body, nil)
+		suite.Assert().Equal(statusCode, http.StatusBadRequest, body)
+	}
+
+	statusCode := suite.request(http.MethodPut, "/api/about-history-categories", `{"names": ["전시", ""]}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodPut, "/api/about-history-categories", `{"names": ["전시", "전시"]}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+}
+
+func TestAboutHistoryTestApiSuite(t *testing.T) {
+	suite.Run(t, new(AboutHistoryTestApiSuite))
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type ApiV2TestSuite struct {
+	suite.Suite
+	dbConnection        *models.DBConnection
+	repositoryConfigure *models.RepositoryConfigure
+
+	testServer *httptest.Server
+}
+
+func (suite *ApiV2TestSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:api_v2_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+
+	suite.repositoryConfigure = repositoryConfigure
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *ApiV2TestSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *ApiV2TestSuite) request(method string, url string, body string) (int, *apis.ResponseEnvelope) {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responseEnvelope apis.ResponseEnvelope
+	err = json.Unmarshal(bytes, &responseEnvelope)
+	suite.Assert().Nil(err, string(bytes))
+
+	return res.StatusCode, &responseEnvelope
+}
+
+func (suite *ApiV2TestSuite) TestSuccess() {
+
+	statusCode, responseEnvelope := suite.request(http.MethodGet, "/api/v2/potofolio/1", "")
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Nil(responseEnvelope.Error)
+
+	// data 는 문자열이 아닌 json 객체이다.
+	var potofolio apis.ResponsePotofolioElement
+	err := json.Unmarshal(responseEnvelope.Data, &potofolio)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, "stone")
+
+	// /api 는 기존 형식 그대로
+	res, err := http.Get(suite.testServer.URL + "/api/potofolio/1")
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	var responsePresent apis.ResponsePresent
+	err = json.NewDecoder(res.Body).Decode(&responsePresent)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(responsePresent.Result, "success")
+	suite.Assert().Contains(responsePresent.Data, `"title":"stone"`)
+}
+
+func (suite *ApiV2TestSuite) TestErrorCodes() {
+
+	statusCode, responseEnvelope := suite.request(http.MethodGet, "/api/v2/potofolio/100", "")
+	suite.Assert().Equal(statusCode, http.StatusNotFound)
+	suite.Assert().Equal(responseEnvelope.Error.Code, "not_found")
+	suite.Assert().Nil(responseEnvelope.Data)
+
+	statusCode, responseEnvelope = suite.request(http.MethodDelete, "/api/v2/essay/abc", "")
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(responseEnvelope.Error.Code, "bad_request")
+
+	statusCode, responseEnvelope = suite.request(http.MethodPost, "/api/v2/collections", `{"title": " "}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(responseEnvelope.Error.Code, "validation_failed")
+	suite.Assert().Equal(responseEnvelope.Error.Fields[0].Field, "title")
+
+	// /api 는 기존처럼 400
+	req, _ := http.NewRequest(http.MethodPost, suite.testServer.URL+"/api/collections", strings.NewReader(`{"title": " "}`))
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusBadRequest)
+
+	// version 충돌은 details 에 current_version 이 있다.
+	req, _ = http.NewRequest(http.MethodDelete, suite.testServer.URL+"/api/v2/potofolio/1", nil)
+	req.Header.Set("If-Match", `"100"`)
+	res, err = http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	var responseEnvelopeConflict apis.ResponseEnvelope
+	json.NewDecoder(res.Body).Decode(&responseEnvelopeConflict)
+	res.Body.Close()
+
+	suite.Assert().Equal(res.StatusCode, http.StatusPreconditionFailed)
+	suite.Assert().Equal(responseEnvelopeConflict.Error.Code, "version_conflict")
+	suite.Assert().JSONEq(string(responseEnvelopeConflict.Error.Details), `{"current_version": 1}`)
+}
+
+func (suite *ApiV2TestSuite) TestUnauthorized() {
+
+	suite.repositoryConfigure.IsCheckAuthorize = true
+	defer func() { suite.repositoryConfigure.IsCheckAuthorize = false }()
+
+	server := httptest.NewServer(Setup(suite.repositoryConfigure, "./assets/images"))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/api/v2/potofolio/1", nil)
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	var responseEnvelope apis.ResponseEnvelope
+	err = json.NewDecoder(res.Body).Decode(&responseEnvelope)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(res.StatusCode, http.StatusUnauthorized)
+	suite.Assert().Equal(responseEnvelope.Error.Code, "unauthorized")
+}
+
+func TestApiV2TestSuite(t *testing.T) {
+	suite.Run(t, new(ApiV2TestSuite))
+}
@@ -121,12 +121,13 @@ func (suite *AuthroizeTestSuite) TestNotLoginAccess() {
 }
 
 func (suite *AuthroizeTestSuite) TestLoginAccess() {
-	reqCreatePotofolio := apis.RequestCreatePotofolio{}
+	// 빈 potofolio 는 요청 오류이므로 tag 추가로 확인한다.
+	reqCreateTag := apis.RequestTag{Name: "login access"}
 
-	bytes, err := json.Marshal(reqCreatePotofolio)
+	bytes, err := json.Marshal(reqCreateTag)
 	suite.Assert().Nil(err)
 
-	req, err := http.NewRequest(http.MethodPost, suite.getUrl()+"/api/potofolio", strings.NewReader(string(bytes)))
+	req, err := http.NewRequest(http.MethodPost, suite.getUrl()+"/api/tags", strings.NewReader(string(bytes)))
 	suite.Assert().Nil(err)
 
 	req.Header.Set("Content-Type", "application/json")
@@ -143,12 +144,13 @@ func (suite *AuthroizeTestSuite) TestLoginAccess() {
 }
 
 func (suite *AuthroizeTestSuite) TestExpireAccessToken() {
-	reqCreatePotofolio := apis.RequestCreatePotofolio{}
+	// 빈 potofolio 는 요청 오류이므로 tag 추가로 확인한다.
+	reqCreateTag := apis.RequestTag{Name: "expire access token"}
 
-	bytes, err := json.Marshal(reqCreatePotofolio)
+	bytes, err := json.Marshal(reqCreateTag)
 	suite.Assert().Nil(err)
 
-	req, err := http.NewRequest(http.MethodPost, suite.getUrl()+"/api/potofolio", strings.NewReader(string(bytes)))
+	req, err := http.NewRequest(http.MethodPost, suite.getUrl()+"/api/tags", strings.NewReader(string(bytes)))
 	suite.Assert().Nil(err)
 
 	req.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/client"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type BulkTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+	client     *client.Client
+}
+
+func (suite *BulkTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:bulk_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", []string{"/images/bulk_stone.jpg"})
+	repositoryConfigure.PotofolioRepository.AddPotofolio("sea", nil)
+	repositoryConfigure.EssayRepository.AddEssay("trip", "", "content", nil)
+	repositoryConfigure.EssayRepository.AddEssay("diary", "", "content", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+
+	apiClient, err := client.New(suite.testServer.URL)
+	suite.Assert().Nil(err)
+
+	suite.client = apiClient
+}
+
+func (suite *BulkTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *BulkTestApiSuite) TestBulkAtomic() {
+
+	title := "sea series"
+	wrongVersion := int64(100)
+	responseBulk, err := suite.client.Bulk(&apis.RequestBulk{
+		Atomic: true,
+		Operations: []apis.RequestBulkOperation{
+			{Type: "potofolio", Id: 2, Action: "retitle", Title: &title},
+			{Type: "essay", Id: 2, Action: "delete", Version: &wrongVersion},
+		},
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().False(responseBulk.Committed)
+	suite.Assert().Equal(responseBulk.Succeeded, 1)
+	suite.Assert().Equal(responseBulk.Failed, 1)
+	suite.Assert().Equal(responseBulk.Results[0].Status, http.StatusOK)
+	suite.Assert().False(responseBulk.Results[0].Applied)
+	suite.Assert().Equal(responseBulk.Results[1].Status, http.StatusPreconditionFailed)
+
+	potofolio, err := suite.client.GetPotofolio(2)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, "sea")
+}
+
+func (suite *BulkTestApiSuite) TestBulkPartial() {
+
+	title := "stone series"
+	responseBulk, err := suite.client.Bulk(&apis.RequestBulk{
+		Operations: []apis.RequestBulkOperation{
+			{Type: "potofolio", Id: 1, Action: "retitle", Title: &title},
+			{Type: "potofolio", Id: 1, Action: "remove_images"},
+			{Type: "essay", Id: 1, Action: "delete"},
+			{Type: "essay", Id: 100, Action: "delete"},
+		},
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().True(responseBulk.Committed)
+	suite.Assert().Equal(responseBulk.Succeeded, 3)
+	suite.Assert().Equal(responseBulk.Failed, 1)
+	suite.Assert().True(responseBulk.Results[1].Applied)
+	suite.Assert().Equal(*responseBulk.Results[1].Version, int64(3))
+	suite.Assert().Equal(responseBulk.Results[3].Status, http.StatusNotFound)
+
+	potofolio, err := suite.client.GetPotofolio(1)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, "stone series")
+	suite.Assert().Equal(len(potofolio.Images), 0)
+
+	_, err = suite.client.GetEssay(1)
+	suite.Assert().True(errors.Is(err, client.ErrNotFound))
+}
+
+func (suite *BulkTestApiSuite) TestBulkWrong() {
+
+	_, err := suite.client.Bulk(&apis.RequestBulk{})
+	suite.Assert().True(errors.Is(err, client.ErrValidationFailed))
+
+	_, err = suite.client.Bulk(&apis.RequestBulk{
+		Operations: []apis.RequestBulkOperation{{Type: "about", Id: 1, Action: "delete"}},
+	})
+
+	var apiErr *client.ApiError
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.Fields[0].Field, "operations[0].type")
+
+	// retitle 에는 title 이 필요하다.
+	_, err = suite.client.Bulk(&apis.RequestBulk{
+		Operations: []apis.RequestBulkOperation{{Type: "essay", Id: 2, Action: "retitle"}},
+	})
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.Fields[0].Field, "operations[0].title")
+}
+
+func TestBulkTestApiSuite(t *testing.T) {
+	suite.Run(t, new(BulkTestApiSuite))
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/client"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer   *httptest.Server
+	testUserName string
+	testPassword string
+}
+
+func (suite *ClientTestSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:client_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	suite.testUserName = "root"
+	repositoryConfigure.UserRepository.AddUser(suite.testUserName, "1234")
+	userModel, err := repositoryConfigure.UserRepository.GetUserModelFromUserName(suite.testUserName)
+	suite.Assert().Nil(err)
+
+	suite.testPassword = userModel.Password
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *ClientTestSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *ClientTestSuite) newClient() *client.Client {
+	apiClient, err := client.New(suite.testServer.URL)
+	suite.Assert().Nil(err)
+
+	return apiClient
+}
+
+func (suite *ClientTestSuite) TestLogin() {
+
+	apiClient := suite.newClient()
+
+	err := apiClient.Login(suite.testUserName, "wrong")
+	var loginFailedErr *client.LoginFailedError
+	suite.Assert().True(errors.As(err, &loginFailedErr))
+	suite.Assert().Equal(loginFailedErr.Result, 2)
+
+	_, err = apiClient.CreateTag("client login")
+	suite.Assert().True(errors.Is(err, client.ErrUnauthorized))
+
+	err = apiClient.Login(suite.testUserName, suite.testPassword)
+	suite.Assert().Nil(err)
+
+	tag, err := apiClient.CreateTag("client login")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(tag.Name, "client login")
+
+	isAuthenticated, err := apiClient.IsAuthenticated()
+	suite.Assert().Nil(err)
+	suite.Assert().True(isAuthenticated)
+
+	// 로그아웃하면 다시 로그인하지 않는다.
+	err = apiClient.Logout()
+	suite.Assert().Nil(err)
+
+	isAuthenticated, err = apiClient.IsAuthenticated()
+	suite.Assert().Nil(err)
+	suite.Assert().False(isAuthenticated)
+
+	err = apiClient.DeleteTag(tag.Id)
+	suite.Assert().True(errors.Is(err, client.ErrUnauthorized))
+}
+
+// 인증이 만료되면 (cookie 가 없어지면) 다시 로그인하고 요청한다.
+func (suite *ClientTestSuite) TestRelogin() {
+
+	apiClient := suite.newClient()
+
+	err := apiClient.Login(suite.testUserName, suite.testPassword)
+	suite.Assert().Nil(err)
+
+	apiClient.HttpClient.Jar, err = cookiejar.New(nil)
+	suite.Assert().Nil(err)
+
+	tag, err := apiClient.CreateTag("client relogin")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(tag.Name, "client relogin")
+}
+
+func (suite *ClientTestSuite) TestErrors() {
+
+	apiClient := suite.newClient()
+
+	err := apiClient.Login(suite.testUserName, suite.testPassword)
+	suite.Assert().Nil(err)
+
+	_, err = apiClient.GetPotofolio(100)
+	suite.Assert().True(errors.Is(err, client.ErrNotFound))
+
+	title := "sea"
+	_, err = apiClient.UpdatePotofolio(1, 100, &apis.RequestUpdatePotofolio{Title: &title})
+	suite.Assert().True(errors.Is(err, client.ErrVersionConflict))
+
+	var apiErr *client.ApiError
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.Code, "version_conflict")
+	suite.Assert().Equal(*apiErr.CurrentVersion, int64(1))
+
+	_, err = apiClient.CreatePotofolio(&apis.RequestCreatePotofolio{Title: "sea"})
+	suite.Assert().True(errors.Is(err, client.ErrValidationFailed))
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.Fields[0].Field, "images")
+}
+
+func (suite *ClientTestSuite) TestUpdate() {
+
+	apiClient := suite.newClient()
+
+	err := apiClient.Login(suite.testUserName, suite.testPassword)
+	suite.Assert().Nil(err)
+
+	potofolio, err := apiClient.GetPotofolio(1)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, "stone")
+
+	featured := true
+	updated, err := apiClient.UpdatePotofolio(potofolio.Id, potofolio.Version, &apis.RequestUpdatePotofolio{Featured: &featured})
+	suite.Assert().Nil(err)
+	suite.Assert().True(updated.Featured)
+	suite.Assert().Equal(updated.Version, potofolio.Version+1)
+
+	bySlug, err := apiClient.GetPotofolioBySlug(updated.Slug)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(bySlug.Id, potofolio.Id)
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type CollectionTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *CollectionTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:collection_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolio("stone", nil)
+	potofolioRepo.AddPotofolio("sea", nil)
+	potofolioRepo.AddPotofolio("wood", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *CollectionTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *CollectionTestApiSuite) request(method string, url string, body string, data interface{}) int {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	if res.StatusCode == http.StatusOK && data != nil {
+		err = json.Unmarshal([]byte(responsePresent.Data), data)
+		suite.Assert().Nil(err)
+	}
+
+	return res.StatusCode
+}
+
+func (suite *CollectionTestApiSuite) TestCollection() {
+
+	var collection apis.ResponseCollectionElement
+	statusCode := suite.request(http.MethodPost, "/api/collections", `{"title": "summer", "description": "여름", "potofolio_ids": [3, 1]}`, &collection)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(collection.PotofolioIds, []int64{3, 1})
+	suite.Assert().Equal(collection.PotofolioList[0].Title, "wood")
+
+	var potofolio apis.ResponsePotofolioElement
+	statusCode = suite.request(http.MethodGet, "/api/potofolio/1", "", &potofolio)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(potofolio.Collections), 1)
+	suite.Assert().Equal(potofolio.Collections[0].Title, "summer")
+
+	statusCode = suite.request(http.MethodPut, "/api/collections/1", `{"title": "summer series", "potofolio_ids": [2]}`, &collection)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(collection.Title, "summer series")
+	suite.Assert().Equal(collection.Description, "여름")
+	suite.Assert().Equal(collection.PotofolioList[0].Title, "sea")
+
+	var collectionList apis.ResponseCollectionList
+	statusCode = suite.request(http.MethodGet, "/api/collections", "", &collectionList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(collectionList.List), 1)
+	suite.Assert().Nil(collectionList.List[0].PotofolioList)
+
+	statusCode = suite.request(http.MethodDelete, "/api/collections/1", "", nil)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	statusCode = suite.request(http.MethodGet, "/api/collections/1", "", nil)
+	suite.Assert().Equal(statusCode, http.StatusNotFound)
+}
+
+func (suite *CollectionTestApiSuite) TestCollectionBadRequest() {
+
+	statusCode := suite.request(http.MethodPost, "/api/collections", `{"title": " "}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodPost, "/api/collections", `{"title": "wrong", "potofolio_ids": [100]}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodPut, "/api/collections/100", `{"title": "none"}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusNotFound)
+}
+
+func TestCollectionTestApiSuite(t *testing.T) {
+	suite.Run(t, new(CollectionTestApiSuite))
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type CompressionTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	viewPath       string
+	testServer     *httptest.Server
+	testViewServer *httptest.Server
+}
+
+func (suite *CompressionTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:compression_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+	repositoryConfigure.CompressionMinSize = 256
+
+	for _, title := range []string{"stone", "sea", "wind", "forest", "river"} {
+		repositoryConfigure.EssayRepository.AddEssay(title, "", strings.Repeat(title+" ", 20), nil)
+	}
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+
+	// view build 결과 (index.html 은 .gz 만, app.js 는 .br 만 있다. api/essay 는 api route 와 이름이 같은 파일)
+	viewPath, err := ioutil.TempDir("", "compression_api_test")
+	suite.Assert().Nil(err)
+
+	files := map[string]string{
+		"index.html":    "<html>index</html>",
+		"index.html.gz": "gzip index",
+		"app.js":        "console.log('app')",
+		"app.js.br":     "brotli app",
+		"api/essay":     "view essay",
+	}
+
+	err = os.Mkdir(filepath.Join(viewPath, "api"), 0755)
+	suite.Assert().Nil(err)
+
+	for name, content := range files {
+		err = ioutil.WriteFile(filepath.Join(viewPath, name), []byte(content), 0644)
+		suite.Assert().Nil(err)
+	}
+
+	// view 는 Setup 에서 ViewPath 가 있을 때 응답한다.
+	viewConfigure := &models.RepositoryConfigure{}
+	viewConfigure.Init(&dbConnection)
+	viewConfigure.CompressionMinSize = 1
+	viewConfigure.ViewPath = viewPath
+
+	suite.viewPath = viewPath
+	suite.testViewServer = httptest.NewServer(Setup(viewConfigure, "./assets/images"))
+}
+
+func (suite *CompressionTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.testViewServer.Close()
+	suite.dbConnection.Close()
+	os.RemoveAll(suite.viewPath)
+}
+
+// Accept-Encoding 을 직접 보내면 http.Client 가 압축을 풀지 않는다.
+func (suite *CompressionTestApiSuite) get(url string, acceptEncoding string) (*http.Response, []byte) {
+
+	req, err := http.NewRequest("GET", url, nil)
+	suite.Assert().Nil(err)
+
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	return res, resBytes
+}
+
+func (suite *CompressionTestApiSuite) TestGzipResponse() {
+
+	res, resBytes := suite.get(suite.testServer.URL+"/api/v2/essay", "br;q=0.5, gzip;q=0.8")
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "gzip")
+	suite.Assert().Equal(res.Header.Values("Vary"), []string{"Cookie", "Accept-Encoding"})
+
+	reader, err := gzip.NewReader(bytes.NewReader(resBytes))
+	suite.Assert().Nil(err)
+
+	body, err := io.ReadAll(reader)
+	suite.Assert().Nil(err)
+
+	var envelope apis.ResponseEnvelope
+	err = json.Unmarshal(body, &envelope)
+	suite.Assert().Nil(err)
+	suite.Assert().Contains(string(envelope.Data), "forest")
+
+	// 캐시된 응답도 압축한다.
+	res, cachedBytes := suite.get(suite.testServer.URL+"/api/v2/essay", "gzip")
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "gzip")
+
+	reader, err = gzip.NewReader(bytes.NewReader(cachedBytes))
+	suite.Assert().Nil(err)
+
+	cachedBody, err := io.ReadAll(reader)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(cachedBody, body)
+}
+
+func (suite *CompressionTestApiSuite) TestBrotliResponse() {
+
+	// q 가 같으면 br 을 고른다.
+	for _, acceptEncoding := range []string{"br", "gzip, deflate, br"} {
+		res, resBytes := suite.get(suite.testServer.URL+"/api/v2/essay", acceptEncoding)
+		suite.Assert().Equal(res.StatusCode, http.StatusOK)
+		suite.Assert().Equal(res.Header.Get("Content-Encoding"), "br")
+		suite.Assert().Equal(res.Header.Values("Vary"), []string{"Cookie", "Accept-Encoding"})
+
+		body, err := io.ReadAll(brotli.NewReader(bytes.NewReader(resBytes)))
+		suite.Assert().Nil(err)
+
+		var envelope apis.ResponseEnvelope
+		err = json.Unmarshal(body, &envelope)
+		suite.Assert().Nil(err)
+		suite.Assert().Contains(string(envelope.Data), "forest")
+	}
+}
+
+func (suite *CompressionTestApiSuite) TestNotCompressed() {
+
+	// 받을 수 없는 encoding
+	for _, acceptEncoding := range []string{"identity", "gzip;q=0", "br;q=0, gzip;q=0"} {
+		res, resBytes := suite.get(suite.testServer.URL+"/api/essay", acceptEncoding)
+		suite.Assert().Equal(res.StatusCode, http.StatusOK)
+		suite.Assert().Equal(res.Header.Get("Content-Encoding"), "")
+		suite.Assert().Contains(res.Header.Values("Vary"), "Accept-Encoding")
+		suite.Assert().True(json.Valid(resBytes))
+	}
+
+	// 작은 응답
+	res, resBytes := suite.get(suite.testServer.URL+"/api/v2/tags", "gzip")
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "")
+	suite.Assert().True(json.Valid(resBytes))
+}
+
+func (suite *CompressionTestApiSuite) TestPrecompressedView() {
+
+	res, resBytes := suite.get(suite.testViewServer.URL+"/potofolio/3", "br, gzip")
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "gzip")
+	suite.Assert().Equal(res.Header.Get("Content-Type"), "text/html; charset=utf-8")
+	suite.Assert().Equal(res.Header.Get("Vary"), "Accept-Encoding")
+	suite.Assert().Equal(string(resBytes), "gzip index")
+
+	res, resBytes = suite.get(suite.testViewServer.URL+"/app.js", "gzip, br")
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "br")
+	suite.Assert().Contains(res.Header.Get("Content-Type"), "javascript")
+	suite.Assert().Equal(string(resBytes), "brotli app")
+
+	// 미리 압축한 파일을 받을 수 없으면 원래 파일 (압축 가능하면 gzip)
+	res, resBytes = suite.get(suite.testViewServer.URL+"/app.js", "identity")
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "")
+	suite.Assert().Equal(res.Header.Get("Vary"), "Accept-Encoding")
+	suite.Assert().Equal(string(resBytes), "console.log('app')")
+
+	res, _ = suite.get(suite.testViewServer.URL+"/app.js", "gzip")
+	suite.Assert().Equal(res.Header.Get("Content-Encoding"), "gzip")
+	suite.Assert().NotEqual(res.Header.Get("Content-Length"), "18")
+
+	res, _ = suite.get(suite.testViewServer.URL+"/missing.js", "gzip")
+	suite.Assert().Equal(res.StatusCode, http.StatusNotFound)
+
+	// 같은 이름의 view 파일이 있어도 api 가 응답한다.
+	res, resBytes = suite.get(suite.testViewServer.URL+"/api/essay", "identity")
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().NotEqual(string(resBytes), "view essay")
+	suite.Assert().True(json.Valid(resBytes))
+
+	// ViewPath 가 없으면 view 를 응답하지 않는다.
+	res, _ = suite.get(suite.testServer.URL+"/app.js", "gzip")
+	suite.Assert().Equal(res.StatusCode, http.StatusNotFound)
+}
+
+func TestCompressionApiSuite(t *testing.T) {
+	suite.Run(t, new(CompressionTestApiSuite))
+}
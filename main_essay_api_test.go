@@ -242,6 +242,8 @@ func (suite *EssayTestApiSuite) TestEssayPutApi() {
 	req, err := http.NewRequest(http.MethodPut, suite.getUrl()+"/api/essay/2", requestReader)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	client := &http.Client{}
 
@@ -301,6 +303,8 @@ func (suite *EssayTestApiSuite) TestPtofolioPutPartialApi() {
 	req, err := http.NewRequest(http.MethodPut, suite.getUrl()+"/api/essay/2", requestReader)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	client := &http.Client{}
 
@@ -329,6 +333,8 @@ func (suite *EssayTestApiSuite) TestEssayDeleteApi() {
 	req, err := http.NewRequest(http.MethodDelete, suite.getUrl()+"/api/essay/4", nil)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	client := http.Client{}
 	client.Do(req)
 
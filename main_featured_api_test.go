@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type FeaturedTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *FeaturedTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:featured_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	featured := true
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Featured: &featured}, "stone", nil)
+	potofolioRepo.AddPotofolio("sea", nil)
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Featured: &featured}, "wood", nil)
+
+	repositoryConfigure.EssayRepository.AddEssayWithOption("", &models.ContentOption{Featured: &featured}, "trip", "", "", nil)
+	repositoryConfigure.EssayRepository.AddEssay("diary", "", "", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *FeaturedTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *FeaturedTestApiSuite) request(method string, url string, body string, data interface{}) int {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	if res.StatusCode == http.StatusOK && data != nil {
+		err = json.Unmarshal([]byte(responsePresent.Data), data)
+		suite.Assert().Nil(err)
+	}
+
+	return res.StatusCode
+}
+
+func (suite *FeaturedTestApiSuite) TestFeaturedAndReorder() {
+
+	var featuredList apis.ResponseFeaturedList
+	statusCode := suite.request(http.MethodGet, "/api/featured", "", &featuredList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	suite.Assert().Equal(len(featuredList.PotofolioList), 2)
+	suite.Assert().Equal(featuredList.PotofolioList[0].Title, "stone")
+	suite.Assert().Equal(featuredList.PotofolioList[1].Title, "wood")
+	suite.Assert().Equal(len(featuredList.EssayList), 1)
+	suite.Assert().True(featuredList.EssayList[0].Featured)
+
+	var potofolioList apis.ResponsePotofolioList
+	statusCode = suite.request(http.MethodPut, "/api/potofolio-order", `{"ids": [3, 2]}`, &potofolioList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(potofolioList.List[0].Title, "wood")
+	suite.Assert().Equal(potofolioList.List[0].Position, int64(1))
+
+	// sort 가 없으면 직접 정한 순서
+	statusCode = suite.request(http.MethodGet, "/api/potofolio", "", &potofolioList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(potofolioList.List[0].Title, "wood")
+	suite.Assert().Equal(potofolioList.List[1].Title, "sea")
+	suite.Assert().Equal(potofolioList.List[2].Title, "stone")
+
+	statusCode = suite.request(http.MethodGet, "/api/potofolio?featured=false", "", &potofolioList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(potofolioList.List), 1)
+	suite.Assert().Equal(potofolioList.List[0].Title, "sea")
+
+	statusCode = suite.request(http.MethodGet, "/api/featured?limit=1", "", &featuredList)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(featuredList.PotofolioList), 1)
+	suite.Assert().Equal(featuredList.PotofolioList[0].Title, "wood")
+}
+
+func (suite *FeaturedTestApiSuite) TestBadRequest() {
+
+	statusCode := suite.request(http.MethodPut, "/api/potofolio-order", `{"ids": [1, 100]}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodPut, "/api/potofolio-order", `{"ids": []}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodGet, "/api/essay?sort=position", "", nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodGet, "/api/potofolio?featured=maybe", "", nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+}
+
+func TestFeaturedTestApiSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTestApiSuite))
+}
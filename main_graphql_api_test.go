@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type GraphqlTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *GraphqlTestApiSuite) getUrl() string {
+	return suite.testServer.URL
+}
+
+func (suite *GraphqlTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:graphql_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolio("stone", []string{"/images/graphql_stone1.jpg", "/images/graphql_stone2.jpg"})
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Publish: &models.PublishOption{Status: models.PublishStatusDraft}}, "draft", nil)
+	potofolioRepo.AddPotofolio("sea", nil)
+
+	essayRepo := repositoryConfigure.EssayRepository
+	essayRepo.AddEssay("trip", "", "trip content", []string{"/images/graphql_trip.jpg"})
+	essayRepo.AddEssay("diary", "", "diary content", nil)
+
+	profileName := "cho"
+	repositoryConfigure.AboutRepository.UpdateAbout(nil, &profileName, nil, nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *GraphqlTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *GraphqlTestApiSuite) query(requestGraphql *apis.RequestGraphql) (int, apis.ResponseGraphql) {
+
+	body, err := json.Marshal(requestGraphql)
+	suite.Assert().Nil(err)
+
+	res, err := http.Post(suite.getUrl()+"/api/graphql", "application/json", bytes.NewReader(body))
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responseGraphql apis.ResponseGraphql
+	err = json.Unmarshal(resBytes, &responseGraphql)
+	suite.Assert().Nil(err)
+
+	return res.StatusCode, responseGraphql
+}
+
+// 로그인하지 않아도 POST 로 조회할 수 있고 공개된 것만 나온다.
+func (suite *GraphqlTestApiSuite) TestPotofolios() {
+
+	statusCode, responseGraphql := suite.query(&apis.RequestGraphql{Query: `
+		query List($first: Int) {
+			items: potofolios(first: $first, sort: "id", status: ["draft"]) {
+				list { ...Item }
+				next_cursor
+			}
+		}
+
+		fragment Item on Potofolio {
+			id
+			title
+			images(first: 1) { image }
+		}`,
+		Variables: map[string]interface{}{"first": 1},
+	})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(responseGraphql.Errors), 0)
+
+	suite.Assert().JSONEq(string(responseGraphql.Data),
+		`{"items":{"list":[{"id":"1","title":"stone","images":[{"image":"/images/graphql_stone1.jpg"}]}],"next_cursor":`+suite.nextCursor(responseGraphql.Data)+`}}`)
+
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `{ potofolios { list { title } } draft: potofolio(id: 2) { title } }`})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().JSONEq(string(responseGraphql.Data), `{"potofolios":{"list":[{"title":"stone"},{"title":"sea"}]},"draft":null}`)
+}
+
+func (suite *GraphqlTestApiSuite) nextCursor(data json.RawMessage) string {
+
+	var result struct {
+		Items struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"items"`
+	}
+
+	err := json.Unmarshal(data, &result)
+	suite.Assert().Nil(err)
+	suite.Assert().NotEqual(result.Items.NextCursor, "")
+
+	cursor, _ := json.Marshal(result.Items.NextCursor)
+	return string(cursor)
+}
+
+// essay 의 image 와 본문은 요청한 field 만 가져온다.
+func (suite *GraphqlTestApiSuite) TestEssays() {
+
+	statusCode, responseGraphql := suite.query(&apis.RequestGraphql{Query: `{
+		essays {
+			list {
+				title
+				essay_content
+				images { id image }
+				__typename
+			}
+		}
+		essay(slug: "diary") { id version }
+	}`})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(responseGraphql.Errors), 0)
+	suite.Assert().JSONEq(string(responseGraphql.Data), `{
+		"essays":{"list":[
+			{"title":"trip","essay_content":"trip content","images":[{"id":"3","image":"/images/graphql_trip.jpg"}],"__typename":"Essay"},
+			{"title":"diary","essay_content":"diary content","images":[],"__typename":"Essay"}
+		]},
+		"essay":{"id":"2","version":1}
+	}`)
+}
+
+func (suite *GraphqlTestApiSuite) TestAbout() {
+
+	query := url.QueryEscape(`{ about { profile_name history_groups { category } } }`)
+	res, err := http.Get(suite.getUrl() + "/api/graphql?query=" + query)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().JSONEq(string(resBytes), `{"data":{"about":{"profile_name":"cho","history_groups":[]}}}`)
+}
+
+// 잘못된 문서는 실행하지 않고 400 으로 응답한다.
+func (suite *GraphqlTestApiSuite) TestInvalidQuery() {
+
+	statusCode, responseGraphql := suite.query(&apis.RequestGraphql{Query: `{ potofolios { list { name } } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Nil(responseGraphql.Data)
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, `Cannot query field "name" on type "Potofolio".`)
+	suite.Assert().Equal(responseGraphql.Errors[0].Locations, []apis.ResponseGraphqlLocation{{Line: 1, Column: 23}})
+
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `{ potofolios { list { title } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(len(responseGraphql.Errors), 1)
+
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `mutation { about { version } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(len(responseGraphql.Errors), 1)
+
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `query Q($id: ID!) { potofolio(id: $id) { title } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, `Variable "$id" of required type "ID!" was not provided.`)
+}
+
+func (suite *GraphqlTestApiSuite) TestQueryLimits() {
+
+	// fragment 가 자기 자신을 펼치면 오류
+	statusCode, responseGraphql := suite.query(&apis.RequestGraphql{Query: `{ potofolios { list { ...A } } } fragment A on Potofolio { title ...A }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, `Cannot spread fragment "A" within itself.`)
+
+	// introspection 도 깊이를 센다.
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, "query is too deep (max depth 8)")
+
+	// 100 개 * (image 100 개 * 2 field) 는 너무 많다.
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `{ potofolios(first: 100) { list { images(first: 100) { id image } } } }`})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, "query is too complex (max complexity 5000)")
+
+	statusCode, responseGraphql = suite.query(&apis.RequestGraphql{Query: `{ potofolios(first: 1000) { next_cursor } }`})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(string(responseGraphql.Data), "null")
+	suite.Assert().Equal(responseGraphql.Errors[0].Message, "first is wrong (first = 1000, 1 ~ 100)")
+	suite.Assert().Equal(responseGraphql.Errors[0].Path, []interface{}{"potofolios"})
+}
+
+func (suite *GraphqlTestApiSuite) TestSchema() {
+
+	res, err := http.Get(suite.getUrl() + "/api/graphql/schema")
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Contains(string(resBytes), "potofolios(after: String, featured: Boolean, first: Int = 20")
+	suite.Assert().Contains(string(resBytes), "type Essay {")
+}
+
+func TestGraphqlApiSuite(t *testing.T) {
+	suite.Run(t, new(GraphqlTestApiSuite))
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+const testStoredImageName = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef.jpg"
+
+type HttpCacheTestApiSuite struct {
+	suite.Suite
+	dbConnection        *models.DBConnection
+	repositoryConfigure *models.RepositoryConfigure
+
+	imagePath  string
+	testServer *httptest.Server
+}
+
+func (suite *HttpCacheTestApiSuite) getUrl() string {
+	return suite.testServer.URL
+}
+
+func (suite *HttpCacheTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:http_cache_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+	repositoryConfigure.CacheControlPolicies["/tags"] = "public, max-age=60"
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+	repositoryConfigure.EssayRepository.AddEssay("trip", "", "content", nil)
+
+	suite.repositoryConfigure = repositoryConfigure
+
+	imagePath, err := ioutil.TempDir("", "http_cache_api_test")
+	suite.Assert().Nil(err)
+
+	err = ioutil.WriteFile(filepath.Join(imagePath, testStoredImageName), []byte("image"), 0644)
+	suite.Assert().Nil(err)
+
+	err = ioutil.WriteFile(filepath.Join(imagePath, "profile.jpg"), []byte("image"), 0644)
+	suite.Assert().Nil(err)
+
+	suite.imagePath = imagePath
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, imagePath))
+}
+
+func (suite *HttpCacheTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+	os.RemoveAll(suite.imagePath)
+}
+
+func (suite *HttpCacheTestApiSuite) get(url string, headers map[string]string) *http.Response {
+
+	req, err := http.NewRequest("GET", suite.getUrl()+url, nil)
+	suite.Assert().Nil(err)
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	res.Body.Close()
+
+	return res
+}
+
+func (suite *HttpCacheTestApiSuite) TestListNotModified() {
+
+	res := suite.get("/api/essay", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "public, no-cache")
+	suite.Assert().Equal(res.Header.Get("Vary"), "Cookie")
+	suite.Assert().NotEqual(res.Header.Get("Last-Modified"), "")
+
+	etag := res.Header.Get("ETag")
+	suite.Assert().Regexp(`^W/"r\d+-p"$`, etag)
+
+	res = suite.get("/api/essay", map[string]string{"If-None-Match": etag})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+
+	res = suite.get("/api/v2/essay", map[string]string{"If-None-Match": etag})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+
+	res = suite.get("/api/essay", map[string]string{"If-Modified-Since": time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+
+	// content 가 바뀌면 다시 응답한다.
+	suite.repositoryConfigure.EssayRepository.AddEssay("diary", "", "content", nil)
+
+	res = suite.get("/api/essay", map[string]string{"If-None-Match": etag})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().NotEqual(res.Header.Get("ETag"), etag)
+}
+
+// 단건은 version 이 ETag (If-Match 와 같은 값) 이다.
+func (suite *HttpCacheTestApiSuite) TestItemNotModified() {
+
+	res := suite.get("/api/potofolio/1", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("ETag"), "\"1\"")
+
+	res = suite.get("/api/potofolio/1", map[string]string{"If-None-Match": "\"1\""})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+
+	res = suite.get("/api/potofolio/1", map[string]string{"If-None-Match": "\"1\"", "If-Modified-Since": time.Unix(0, 0).UTC().Format(http.TimeFormat)})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	res = suite.get("/api/potofolio/100", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusNotFound)
+}
+
+// 연결된 tag, collection 이 바뀌면 단건 응답도 바뀌므로 이전 ETag 로 304 가 되면 안된다.
+func (suite *HttpCacheTestApiSuite) TestItemLinkChanged() {
+
+	tagId, err := suite.repositoryConfigure.TagRepository.AddTag("linked")
+	suite.Assert().Nil(err)
+
+	contentOption := &models.ContentOption{Tags: []string{"linked"}}
+	potofolioId, err := suite.repositoryConfigure.PotofolioRepository.AddPotofolioWithOption(contentOption, "linked stone", nil)
+	suite.Assert().Nil(err)
+
+	essayId, err := suite.repositoryConfigure.EssayRepository.AddEssayWithOption("", contentOption, "linked trip", "", "content", nil)
+	suite.Assert().Nil(err)
+
+	potofolioUrl := fmt.Sprintf("/api/potofolio/%d", potofolioId)
+	essayUrl := fmt.Sprintf("/api/essay/%d", essayId)
+
+	potofolioETag := suite.get(potofolioUrl, nil).Header.Get("ETag")
+	essayETag := suite.get(essayUrl, nil).Header.Get("ETag")
+
+	err = suite.repositoryConfigure.TagRepository.UpdateTag(tagId, "renamed")
+	suite.Assert().Nil(err)
+
+	res := suite.get(potofolioUrl, map[string]string{"If-None-Match": potofolioETag})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	res = suite.get(essayUrl, map[string]string{"If-None-Match": essayETag})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	// collection 에 들어가거나 collection 이름이 바뀌어도 마찬가지
+	potofolioETag = suite.get(potofolioUrl, nil).Header.Get("ETag")
+
+	collectionId, err := suite.repositoryConfigure.CollectionRepository.AddCollection("series", "", "", []int64{potofolioId})
+	suite.Assert().Nil(err)
+
+	res = suite.get(potofolioUrl, map[string]string{"If-None-Match": potofolioETag})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	potofolioETag = res.Header.Get("ETag")
+
+	title := "series 2"
+	_, err = suite.repositoryConfigure.CollectionRepository.UpdateCollection(collectionId, &title, nil, nil, nil)
+	suite.Assert().Nil(err)
+
+	res = suite.get(potofolioUrl, map[string]string{"If-None-Match": potofolioETag})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	// 바뀐 것이 없으면 304
+	res = suite.get(potofolioUrl, map[string]string{"If-None-Match": res.Header.Get("ETag")})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+}
+
+func (suite *HttpCacheTestApiSuite) TestCachePolicies() {
+
+	res := suite.get("/api/tags", nil)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "public, max-age=60")
+
+	// 캐시하지 않는 route
+	res = suite.get("/api/authentication", nil)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "")
+	suite.Assert().Equal(res.Header.Get("ETag"), "")
+
+	// 잘못된 요청 (400) 도 revision 이 같으면 같은 응답이라 캐시 header 를 붙인다.
+	res = suite.get("/api/essay?sort=wrong", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusBadRequest)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "public, no-cache")
+}
+
+func (suite *HttpCacheTestApiSuite) TestImmutableImage() {
+
+	res := suite.get("/images/"+testStoredImageName, nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "public, max-age=31536000, immutable")
+
+	res = suite.get("/images/profile.jpg", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "")
+
+	res = suite.get("/images/"+testStoredImageName[1:]+"0", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusNotFound)
+	suite.Assert().NotEqual(res.Header.Get("Cache-Control"), "public, max-age=31536000, immutable")
+}
+
+func TestHttpCacheApiSuite(t *testing.T) {
+	suite.Run(t, new(HttpCacheTestApiSuite))
+}
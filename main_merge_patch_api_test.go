@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/client"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type MergePatchTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+	client     *client.Client
+}
+
+func (suite *MergePatchTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:merge_patch_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	year := 2021
+	medium := "stone"
+	repositoryConfigure.PotofolioRepository.AddPotofolioWithMetadata(
+		&models.ContentOption{Tags: []string{"sculpture", "travel"}},
+		&models.PotofolioMetadataOption{
+			Year:       &year,
+			Medium:     &medium,
+			Dimensions: &models.PotofolioDimensions{Width: 10, Height: 20, Unit: "cm"},
+		},
+		"stone", nil)
+
+	repositoryConfigure.EssayRepository.AddEssayWithOption("", nil, "trip", "", "content", nil)
+
+	profileName := "cho"
+	contact := "cho@chomakers.com"
+	repositoryConfigure.AboutRepository.UpdateAbout(nil, &profileName, &contact, nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+
+	apiClient, err := client.New(suite.testServer.URL)
+	suite.Assert().Nil(err)
+
+	suite.client = apiClient
+}
+
+func (suite *MergePatchTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *MergePatchTestApiSuite) TestPatchAbout() {
+
+	about, err := suite.client.PatchAbout(client.AnyVersion, map[string]interface{}{"profile_name": nil, "introduce_content": "hello"})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(about.ProfileName, "")
+	suite.Assert().Equal(about.Contact, "cho@chomakers.com")
+	suite.Assert().Equal(about.IntroduceContent, "hello")
+
+	// 이미지는 지우기만 할 수 있다.
+	_, err = suite.client.PatchAbout(client.AnyVersion, map[string]interface{}{"profile_image": "/images/a.jpg"})
+	suite.Assert().True(errors.Is(err, client.ErrValidationFailed))
+}
+
+func (suite *MergePatchTestApiSuite) TestPatchEssay() {
+
+	essay, err := suite.client.GetEssay(1)
+	suite.Assert().Nil(err)
+
+	patched, err := suite.client.PatchEssay(1, essay.Version, json.RawMessage(`{"essay_content": null, "featured": true, "tags": ["travel"]}`))
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(patched.Title, "trip")
+	suite.Assert().Equal(patched.EssayContent, "")
+	suite.Assert().True(patched.Featured)
+	suite.Assert().Equal(patched.Tags[0].Name, "travel")
+	suite.Assert().Equal(patched.Version, essay.Version+1)
+
+	// 예전 version
+	_, err = suite.client.PatchEssay(1, essay.Version, map[string]interface{}{"title": "trip 2"})
+	suite.Assert().True(errors.Is(err, client.ErrVersionConflict))
+}
+
+func (suite *MergePatchTestApiSuite) TestPatchPotofolio() {
+
+	// 객체 (dimensions) 는 합치고, null 은 지운다.
+	patch := `{"year": null, "medium": null, "dimensions": {"width": 30}, "tags": ["sculpture"], "description": "granite"}`
+	potofolio, err := suite.client.PatchPotofolio(1, client.AnyVersion, json.RawMessage(patch))
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, "stone")
+	suite.Assert().Nil(potofolio.Year)
+	suite.Assert().Equal(potofolio.Medium, "")
+	suite.Assert().Equal(potofolio.Description, "granite")
+	suite.Assert().Equal(potofolio.Dimensions.Width, float64(30))
+	suite.Assert().Equal(potofolio.Dimensions.Height, float64(20))
+	suite.Assert().Equal(potofolio.Dimensions.Unit, "cm")
+	suite.Assert().Equal(len(potofolio.Tags), 1)
+
+	potofolio, err = suite.client.PatchPotofolio(1, potofolio.Version, map[string]interface{}{"dimensions": nil, "tags": nil})
+	suite.Assert().Nil(err)
+	suite.Assert().Nil(potofolio.Dimensions)
+	suite.Assert().Equal(len(potofolio.Tags), 0)
+	suite.Assert().Equal(potofolio.Description, "granite")
+}
+
+func (suite *MergePatchTestApiSuite) TestPatchPotofolioWrong() {
+
+	// 꼭 필요한 항목은 지울 수 없다.
+	_, err := suite.client.PatchPotofolio(1, client.AnyVersion, map[string]interface{}{"title": nil, "images": []string{}})
+
+	var apiErr *client.ApiError
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.StatusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(apiErr.Fields[0].Field, "images")
+	suite.Assert().Equal(apiErr.Fields[0].Message, "cannot be patched")
+
+	_, err = suite.client.PatchPotofolio(1, client.AnyVersion, map[string]interface{}{"title": nil})
+	suite.Assert().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(apiErr.Fields[0].Field, "title")
+
+	_, err = suite.client.PatchPotofolio(1, client.AnyVersion, json.RawMessage(`[]`))
+	suite.Assert().True(errors.Is(err, client.ErrBadRequest))
+
+	// If-Match 가 필요하다.
+	req, _ := http.NewRequest(http.MethodPatch, suite.testServer.URL+"/api/potofolio/1", strings.NewReader(`{"title": "sea"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusPreconditionRequired)
+
+	req, _ = http.NewRequest(http.MethodPatch, suite.testServer.URL+"/api/potofolio/1", strings.NewReader(`{"title": "sea"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("If-Match", "*")
+	res, err = http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusUnsupportedMediaType)
+}
+
+// 따옴표가 있는 문자열도 그대로 저장한다.
+func (suite *MergePatchTestApiSuite) TestPatchQuotedText() {
+
+	essay, err := suite.client.PatchEssay(1, client.AnyVersion, map[string]interface{}{"title": `the "trip"`, "essay_content": `he said "hi" and 'bye'`})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(essay.Title, `the "trip"`)
+	suite.Assert().Equal(essay.EssayContent, `he said "hi" and 'bye'`)
+
+	potofolio, err := suite.client.PatchPotofolio(1, client.AnyVersion, map[string]interface{}{"title": `12" stone`})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(potofolio.Title, `12" stone`)
+
+	about, err := suite.client.PatchAbout(client.AnyVersion, map[string]interface{}{"profile_name": `cho "maker"`, "contact": `"cho" <cho@chomakers.com>`, "introduce_content": `"hello"`})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(about.ProfileName, `cho "maker"`)
+	suite.Assert().Equal(about.Contact, `"cho" <cho@chomakers.com>`)
+	suite.Assert().Equal(about.IntroduceContent, `"hello"`)
+
+	// 다시 읽어도 같다.
+	essay, err = suite.client.GetEssay(1)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(essay.Title, `the "trip"`)
+}
+
+func TestMergePatchTestApiSuite(t *testing.T) {
+	suite.Run(t, new(MergePatchTestApiSuite))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type OpenApiTestSuite struct {
+	suite.Suite
+	dbConnection        *models.DBConnection
+	repositoryConfigure *models.RepositoryConfigure
+}
+
+func (suite *OpenApiTestSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:openapi_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	suite.repositoryConfigure = repositoryConfigure
+}
+
+func (suite *OpenApiTestSuite) TearDownSuite() {
+	suite.dbConnection.Close()
+}
+
+// route 를 추가하고 apis/openapi.go 에 설명을 추가하지 않으면 실패한다.
+func (suite *OpenApiTestSuite) TestAllRoutesDescribed() {
+
+	router := Setup(suite.repositoryConfigure, "./assets/images")
+
+	problems := apis.OpenApiRouteProblems(router.Routes())
+	suite.Assert().Empty(problems)
+}
+
+func (suite *OpenApiTestSuite) TestOpenApiDocument() {
+
+	testServer := httptest.NewServer(Setup(suite.repositoryConfigure, "./assets/images"))
+	defer testServer.Close()
+
+	res, err := http.Get(testServer.URL + "/api/openapi.json")
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var document struct {
+		OpenApi string                                       `json:"openapi"`
+		Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                          `json:"required"`
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+
+	err = json.Unmarshal(bytes, &document)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(document.OpenApi, "3.0.3")
+
+	// logout 은 GET
+	suite.Assert().Contains(document.Paths["/logout"], "get")
+	suite.Assert().NotContains(document.Paths["/logout"], "post")
+
+	suite.Assert().Contains(document.Paths["/potofolio/{id}"], "put")
+	suite.Assert().Contains(document.Paths["/essay/{id}/revisions/{rev}/restore"], "post")
+	suite.Assert().NotContains(document.Paths, "/v2/potofolio")
+	suite.Assert().NotContains(document.Paths, "/openapi.json")
+	suite.Assert().NotContains(document.Paths, "/docs/swagger-ui.css")
+
+	// 휴지통의 history 도 복구할 수 있다.
+	var typeEnum interface{}
+	for _, parameter := range document.Paths["/trash/{type}/{id}/restore"]["post"]["parameters"].([]interface{}) {
+		if parameter.(map[string]interface{})["name"] == "type" {
+			typeEnum = parameter.(map[string]interface{})["schema"].(map[string]interface{})["enum"]
+		}
+	}
+	suite.Assert().Equal(typeEnum, []interface{}{"potofolio", "essay", "history"})
+
+	// binding tag 가 schema 에 들어간다.
+	createPotofolio := document.Components.Schemas["RequestCreatePotofolio"]
+	suite.Assert().Equal(createPotofolio.Required, []string{"title", "images"})
+	suite.Assert().Equal(createPotofolio.Properties["title"]["maxLength"], float64(200))
+	suite.Assert().Equal(createPotofolio.Properties["images"]["minItems"], float64(1))
+
+	// embedded struct 의 field 도 들어간다.
+	suite.Assert().Contains(createPotofolio.Properties, "dimensions")
+
+	saveImage := document.Components.Schemas["RequestSaveImage"]
+	suite.Assert().Equal(saveImage.Properties["data"]["format"], "byte")
+}
+
+func (suite *OpenApiTestSuite) TestApiDocs() {
+
+	testServer := httptest.NewServer(Setup(suite.repositoryConfigure, "./assets/images"))
+
+	res, err := http.Get(testServer.URL + "/api/docs")
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusNotFound)
+
+	testServer.Close()
+
+	suite.repositoryConfigure.IsServeApiDocs = true
+	defer func() { suite.repositoryConfigure.IsServeApiDocs = false }()
+
+	testServer = httptest.NewServer(Setup(suite.repositoryConfigure, "./assets/images"))
+	defer testServer.Close()
+
+	res, err = http.Get(testServer.URL + "/api/docs")
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Contains(res.Header.Get("Content-Type"), "text/html")
+
+	// swagger-ui 는 서버에 들어 있는 파일을 사용한다.
+	for _, asset := range []string{"/api/docs/swagger-ui.css", "/api/docs/swagger-ui-bundle.js"} {
+		res, err = http.Get(testServer.URL + asset)
+		suite.Assert().Nil(err)
+		res.Body.Close()
+		suite.Assert().Equal(res.StatusCode, http.StatusOK, asset)
+		suite.Assert().True(res.ContentLength > 0 || res.ContentLength == -1, asset)
+	}
+}
+
+func TestOpenApiTestSuite(t *testing.T) {
+	suite.Run(t, new(OpenApiTestSuite))
+}
@@ -206,6 +206,8 @@ func (suite *PotofolioTestApiSuite) TestPtofolioPutApi() {
 	req, err := http.NewRequest(http.MethodPut, suite.getUrl()+"/api/potofolio/2", requestReader)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	client := &http.Client{}
 
@@ -261,6 +263,8 @@ func (suite *PotofolioTestApiSuite) TestPtofolioPutPartialApi() {
 	req, err := http.NewRequest(http.MethodPut, suite.getUrl()+"/api/potofolio/2", requestReader)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	client := &http.Client{}
 
@@ -289,6 +293,8 @@ func (suite *PotofolioTestApiSuite) TestPtofolioDeleteApi() {
 	req, err := http.NewRequest(http.MethodDelete, suite.getUrl()+"/api/potofolio/2", nil)
 	suite.Assert().Nil(err)
 
+	req.Header.Set("If-Match", "*")
+
 	client := http.Client{}
 	client.Do(req)
 
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type PotofolioMetadataTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *PotofolioMetadataTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:potofolio_metadata_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("sea", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *PotofolioMetadataTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *PotofolioMetadataTestApiSuite) request(method string, url string, body string, data interface{}) int {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	req.Header.Set("If-Match", "*")
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	if res.StatusCode == http.StatusOK && data != nil {
+		err = json.Unmarshal([]byte(responsePresent.Data), data)
+		suite.Assert().Nil(err)
+	}
+
+	return res.StatusCode
+}
+
+func (suite *PotofolioMetadataTestApiSuite) TestPotofolioMetadata() {
+
+	body := `{
+		"year": 2021,
+		"medium": "oil on canvas",
+		"dimensions": {"width": 72.7, "height": 60.6, "unit": "cm"},
+		"description": "바다",
+		"credits": [{"name": "kim", "role": "photo"}],
+		"links": [{"title": "전시", "url": "https://example.com/show"}]
+	}`
+
+	statusCode := suite.request(http.MethodPut, "/api/potofolio/1", body, nil)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	var potofolio apis.ResponsePotofolioElement
+	statusCode = suite.request(http.MethodGet, "/api/potofolio/1", "", &potofolio)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	suite.Assert().Equal(*potofolio.Year, 2021)
+	suite.Assert().Equal(potofolio.Medium, "oil on canvas")
+	suite.Assert().Equal(potofolio.Dimensions.Unit, "cm")
+	suite.Assert().Equal(potofolio.Credits[0].Role, "photo")
+	suite.Assert().Equal(potofolio.Links[0].Url, "https://example.com/show")
+
+	statusCode = suite.request(http.MethodPut, "/api/potofolio/1", `{"year": 0, "dimensions": {}, "client": "gallery"}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	statusCode = suite.request(http.MethodGet, "/api/potofolio/1", "", &potofolio)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	suite.Assert().Nil(potofolio.Year)
+	suite.Assert().Nil(potofolio.Dimensions)
+	suite.Assert().Equal(potofolio.Medium, "oil on canvas")
+	suite.Assert().Equal(potofolio.Client, "gallery")
+}
+
+func (suite *PotofolioMetadataTestApiSuite) TestPotofolioMetadataBadRequest() {
+
+	statusCode := suite.request(http.MethodPut, "/api/potofolio/1", `{"dimensions": {"width": 10, "height": 10, "unit": "ft"}}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode = suite.request(http.MethodPut, "/api/potofolio/1", `{"links": [{"url": "ftp://example.com"}]}`, nil)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+}
+
+func TestPotofolioMetadataTestApiSuite(t *testing.T) {
+	suite.Run(t, new(PotofolioMetadataTestApiSuite))
+}
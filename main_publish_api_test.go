@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type PublishTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *PublishTestApiSuite) getUrl() string {
+	return suite.testServer.URL
+}
+
+func (suite *PublishTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:publish_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolio("published", nil)
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Publish: &models.PublishOption{Status: models.PublishStatusDraft}}, "draft", nil)
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Publish: &models.PublishOption{Status: models.PublishStatusUnlisted}}, "unlisted", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *PublishTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *PublishTestApiSuite) get(url string) (int, apis.ResponsePresent) {
+
+	res, err := http.Get(suite.getUrl() + url)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	return res.StatusCode, responsePresent
+}
+
+// 로그인하지 않으면 published 만 목록에 나온다.
+func (suite *PublishTestApiSuite) TestPotofolioListWithoutLogin() {
+
+	statusCode, responsePresent := suite.get("/api/potofolio?status=draft")
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	var responseData apis.ResponsePotofolioList
+	err := json.Unmarshal([]byte(responsePresent.Data), &responseData)
+	suite.Assert().Nil(err)
+
+	suite.Assert().Equal(len(responseData.List), 1)
+	suite.Assert().Equal(responseData.List[0].Title, "published")
+	suite.Assert().Equal(responseData.List[0].Status, "published")
+}
+
+// 로그인하지 않으면 draft 는 없는 것으로, unlisted 는 id 로 볼 수 있다.
+func (suite *PublishTestApiSuite) TestPotofolioGetWithoutLogin() {
+
+	statusCode, _ := suite.get("/api/potofolio/2")
+	suite.Assert().Equal(statusCode, http.StatusNotFound)
+
+	statusCode, responsePresent := suite.get("/api/potofolio/3")
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	var responseData apis.ResponsePotofolioElement
+	err := json.Unmarshal([]byte(responsePresent.Data), &responseData)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(responseData.Status, "unlisted")
+}
+
+func TestPublishTestApiSuite(t *testing.T) {
+	suite.Run(t, new(PublishTestApiSuite))
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type ResponseCacheTestApiSuite struct {
+	suite.Suite
+	dbConnection        *models.DBConnection
+	repositoryConfigure *models.RepositoryConfigure
+
+	testServer  *httptest.Server
+	accessToken string
+}
+
+func (suite *ResponseCacheTestApiSuite) getUrl() string {
+	return suite.testServer.URL
+}
+
+func (suite *ResponseCacheTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:response_cache_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+	repositoryConfigure.EssayRepository.AddEssay("trip", "", "trip content", nil)
+	repositoryConfigure.EssayRepository.AddEssay("diary", "", "diary content", nil)
+
+	repositoryConfigure.UserRepository.AddUser("root", "1234")
+	userModel, err := repositoryConfigure.UserRepository.GetUserModelFromUserName("root")
+	suite.Assert().Nil(err)
+
+	suite.repositoryConfigure = repositoryConfigure
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+
+	bytes, err := json.Marshal(apis.RequestLogin{UserName: "root", Password: userModel.Password})
+	suite.Assert().Nil(err)
+
+	res, err := http.Post(suite.getUrl()+"/api/login", "application/json", strings.NewReader(string(bytes)))
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	res.Body.Close()
+
+	suite.accessToken = getCookieValue(res, "access-token")
+}
+
+func (suite *ResponseCacheTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *ResponseCacheTestApiSuite) get(url string, headers map[string]string) (*http.Response, string) {
+
+	req, err := http.NewRequest("GET", suite.getUrl()+url, nil)
+	suite.Assert().Nil(err)
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	return res, string(resBytes)
+}
+
+func (suite *ResponseCacheTestApiSuite) stats() apis.ResponseCacheStats {
+
+	res, body := suite.get("/api/v2/cache/stats", map[string]string{"Cookie": "access-token=" + suite.accessToken})
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	var envelope apis.ResponseEnvelope
+	err := json.Unmarshal([]byte(body), &envelope)
+	suite.Assert().Nil(err)
+
+	var stats apis.ResponseCacheStats
+	err = json.Unmarshal(envelope.Data, &stats)
+	suite.Assert().Nil(err)
+
+	return stats
+}
+
+func (suite *ResponseCacheTestApiSuite) TestHitAndInvalidate() {
+
+	_, potofolioList := suite.get("/api/potofolio", nil)
+	_, essay1 := suite.get("/api/essay/1", nil)
+	_, essay2 := suite.get("/api/essay/2", nil)
+
+	before := suite.stats()
+
+	// query 순서가 달라도 같은 응답이다.
+	_, body := suite.get("/api/essay?sort=id&order=asc", nil)
+	_, cachedBody := suite.get("/api/essay?order=asc&sort=id", nil)
+	suite.Assert().Equal(cachedBody, body)
+
+	res, cachedBody := suite.get("/api/essay/1", nil)
+	suite.Assert().Equal(cachedBody, essay1)
+	suite.Assert().Equal(res.Header.Get("ETag"), "\"1\"")
+	suite.Assert().Equal(res.Header.Get("Cache-Control"), "public, no-cache")
+
+	// 캐시된 단건도 version 이 같으면 304
+	res, _ = suite.get("/api/essay/1", map[string]string{"If-None-Match": "\"1\""})
+	suite.Assert().Equal(res.StatusCode, http.StatusNotModified)
+
+	stats := suite.stats()
+	suite.Assert().Equal(stats.Hits-before.Hits, uint64(3))
+	suite.Assert().Equal(stats.Misses-before.Misses, uint64(1))
+
+	// essay 1 을 바꾸면 essay 목록과 essay 1 만 지운다.
+	title := "trip to sea"
+	_, err := suite.repositoryConfigure.EssayRepository.UpdateEssay(1, &title, nil, nil, nil, nil)
+	suite.Assert().Nil(err)
+
+	_, body = suite.get("/api/essay/1", nil)
+	suite.Assert().NotEqual(body, essay1)
+	suite.Assert().Contains(body, "trip to sea")
+
+	_, body = suite.get("/api/essay?sort=id&order=asc", nil)
+	suite.Assert().Contains(body, "trip to sea")
+
+	_, cachedBody = suite.get("/api/essay/2", nil)
+	suite.Assert().Equal(cachedBody, essay2)
+
+	_, cachedBody = suite.get("/api/potofolio", nil)
+	suite.Assert().Equal(cachedBody, potofolioList)
+
+	after := suite.stats()
+	suite.Assert().Equal(after.Hits-stats.Hits, uint64(2))
+	suite.Assert().Equal(after.Misses-stats.Misses, uint64(2))
+	suite.Assert().GreaterOrEqual(after.Invalidations-stats.Invalidations, uint64(2))
+}
+
+// tag 이름은 potofolio, essay 응답에 들어 있다.
+func (suite *ResponseCacheTestApiSuite) TestTagInvalidate() {
+
+	tagId, err := suite.repositoryConfigure.TagRepository.AddTag("sculpture")
+	suite.Assert().Nil(err)
+
+	_, err = suite.repositoryConfigure.PotofolioRepository.UpdatePotofolioWithOption(1, nil, &models.ContentOption{Tags: []string{"sculpture"}}, nil, nil, nil)
+	suite.Assert().Nil(err)
+
+	_, body := suite.get("/api/v2/potofolio/1", nil)
+	suite.Assert().Contains(body, "sculpture")
+
+	err = suite.repositoryConfigure.TagRepository.UpdateTag(tagId, "stone work")
+	suite.Assert().Nil(err)
+
+	_, body = suite.get("/api/v2/potofolio/1", nil)
+	suite.Assert().Contains(body, "stone work")
+}
+
+// 로그인한 요청은 캐시하지 않는다.
+func (suite *ResponseCacheTestApiSuite) TestAuthorizedNotCached() {
+
+	before := suite.stats()
+
+	suite.get("/api/essay?status=draft", map[string]string{"Cookie": "access-token=" + suite.accessToken})
+	suite.get("/api/essay?status=draft", map[string]string{"Cookie": "access-token=" + suite.accessToken})
+
+	after := suite.stats()
+	suite.Assert().Equal(after.Hits, before.Hits)
+	suite.Assert().Equal(after.Misses, before.Misses)
+
+	res, _ := suite.get("/api/cache/stats", nil)
+	suite.Assert().Equal(res.StatusCode, http.StatusUnauthorized)
+}
+
+func TestResponseCacheApiSuite(t *testing.T) {
+	suite.Run(t, new(ResponseCacheTestApiSuite))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type SearchTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *SearchTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:search_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	draft := &models.PublishOption{Status: models.PublishStatusDraft}
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("Summer Sculpture", nil)
+	repositoryConfigure.EssayRepository.AddEssay("여름 일기", "", "sculpture 를 만든 여름", nil)
+	repositoryConfigure.EssayRepository.AddEssayWithOption("", &models.ContentOption{Publish: draft}, "sculpture draft", "", "", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *SearchTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *SearchTestApiSuite) search(query url.Values) (int, apis.ResponseSearchList) {
+
+	res, err := http.Get(suite.testServer.URL + "/api/search?" + query.Encode())
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	var responseData apis.ResponseSearchList
+	if res.StatusCode == http.StatusOK {
+		err = json.Unmarshal([]byte(responsePresent.Data), &responseData)
+		suite.Assert().Nil(err)
+	}
+
+	return res.StatusCode, responseData
+}
+
+// 로그인하지 않으면 published 만 찾는다.
+func (suite *SearchTestApiSuite) TestSearchWithoutLogin() {
+
+	statusCode, responseData := suite.search(url.Values{"q": {"sculpture"}})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+
+	suite.Assert().Equal(len(responseData.List), 2)
+	suite.Assert().Equal(responseData.List[0].Type, "potofolio")
+	suite.Assert().Equal(responseData.List[0].TitleHighlight, "Summer <mark>Sculpture</mark>")
+	suite.Assert().Equal(responseData.List[1].Type, "essay")
+	suite.Assert().Contains(responseData.List[1].Snippet, "<mark>sculpture</mark>")
+
+	statusCode, responseData = suite.search(url.Values{"q": {"여름"}, "type": {"essay"}})
+	suite.Assert().Equal(statusCode, http.StatusOK)
+	suite.Assert().Equal(len(responseData.List), 1)
+	suite.Assert().Equal(responseData.List[0].Title, "여름 일기")
+}
+
+func (suite *SearchTestApiSuite) TestSearchBadRequest() {
+
+	statusCode, _ := suite.search(url.Values{"q": {" "}})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode, _ = suite.search(url.Values{"q": {"sculpture"}, "type": {"about"}})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	statusCode, _ = suite.search(url.Values{"q": {"sculpture"}, "limit": {"0"}})
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+}
+
+func TestSearchTestApiSuite(t *testing.T) {
+	suite.Run(t, new(SearchTestApiSuite))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type SlugTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *SlugTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:slug_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolio("여름 그림", nil)
+
+	newSlug := "summer"
+	potofolioRepo.AddPotofolio("old title", nil)
+	potofolioRepo.UpdatePotofolioWithOption(2, nil, &models.ContentOption{Slug: &newSlug}, nil, nil, nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *SlugTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+// redirect 를 따라가지 않고 응답을 받는다.
+func (suite *SlugTestApiSuite) get(path string) *http.Response {
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(suite.testServer.URL + path)
+	suite.Assert().Nil(err)
+
+	return res
+}
+
+func (suite *SlugTestApiSuite) TestPotofolioGetBySlug() {
+
+	res := suite.get("/api/potofolio/" + url.PathEscape("여름-그림"))
+	defer res.Body.Close()
+
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	var responseData apis.ResponsePotofolioElement
+	err = json.Unmarshal([]byte(responsePresent.Data), &responseData)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(responseData.Id, int64(1))
+	suite.Assert().Equal(responseData.Slug, "여름-그림")
+
+	notFoundRes := suite.get("/api/potofolio/unknown")
+	defer notFoundRes.Body.Close()
+
+	suite.Assert().Equal(notFoundRes.StatusCode, http.StatusNotFound)
+}
+
+// 이전 slug 로 요청하면 현재 slug 로 보낸다.
+func (suite *SlugTestApiSuite) TestPotofolioGetByOldSlug() {
+
+	res := suite.get("/api/potofolio/old-title?lang=ko")
+	defer res.Body.Close()
+
+	suite.Assert().Equal(res.StatusCode, http.StatusMovedPermanently)
+	suite.Assert().Equal(res.Header.Get("Location"), "/api/potofolio/summer?lang=ko")
+}
+
+func TestSlugTestApiSuite(t *testing.T) {
+	suite.Run(t, new(SlugTestApiSuite))
+}
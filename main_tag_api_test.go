@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/client"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type TagTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+	client     *client.Client
+}
+
+func (suite *TagTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:tag_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = true
+
+	draft := &models.PublishOption{Status: models.PublishStatusDraft}
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Tags: []string{"sculpture", "travel"}}, "stone", nil)
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Tags: []string{"travel"}}, "sea", nil)
+	potofolioRepo.AddPotofolioWithOption(&models.ContentOption{Publish: draft, Tags: []string{"secret"}}, "draft", nil)
+
+	repositoryConfigure.EssayRepository.AddEssayWithOption("", &models.ContentOption{Tags: []string{"travel"}}, "trip", "", "", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+
+	apiClient, err := client.New(suite.testServer.URL)
+	suite.Assert().Nil(err)
+
+	suite.client = apiClient
+}
+
+func (suite *TagTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+// 로그인하지 않으면 공개된 content 만 센다.
+func (suite *TagTestApiSuite) TestTagCloud() {
+
+	responseData, err := suite.client.ListTags()
+	suite.Assert().Nil(err)
+
+	suite.Assert().Equal(len(responseData.List), 2)
+	suite.Assert().Equal(responseData.List[0].Name, "sculpture")
+	suite.Assert().Equal(responseData.List[0].Count, int64(1))
+	suite.Assert().Equal(responseData.List[1].Name, "travel")
+	suite.Assert().Equal(responseData.List[1].Count, int64(3))
+	suite.Assert().Equal(responseData.List[1].PotofolioCount, int64(2))
+	suite.Assert().Equal(responseData.List[1].EssayCount, int64(1))
+}
+
+func (suite *TagTestApiSuite) TestListByTag() {
+
+	potofolioList, err := suite.client.ListPotofolios(&client.ListOption{Tag: "Sculpture"})
+	suite.Assert().Nil(err)
+
+	suite.Assert().Equal(len(potofolioList.List), 1)
+	suite.Assert().Equal(potofolioList.List[0].Title, "stone")
+	suite.Assert().Equal(len(potofolioList.List[0].Tags), 2)
+	suite.Assert().Equal(potofolioList.List[0].Tags[1].Name, "travel")
+
+	essayList, err := suite.client.ListEssays(&client.ListOption{Tag: "travel"})
+	suite.Assert().Nil(err)
+
+	suite.Assert().Equal(len(essayList.List), 1)
+	suite.Assert().Equal(essayList.List[0].Tags[0].Name, "travel")
+
+	potofolioList, err = suite.client.ListPotofolios(&client.ListOption{Tag: "secret"})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(len(potofolioList.List), 0)
+}
+
+func TestTagTestApiSuite(t *testing.T) {
+	suite.Run(t, new(TagTestApiSuite))
+}
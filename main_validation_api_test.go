@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type ValidationTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *ValidationTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:validation_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *ValidationTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+// 실패한 field 이름 목록
+func (suite *ValidationTestApiSuite) requestFields(method string, url string, body string) (int, []string) {
+
+	req, err := http.NewRequest(method, suite.testServer.URL+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	// PUT 의 version 확인은 하지 않는다.
+	req.Header.Set("If-Match", "*")
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responseEnvelope apis.ResponseEnvelope
+	err = json.Unmarshal(bytes, &responseEnvelope)
+	suite.Assert().Nil(err)
+
+	fields := make([]string, 0)
+	if responseEnvelope.Error != nil {
+		for _, field := range responseEnvelope.Error.Fields {
+			fields = append(fields, field.Field)
+		}
+	}
+
+	return res.StatusCode, fields
+}
+
+func (suite *ValidationTestApiSuite) TestCreateValidation() {
+
+	statusCode, fields := suite.requestFields(http.MethodPost, "/api/v2/potofolio", `{"title": " "}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"title", "images"})
+
+	statusCode, fields = suite.requestFields(http.MethodPost, "/api/v2/potofolio", `{"title": "sea", "images": [{"filename": "a.jpg", "data": "not base64!"}, {"data": "aGVsbG8="}]}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"images[0].data", "images[1].filename"})
+
+	statusCode, fields = suite.requestFields(http.MethodPost, "/api/v2/essay", `{"title": "essay", "thumbmail": {"filename": "a.jpg"}}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"thumbmail.data"})
+
+	// body 가 없어도 필수 항목을 확인한다.
+	statusCode, fields = suite.requestFields(http.MethodPost, "/api/v2/essay", "")
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Contains(fields, "title")
+
+	// json 형식 오류
+	statusCode, fields = suite.requestFields(http.MethodPost, "/api/v2/essay", `{"title": 1}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"title"})
+
+	statusCode, _ = suite.requestFields(http.MethodPost, "/api/v2/essay", `{"title": `)
+	suite.Assert().Equal(statusCode, http.StatusBadRequest)
+
+	// /api 는 400
+	req, _ := http.NewRequest(http.MethodPost, suite.testServer.URL+"/api/potofolio", strings.NewReader(`{"title": "sea"}`))
+	res, err := http.DefaultClient.Do(req)
+	suite.Assert().Nil(err)
+	res.Body.Close()
+	suite.Assert().Equal(res.StatusCode, http.StatusBadRequest)
+}
+
+func (suite *ValidationTestApiSuite) TestUpdateValidation() {
+
+	statusCode, fields := suite.requestFields(http.MethodPut, "/api/v2/potofolio/1", `{"title": "", "tags": ["`+strings.Repeat("a", 41)+`"]}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"title", "tags[0]"})
+
+	statusCode, fields = suite.requestFields(http.MethodPost, "/api/v2/about-history", `{"update_history_list": [{"content": "no id"}]}`)
+	suite.Assert().Equal(statusCode, http.StatusUnprocessableEntity)
+	suite.Assert().Equal(fields, []string{"update_history_list[0].id"})
+
+	// 생략한 항목은 확인하지 않는다.
+	statusCode, _ = suite.requestFields(http.MethodPut, "/api/v2/potofolio/1", `{"featured": true}`)
+	suite.Assert().Equal(statusCode, http.StatusOK)
+}
+
+func TestValidationTestApiSuite(t *testing.T) {
+	suite.Run(t, new(ValidationTestApiSuite))
+}
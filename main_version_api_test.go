@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/golbeng-original/chomakers-web/apis"
+	"github.com/golbeng-original/chomakers-web/models"
+)
+
+type VersionTestApiSuite struct {
+	suite.Suite
+	dbConnection *models.DBConnection
+
+	testServer *httptest.Server
+}
+
+func (suite *VersionTestApiSuite) getUrl() string {
+	return suite.testServer.URL
+}
+
+func (suite *VersionTestApiSuite) SetupSuite() {
+
+	dbConnection := models.DBConnection{}
+	dbConnection.Open("file:version_api_test?mode=memory&cache=shared")
+
+	suite.dbConnection = &dbConnection
+
+	repositoryConfigure := &models.RepositoryConfigure{}
+	repositoryConfigure.Init(&dbConnection)
+	repositoryConfigure.IsCheckAuthorize = false
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("potofolio1", nil)
+
+	suite.testServer = httptest.NewServer(Setup(repositoryConfigure, "./assets/images"))
+}
+
+func (suite *VersionTestApiSuite) TearDownSuite() {
+	suite.testServer.Close()
+	suite.dbConnection.Close()
+}
+
+func (suite *VersionTestApiSuite) putPotofolioTitle(title string, ifMatch string) (*http.Response, apis.ResponsePresent) {
+
+	bytes, err := json.Marshal(apis.RequestUpdatePotofolio{Title: &title})
+	suite.Assert().Nil(err)
+
+	req, err := http.NewRequest(http.MethodPut, suite.getUrl()+"/api/potofolio/1", strings.NewReader(string(bytes)))
+	suite.Assert().Nil(err)
+
+	req.Header.Set("Content-Type", "application/json")
+	if len(ifMatch) > 0 {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	client := &http.Client{}
+	res, err := client.Do(req)
+	suite.Assert().Nil(err)
+
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	suite.Assert().Nil(err)
+
+	var responsePresent apis.ResponsePresent
+	err = json.Unmarshal(bodyBytes, &responsePresent)
+	suite.Assert().Nil(err)
+
+	return res, responsePresent
+}
+
+// Test ETag / If-Match on /api/potofolio/:id
+func (suite *VersionTestApiSuite) TestPotofolioIfMatch() {
+
+	res, err := http.Get(suite.getUrl() + "/api/potofolio/1")
+	suite.Assert().Nil(err)
+	res.Body.Close()
+
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("ETag"), "\"1\"")
+
+	// If-Match 없음
+	res, responsePresent := suite.putPotofolioTitle("update title", "")
+	suite.Assert().Equal(res.StatusCode, http.StatusPreconditionRequired)
+	suite.Assert().Equal(responsePresent.Result, "failed")
+
+	res, _ = suite.putPotofolioTitle("update title", "\"1\"")
+	suite.Assert().Equal(res.StatusCode, http.StatusOK)
+	suite.Assert().Equal(res.Header.Get("ETag"), "")
+
+	// 다른 사람이 이미 수정한 version
+	res, responsePresent = suite.putPotofolioTitle("stale title", "\"1\"")
+	suite.Assert().Equal(res.StatusCode, http.StatusPreconditionFailed)
+	suite.Assert().Equal(res.Header.Get("ETag"), "\"2\"")
+
+	var versionConflict apis.ResponseVersionConflict
+	err = json.Unmarshal([]byte(responsePresent.Data), &versionConflict)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(versionConflict.CurrentVersion, int64(2))
+
+	potofolioRes, err := http.Get(suite.getUrl() + "/api/potofolio/1")
+	suite.Assert().Nil(err)
+	potofolioRes.Body.Close()
+
+	suite.Assert().Equal(potofolioRes.Header.Get("ETag"), "\"2\"")
+}
+
+func (suite *VersionTestApiSuite) sendAbout(method string, url string, contentType string, body string, ifMatch string) int {
+
+	req, err := http.NewRequest(method, suite.getUrl()+url, strings.NewReader(body))
+	suite.Assert().Nil(err)
+
+	req.Header.Set("Content-Type", contentType)
+	if len(ifMatch) > 0 {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	client := &http.Client{}
+	res, err := client.Do(req)
+	suite.Assert().Nil(err)
+	res.Body.Close()
+
+	return res.StatusCode
+}
+
+// about 은 기존 frontend 가 쓰는 /api 의 POST 만 If-Match 없이 수정할 수 있다.
+func (suite *VersionTestApiSuite) TestAboutIfMatch() {
+
+	body := `{"profile_name": "cho"}`
+
+	suite.Assert().Equal(suite.sendAbout(http.MethodPost, "/api/about", "application/json", body, ""), http.StatusOK)
+	suite.Assert().Equal(suite.sendAbout(http.MethodPost, "/api/v2/about", "application/json", body, ""), http.StatusPreconditionRequired)
+	suite.Assert().Equal(suite.sendAbout(http.MethodPatch, "/api/about", "application/merge-patch+json", body, ""), http.StatusPreconditionRequired)
+	suite.Assert().Equal(suite.sendAbout(http.MethodPut, "/api/about-history-categories", "application/json", `{"names": []}`, ""), http.StatusPreconditionRequired)
+
+	res, err := http.Get(suite.getUrl() + "/api/about")
+	suite.Assert().Nil(err)
+	res.Body.Close()
+
+	etag := res.Header.Get("ETag")
+	suite.Assert().Equal(suite.sendAbout(http.MethodPost, "/api/v2/about", "application/json", body, etag), http.StatusOK)
+	suite.Assert().Equal(suite.sendAbout(http.MethodPost, "/api/v2/about", "application/json", body, etag), http.StatusPreconditionFailed)
+}
+
+func TestVersionTestApiSuite(t *testing.T) {
+	suite.Run(t, new(VersionTestApiSuite))
+}
@@ -0,0 +1,396 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// history 의 끝 날짜가 없고 지금도 계속되는 경우
+const HistoryDatePresent = "present"
+
+// 현재를 나타내는 duration 표현 (대소문자 구분 없음)
+var historyPresentWords = []string{"present", "current", "now", "현재", "재직중", "진행중"}
+
+// 연도만, 연월, 연월일 정밀도의 날짜 (Month, Day 가 0 이면 없는 것)
+type HistoryDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// "2021", "2021-03", "2021-03-15" 형식
+func (date HistoryDate) String() string {
+
+	if date.Month == 0 {
+		return fmt.Sprintf("%04d", date.Year)
+	}
+
+	if date.Day == 0 {
+		return fmt.Sprintf("%04d-%02d", date.Year, date.Month)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", date.Year, date.Month, date.Day)
+}
+
+// 정밀도가 낮은 날짜 (2021) 는 같은 해의 다른 날짜 (2021-03) 보다 앞이다.
+func (date HistoryDate) Compare(other HistoryDate) int {
+
+	values := [][2]int{{date.Year, other.Year}, {date.Month, other.Month}, {date.Day, other.Day}}
+	for _, value := range values {
+		if value[0] != value[1] {
+			if value[0] < value[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func newHistoryDate(parts []int) (*HistoryDate, error) {
+
+	date := &HistoryDate{Year: parts[0]}
+	if len(parts) > 1 {
+		date.Month = parts[1]
+	}
+
+	if len(parts) > 2 {
+		date.Day = parts[2]
+	}
+
+	if date.Year < 1000 || date.Year > 9999 || date.Month < 0 || date.Month > 12 || date.Day < 0 || date.Day > 31 || (date.Month == 0 && date.Day != 0) {
+		return nil, fmt.Errorf("history date is wrong [%v]", date)
+	}
+
+	return date, nil
+}
+
+// "2021", "2021-03", "2021-03-15" 을 읽는다.
+func ParseHistoryDate(value string) (*HistoryDate, error) {
+
+	strParts := strings.Split(strings.TrimSpace(value), "-")
+	if len(strParts) > 3 {
+		return nil, fmt.Errorf("history date is wrong [%s] (YYYY, YYYY-MM, YYYY-MM-DD)", value)
+	}
+
+	parts := make([]int, 0, len(strParts))
+	for i, strPart := range strParts {
+		if (i == 0 && len(strPart) != 4) || (i > 0 && len(strPart) != 2) {
+			return nil, fmt.Errorf("history date is wrong [%s] (YYYY, YYYY-MM, YYYY-MM-DD)", value)
+		}
+
+		part, err := strconv.Atoi(strPart)
+		if err != nil {
+			return nil, fmt.Errorf("history date is wrong [%s] (YYYY, YYYY-MM, YYYY-MM-DD)", value)
+		}
+
+		parts = append(parts, part)
+	}
+
+	return newHistoryDate(parts)
+}
+
+// 자유 형식 duration (예: "2019.03 ~ 현재", "2018 - 2020", "2017년 5월") 에서 날짜를 찾는다.
+// 날짜를 찾지 못하거나 애매하면 ok 가 false 이다.
+func ParseHistoryDuration(duration string) (start *HistoryDate, end *HistoryDate, present bool, ok bool) {
+
+	// 숫자 묶음을 순서대로 모은다. 4 자리 숫자가 새 날짜의 시작이고 뒤의 1~2 자리 숫자는 월, 일이다.
+	dates := make([][]int, 0)
+	runes := []rune(duration)
+	for i := 0; i < len(runes); {
+		if !unicode.IsDigit(runes[i]) {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && unicode.IsDigit(runes[j]) {
+			j++
+		}
+
+		number, _ := strconv.Atoi(string(runes[i:j]))
+		switch {
+		case j-i == 4:
+			dates = append(dates, []int{number})
+		case j-i <= 2 && len(dates) > 0 && len(dates[len(dates)-1]) < 3:
+			dates[len(dates)-1] = append(dates[len(dates)-1], number)
+		default:
+			return nil, nil, false, false
+		}
+
+		i = j
+	}
+
+	lowerDuration := strings.ToLower(duration)
+	for _, word := range historyPresentWords {
+		if strings.Contains(lowerDuration, word) {
+			present = true
+			break
+		}
+	}
+
+	if len(dates) == 0 || len(dates) > 2 || (present && len(dates) != 1) {
+		return nil, nil, false, false
+	}
+
+	start, err := newHistoryDate(dates[0])
+	if err != nil {
+		return nil, nil, false, false
+	}
+
+	if len(dates) == 2 {
+		end, err = newHistoryDate(dates[1])
+		if err != nil || end.Compare(*start) < 0 {
+			return nil, nil, false, false
+		}
+	}
+
+	return start, end, present, true
+}
+
+// 날짜로 duration 문자열을 만든다. (예: "2019.03 ~ 현재")
+func FormatHistoryDuration(start *HistoryDate, end *HistoryDate, present bool) string {
+
+	if start == nil {
+		return ""
+	}
+
+	format := func(date *HistoryDate) string {
+		return strings.ReplaceAll(date.String(), "-", ".")
+	}
+
+	switch {
+	case present:
+		return format(start) + " ~ 현재"
+	case end != nil && end.Compare(*start) != 0:
+		return format(start) + " ~ " + format(end)
+	default:
+		return format(start)
+	}
+}
+
+// 날짜가 없으면 duration 에서 찾고, duration 이 없으면 날짜로 만든다.
+func (content *AboutHistoryContent) resolveDates() {
+
+	if content.StartDate == nil && content.EndDate == nil && !content.IsPresent {
+		start, end, present, ok := ParseHistoryDuration(content.Duration)
+		if ok {
+			content.StartDate, content.EndDate, content.IsPresent = start, end, present
+		}
+		return
+	}
+
+	if len(strings.TrimSpace(content.Duration)) == 0 {
+		content.Duration = FormatHistoryDuration(content.StartDate, content.EndDate, content.IsPresent)
+	}
+}
+
+// start, end 가 맞는지 확인한다.
+func (content *AboutHistoryContent) ValidateDates() error {
+
+	if content.StartDate == nil && (content.EndDate != nil || content.IsPresent) {
+		return fmt.Errorf("history start date is empty")
+	}
+
+	if content.EndDate != nil && content.IsPresent {
+		return fmt.Errorf("history end date and present can not be used together")
+	}
+
+	if content.EndDate != nil && content.EndDate.Compare(*content.StartDate) < 0 {
+		return fmt.Errorf("history end date is before start date [%v ~ %v]", content.StartDate, content.EndDate)
+	}
+
+	return nil
+}
+
+func historyDateColumn(date *HistoryDate) interface{} {
+	if date == nil {
+		return nil
+	}
+
+	return date.String()
+}
+
+func historyEndDateColumn(end *HistoryDate, present bool) interface{} {
+	if present {
+		return HistoryDatePresent
+	}
+
+	return historyDateColumn(end)
+}
+
+func scanHistoryDates(startDate sql.NullString, endDate sql.NullString) (*HistoryDate, *HistoryDate, bool) {
+
+	var start *HistoryDate
+	var end *HistoryDate
+	present := false
+
+	if startDate.Valid {
+		start, _ = ParseHistoryDate(startDate.String)
+	}
+
+	if endDate.Valid {
+		if endDate.String == HistoryDatePresent {
+			present = true
+		} else {
+			end, _ = ParseHistoryDate(endDate.String)
+		}
+	}
+
+	return start, end, present
+}
+
+// startDate, endDate column 을 추가하고, 새로 추가되었으면 기존 duration 에서 날짜를 찾아 채운다.
+func addHistoryDateColumns(db *sql.DB) error {
+
+	added, err := addColumnIfNotExists(db, "about_history", "startDate", "TEXT")
+	if err != nil {
+		return err
+	}
+
+	_, err = addColumnIfNotExists(db, "about_history", "endDate", "TEXT")
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return nil
+	}
+
+	rows, err := db.Query("SELECT id, COALESCE(duration, '') FROM about_history")
+	if err != nil {
+		return err
+	}
+
+	contents := make([]AboutHistoryIdContent, 0)
+	for rows.Next() {
+		var content AboutHistoryIdContent
+		err = rows.Scan(&content.Id, &content.Duration)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		contents = append(contents, content)
+	}
+	rows.Close()
+
+	for _, content := range contents {
+		start, end, present, ok := ParseHistoryDuration(content.Duration)
+		if !ok {
+			log.Printf("[info] about_history duration is not parsed [id:%v] (%s)\n", content.Id, content.Duration)
+			continue
+		}
+
+		_, err = db.Exec("UPDATE about_history SET startDate = $1, endDate = $2 WHERE id = $3",
+			historyDateColumn(start), historyEndDateColumn(end, present), content.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// category 별로 정해진 순서
+// 순서를 정하지 않은 category 는 정한 category 뒤에 처음 추가된 순서대로 온다.
+func createHistoryCategoryTable(db *sql.DB) error {
+
+	createCategoryTableQuery := `
+		CREATE TABLE IF NOT EXISTS "about_history_category"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"name" TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			"displayOrder" INTEGER NOT NULL
+		)`
+
+	_, err := db.Exec(createCategoryTableQuery)
+	return err
+}
+
+// category 이름은 비어 있지 않고 (대소문자 구분 없이) 중복되지 않아야 한다.
+func ValidateHistoryCategoryNames(names []string) error {
+
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if len(key) == 0 {
+			return fmt.Errorf("category name is empty")
+		}
+
+		if seen[key] {
+			return fmt.Errorf("category name is duplicated [%s]", name)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// 화면에 보여줄 순서대로 정렬한다.
+// category 순서 -> 날짜 (끝나지 않은 것, 최근 것 먼저) -> 날짜가 없는 것은 추가된 순서
+func sortHistories(histories []AboutHistoryModel, categoryOrders map[string]int) {
+
+	// 끝 날짜가 없으면 시작 날짜로 비교한다.
+	latestDate := func(history *AboutHistoryModel) HistoryDate {
+		if history.EndDate != nil {
+			return *history.EndDate
+		}
+
+		return *history.StartDate
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		left, right := &histories[i], &histories[j]
+
+		leftCategoryOrder, rightCategoryOrder := categoryOrders[strings.ToLower(left.Category)], categoryOrders[strings.ToLower(right.Category)]
+		if leftCategoryOrder != rightCategoryOrder {
+			return leftCategoryOrder < rightCategoryOrder
+		}
+
+		if (left.StartDate == nil) != (right.StartDate == nil) {
+			return left.StartDate != nil
+		}
+
+		if left.StartDate == nil {
+			return left.Id < right.Id
+		}
+
+		if left.IsPresent != right.IsPresent {
+			return left.IsPresent
+		}
+
+		if compared := latestDate(left).Compare(latestDate(right)); compared != 0 && !left.IsPresent {
+			return compared > 0
+		}
+
+		if compared := left.StartDate.Compare(*right.StartDate); compared != 0 {
+			return compared > 0
+		}
+
+		return left.Id < right.Id
+	})
+}
+
+// 정렬된 history 를 category 별로 묶는다.
+func GroupHistories(histories []AboutHistoryModel) []AboutHistoryGroupModel {
+
+	groups := make([]AboutHistoryGroupModel, 0)
+	for _, history := range histories {
+		if len(groups) == 0 || !strings.EqualFold(groups[len(groups)-1].Category, history.Category) {
+			groups = append(groups, AboutHistoryGroupModel{Category: history.Category, Histories: make([]AboutHistoryModel, 0)})
+		}
+
+		group := &groups[len(groups)-1]
+		group.Histories = append(group.Histories, history)
+	}
+
+	return groups
+}
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func historyIds(histories []AboutHistoryModel) []int64 {
+	ids := make([]int64, 0, len(histories))
+	for _, history := range histories {
+		ids = append(ids, history.Id)
+	}
+
+	return ids
+}
+
+func TestParseHistoryDuration(t *testing.T) {
+
+	start, end, present, ok := ParseHistoryDuration("2019.03 ~ 현재")
+	assert.True(t, ok)
+	assert.Equal(t, start.String(), "2019-03")
+	assert.Nil(t, end)
+	assert.True(t, present)
+
+	start, end, present, ok = ParseHistoryDuration("2018 - 2020")
+	assert.True(t, ok)
+	assert.Equal(t, start.String(), "2018")
+	assert.Equal(t, end.String(), "2020")
+	assert.False(t, present)
+
+	start, _, _, ok = ParseHistoryDuration("2017년 5월 3일")
+	assert.True(t, ok)
+	assert.Equal(t, start.String(), "2017-05-03")
+
+	_, _, present, ok = ParseHistoryDuration("2015.09 - Present")
+	assert.True(t, ok)
+	assert.True(t, present)
+
+	// 날짜로 읽을 수 없는 것
+	for _, duration := range []string{"", "학부 시절", "2020 ~ 2018", "19.03 ~ 20.02", "2019.13", "2017, 2018, 2019", "2017 ~ 2018 현재"} {
+		_, _, _, ok = ParseHistoryDuration(duration)
+		assert.False(t, ok, duration)
+	}
+}
+
+func TestParseHistoryDate(t *testing.T) {
+
+	date, err := ParseHistoryDate("2021-03")
+	assert.Nil(t, err)
+	assert.Equal(t, *date, HistoryDate{Year: 2021, Month: 3})
+
+	for _, value := range []string{"21", "2021-3", "2021-13", "2021/03", "2021-03-01-01"} {
+		_, err = ParseHistoryDate(value)
+		assert.NotNil(t, err, value)
+	}
+
+	assert.Equal(t, FormatHistoryDuration(&HistoryDate{Year: 2019, Month: 3}, nil, true), "2019.03 ~ 현재")
+	assert.Equal(t, FormatHistoryDuration(&HistoryDate{Year: 2018}, &HistoryDate{Year: 2020}, false), "2018 ~ 2020")
+	assert.Equal(t, FormatHistoryDuration(&HistoryDate{Year: 2018}, &HistoryDate{Year: 2018}, false), "2018")
+
+	content := AboutHistoryContent{StartDate: &HistoryDate{Year: 2020}, EndDate: &HistoryDate{Year: 2019}}
+	assert.NotNil(t, content.ValidateDates())
+
+	content = AboutHistoryContent{EndDate: &HistoryDate{Year: 2019}}
+	assert.NotNil(t, content.ValidateDates())
+
+	content = AboutHistoryContent{StartDate: &HistoryDate{Year: 2019}, EndDate: &HistoryDate{Year: 2020}, IsPresent: true}
+	assert.NotNil(t, content.ValidateDates())
+}
+
+func TestAboutHistoryOrder(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:about_history_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	aboutRepo := repositoryConfigure.AboutRepository
+
+	err = aboutRepo.UpdateAboutHistory(nil, nil, []AboutHistoryContent{
+		{Category: "학력", Duration: "2010 ~ 2014", Content: "학부"},
+		{Category: "전시", Duration: "2018", Content: "첫 개인전"},
+		{Category: "학력", Duration: "2015.03 ~ 2017.02", Content: "대학원"},
+		{Category: "전시", Content: "날짜 없는 전시"},
+		{Category: "전시", StartDate: &HistoryDate{Year: 2021, Month: 5}, IsPresent: true, Content: "상설 전시"},
+		{Category: "수상", Duration: "2019", Content: "공모전"},
+	})
+	assert.Nil(t, err)
+
+	// 순서를 정하지 않으면 처음 추가된 category 순서대로
+	histories, err := aboutRepo.GetHistory()
+	assert.Nil(t, err)
+	assert.Equal(t, historyIds(histories), []int64{3, 1, 5, 2, 4, 6})
+	assert.Equal(t, histories[2].Duration, "2021.05 ~ 현재")
+	assert.True(t, histories[2].IsPresent)
+	assert.Equal(t, histories[1].EndDate.String(), "2014")
+
+	groups := GroupHistories(histories)
+	assert.Equal(t, len(groups), 3)
+	assert.Equal(t, groups[1].Category, "전시")
+	assert.Equal(t, len(groups[1].Histories), 3)
+
+	aboutModel, err := aboutRepo.GetAbout()
+	assert.Nil(t, err)
+
+	version := aboutModel.Version
+	err = aboutRepo.SetHistoryCategoryOrderIfMatch(&version, []string{"전시", "수상"})
+	assert.Nil(t, err)
+
+	histories, err = aboutRepo.GetHistory()
+	assert.Nil(t, err)
+	assert.Equal(t, historyIds(histories), []int64{5, 2, 4, 6, 3, 1})
+
+	categories, err := aboutRepo.GetHistoryCategories()
+	assert.Nil(t, err)
+	assert.Equal(t, len(categories), 3)
+	assert.Equal(t, categories[0].Name, "전시")
+	assert.Equal(t, *categories[0].DisplayOrder, 0)
+	assert.Equal(t, categories[0].HistoryCount, int64(3))
+	assert.Equal(t, categories[2].Name, "학력")
+	assert.Nil(t, categories[2].DisplayOrder)
+
+	// 이전 version
+	err = aboutRepo.SetHistoryCategoryOrderIfMatch(&version, []string{"학력"})
+	assert.IsType(t, &VersionConflictError{}, err)
+
+	err = aboutRepo.SetHistoryCategoryOrderIfMatch(nil, []string{"전시", "전시 "})
+	assert.NotNil(t, err)
+}
+
+func TestAboutHistoryDateMigration(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:about_history_migration_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	db, err := dbConnection.GetDB()
+	assert.Nil(t, err)
+
+	// 날짜 column 이 없던 때의 table
+	_, err = db.Exec(`CREATE TABLE "about_history" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "category" TEXT, "duration" TEXT, "content" TEXT)`)
+	assert.Nil(t, err)
+
+	_, err = db.Exec(`INSERT INTO about_history (category, duration, content) VALUES ('경력', '2016.04 ~ 재직중', 'A'), ('경력', '대학 시절', 'B')`)
+	assert.Nil(t, err)
+
+	aboutRepo := &AboutRepository{DBConnect: dbConnection}
+	err = aboutRepo.CreateTable()
+	assert.Nil(t, err)
+
+	histories, err := aboutRepo.GetHistory()
+	assert.Nil(t, err)
+	assert.Equal(t, len(histories), 2)
+
+	assert.Equal(t, histories[0].StartDate.String(), "2016-04")
+	assert.True(t, histories[0].IsPresent)
+
+	// 읽을 수 없는 duration 은 그대로 둔다.
+	assert.Nil(t, histories[1].StartDate)
+	assert.Equal(t, histories[1].Duration, "대학 시절")
+}
+
+// 휴지통에서 되살린 history 도 about 의 변경이다.
+func TestAboutHistoryRestoreVersion(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:about_history_restore_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	aboutRepo := repositoryConfigure.AboutRepository
+
+	err = aboutRepo.UpdateAboutHistory(nil, nil, []AboutHistoryContent{
+		{Category: "전시", Duration: "2018", Content: "첫 개인전"},
+		{Category: "수상", Duration: "2019", Content: "공모전"},
+	})
+	assert.Nil(t, err)
+
+	err = aboutRepo.UpdateAboutHistory([]int64{1}, nil, nil)
+	assert.Nil(t, err)
+
+	aboutModel, err := aboutRepo.GetAbout()
+	assert.Nil(t, err)
+
+	version := aboutModel.Version
+
+	err = aboutRepo.RestoreHistory(1)
+	assert.Nil(t, err)
+
+	aboutModel, err = aboutRepo.GetAbout()
+	assert.Nil(t, err)
+	assert.Equal(t, aboutModel.Version, version+1)
+
+	histories, err := aboutRepo.GetHistory()
+	assert.Nil(t, err)
+	assert.Equal(t, len(histories), 2)
+
+	// 휴지통에 없으면 version 도 그대로
+	err = aboutRepo.RestoreHistory(1)
+	assert.IsType(t, &TrashNotFoundError{}, err)
+
+	aboutModel, err = aboutRepo.GetAbout()
+	assert.Nil(t, err)
+	assert.Equal(t, aboutModel.Version, version+1)
+}
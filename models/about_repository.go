@@ -1,9 +1,11 @@
 package models
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/thoas/go-funk"
 )
@@ -13,6 +15,7 @@ type AboutModel struct {
 	ProfileName      *string `json:"profile_name"`
 	Contact          *string `json:"contact"`
 	IntroduceContent *string `json:"introduce_content"`
+	Version          int64   `json:"version"` // about row 가 없으면 0
 }
 
 func (aboutModel *AboutModel) GetProfileImagePath(saveDir string, prefixUri string) string {
@@ -25,16 +28,36 @@ func (aboutModel *AboutModel) GetProfileImagePath(saveDir string, prefixUri stri
 }
 
 type AboutHistoryModel struct {
-	Id       int64  `json:"id"`
-	Category string `json:"category"`
-	Duration string `json:"duration"`
-	Content  string `json:"content"`
+	Id        int64        `json:"id"`
+	Category  string       `json:"category"`
+	Duration  string       `json:"duration"`
+	Content   string       `json:"content"`
+	StartDate *HistoryDate `json:"startDate"`
+	EndDate   *HistoryDate `json:"endDate"`
+	IsPresent bool         `json:"isPresent"`
 }
 
+// 같은 category 의 history 묶음
+type AboutHistoryGroupModel struct {
+	Category  string              `json:"category"`
+	Histories []AboutHistoryModel `json:"histories"`
+}
+
+// category 와 정해진 순서 (DisplayOrder 가 nil 이면 정하지 않은 category)
+type AboutHistoryCategoryModel struct {
+	Name         string `json:"name"`
+	DisplayOrder *int   `json:"displayOrder"`
+	HistoryCount int64  `json:"historyCount"`
+}
+
+// 날짜가 모두 없으면 Duration 에서 찾고, Duration 이 없으면 날짜로 만든다.
 type AboutHistoryContent struct {
-	Category string
-	Duration string
-	Content  string
+	Category  string
+	Duration  string
+	Content   string
+	StartDate *HistoryDate
+	EndDate   *HistoryDate
+	IsPresent bool
 }
 
 type AboutHistoryIdContent struct {
@@ -73,7 +96,8 @@ func (repo *AboutRepository) createAbout() (int64, error) {
 		return -1, err
 	}
 
-	aboutCreateQuery := "INSERT INTO about (profileImage, profileName, Contact, IntroduceContent) VALUES (NULL, NULL, NULL, NULL)"
+	// 아무것도 없는 상태를 version 0 으로 본다.
+	aboutCreateQuery := "INSERT INTO about (profileImage, profileName, Contact, IntroduceContent, version) VALUES (NULL, NULL, NULL, NULL, 0)"
 
 	result, err := db.Exec(aboutCreateQuery)
 	if err != nil {
@@ -141,6 +165,13 @@ func (repo *AboutRepository) CreateTable() error {
 		return err
 	}
 
+	// 동시 수정 확인용 (about, about_history 가 수정될 때 마다 1 씩 증가)
+	_, err = addColumnIfNotExists(db, "about", "version", "INTEGER NOT NULL DEFAULT 1")
+	if err != nil {
+		log.Printf("[error] add column about.version [%v]\n", err)
+		return err
+	}
+
 	createAboutHistoryTableQuery := `
 		CREATE TABLE IF NOT EXISTS "about_history"
 		(
@@ -156,6 +187,25 @@ func (repo *AboutRepository) CreateTable() error {
 		return err
 	}
 
+	// 휴지통 (NULL 이면 삭제되지 않은 상태)
+	_, err = addColumnIfNotExists(db, "about_history", "deletedAt", "INTEGER")
+	if err != nil {
+		log.Printf("[error] add column about_history.deletedAt [%v]\n", err)
+		return err
+	}
+
+	err = addHistoryDateColumns(db)
+	if err != nil {
+		log.Printf("[error] add date columns about_history [%v]\n", err)
+		return err
+	}
+
+	err = createHistoryCategoryTable(db)
+	if err != nil {
+		log.Printf("[error] create table about_history_category [%v]\n", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -165,7 +215,7 @@ func (repo *AboutRepository) GetAbout() (*AboutModel, error) {
 		return nil, err
 	}
 
-	aboutRow, err := db.Query("SELECT profileImage, profileName, Contact, IntroduceContent FROM about LIMIT 1")
+	aboutRow, err := db.Query("SELECT profileImage, profileName, Contact, IntroduceContent, version FROM about LIMIT 1")
 	if err != nil {
 		log.Printf("[error] about query [%v]\n", err)
 		return nil, err
@@ -179,7 +229,7 @@ func (repo *AboutRepository) GetAbout() (*AboutModel, error) {
 
 	about := AboutModel{}
 
-	err = aboutRow.Scan(&about.ProfileImage, &about.ProfileName, &about.Contact, &about.IntroduceContent)
+	err = aboutRow.Scan(&about.ProfileImage, &about.ProfileName, &about.Contact, &about.IntroduceContent, &about.Version)
 	if err != nil {
 		log.Printf("[error] about scan [%v]\n", err)
 		return nil, err
@@ -188,6 +238,7 @@ func (repo *AboutRepository) GetAbout() (*AboutModel, error) {
 	return &about, nil
 }
 
+// category 순서, 날짜 순서 (최근 것 먼저) 로 정렬해서 가져온다.
 func (repo *AboutRepository) GetHistory() ([]AboutHistoryModel, error) {
 
 	db, err := repo.DBConnect.GetDB()
@@ -195,31 +246,185 @@ func (repo *AboutRepository) GetHistory() ([]AboutHistoryModel, error) {
 		return nil, err
 	}
 
-	aboutHistoryRows, err := db.Query("SELECT id, category, duration, content FROM about_history ORDER BY id")
+	aboutHistoryRows, err := db.Query("SELECT id, category, duration, content, startDate, endDate FROM about_history WHERE deletedAt IS NULL ORDER BY id")
 	if err != nil {
 		return nil, err
 	}
 
-	defer aboutHistoryRows.Close()
-
 	aboutHistoryModels := make([]AboutHistoryModel, 0)
 
 	for aboutHistoryRows.Next() {
 
 		aboutHistoryModel := AboutHistoryModel{}
-		err = aboutHistoryRows.Scan(&aboutHistoryModel.Id, &aboutHistoryModel.Category, &aboutHistoryModel.Duration, &aboutHistoryModel.Content)
+		var startDate sql.NullString
+		var endDate sql.NullString
+		err = aboutHistoryRows.Scan(&aboutHistoryModel.Id, &aboutHistoryModel.Category, &aboutHistoryModel.Duration, &aboutHistoryModel.Content, &startDate, &endDate)
 		if err != nil {
 			log.Printf("[error] aboutHistory scan [%v]\n", err)
 			continue
 		}
 
+		aboutHistoryModel.StartDate, aboutHistoryModel.EndDate, aboutHistoryModel.IsPresent = scanHistoryDates(startDate, endDate)
+
 		aboutHistoryModels = append(aboutHistoryModels, aboutHistoryModel)
 	}
+	aboutHistoryRows.Close()
+
+	categoryOrders, err := repo.getCategoryOrders(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 순서를 정하지 않은 category 는 처음 추가된 순서대로 뒤에 둔다.
+	for _, aboutHistoryModel := range aboutHistoryModels {
+		key := strings.ToLower(aboutHistoryModel.Category)
+		if _, ok := categoryOrders[key]; !ok {
+			categoryOrders[key] = len(categoryOrders)
+		}
+	}
+
+	sortHistories(aboutHistoryModels, categoryOrders)
 
 	return aboutHistoryModels, nil
 }
 
+// 순서를 정한 category (소문자 이름 -> 순서)
+func (repo *AboutRepository) getCategoryOrders(db *sql.DB) (map[string]int, error) {
+
+	rows, err := db.Query("SELECT name FROM about_history_category ORDER BY displayOrder, id")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	categoryOrders := make(map[string]int)
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		categoryOrders[strings.ToLower(name)] = len(categoryOrders)
+	}
+
+	return categoryOrders, nil
+}
+
+// 순서를 정한 category 와 history 에서 사용중인 category 를 화면 순서대로 가져온다.
+func (repo *AboutRepository) GetHistoryCategories() ([]AboutHistoryCategoryModel, error) {
+
+	histories, err := repo.GetHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]AboutHistoryCategoryModel, 0)
+	indexByName := make(map[string]int)
+
+	rows, err := db.Query("SELECT name FROM about_history_category ORDER BY displayOrder, id")
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		displayOrder := len(categories)
+		indexByName[strings.ToLower(name)] = len(categories)
+		categories = append(categories, AboutHistoryCategoryModel{Name: name, DisplayOrder: &displayOrder})
+	}
+	rows.Close()
+
+	for _, history := range histories {
+		key := strings.ToLower(history.Category)
+		index, ok := indexByName[key]
+		if !ok {
+			index = len(categories)
+			indexByName[key] = index
+			categories = append(categories, AboutHistoryCategoryModel{Name: history.Category})
+		}
+
+		categories[index].HistoryCount++
+	}
+
+	return categories, nil
+}
+
+// names 순서대로 category 순서를 정한다. (names 에 없는 category 는 순서를 정하지 않은 상태가 된다.)
+// category 순서도 about 의 일부이므로 about 의 version 으로 확인한다.
+func (repo *AboutRepository) SetHistoryCategoryOrderIfMatch(expectedVersion *int64, names []string) error {
+
+	err := ValidateHistoryCategoryNames(names)
+	if err != nil {
+		return err
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	aboutId, err := repo.getAboutId()
+	if err != nil {
+		return err
+	}
+
+	if aboutId == -1 {
+		aboutId, err = repo.createAbout()
+		if err != nil {
+			return err
+		}
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	complete := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&complete, ContentChange{Content: AboutContent})
+	defer CloseTranstion(transaction, &complete)
+
+	err = bumpVersionTransaction(transaction, "about", AboutType, aboutId, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	_, err = transaction.Exec("DELETE FROM about_history_category")
+	if err != nil {
+		return err
+	}
+
+	for order, name := range names {
+		_, err = transaction.Exec("INSERT INTO about_history_category (name, displayOrder) VALUES ($1, $2)", strings.TrimSpace(name), order)
+		if err != nil {
+			return err
+		}
+	}
+
+	complete = true
+
+	return nil
+}
+
 func (repo *AboutRepository) UpdateAbout(profileImage *string, profileName *string, contact *string, introduceContent *string) (*string, error) {
+	return repo.UpdateAboutIfMatch(nil, profileImage, profileName, contact, introduceContent)
+}
+
+// expectedVersion 이 있으면 현재 version 과 같을 때만 수정한다. (다르면 VersionConflictError)
+func (repo *AboutRepository) UpdateAboutIfMatch(expectedVersion *int64, profileImage *string, profileName *string, contact *string, introduceContent *string) (*string, error) {
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
 		return nil, err
@@ -232,6 +437,7 @@ func (repo *AboutRepository) UpdateAbout(profileImage *string, profileName *stri
 		return nil, err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&complete, ContentChange{Content: AboutContent})
 	defer CloseTranstion(transaction, &complete)
 
 	aboutId, err := repo.getAboutId()
@@ -246,41 +452,48 @@ func (repo *AboutRepository) UpdateAbout(profileImage *string, profileName *stri
 		}
 	}
 
+	// version 을 올리면 about 테이블에 write lock 이 걸리므로 이전 profileImage 는 먼저 읽어둔다.
 	var removePorfileIamge *string
 	if profileImage != nil {
-
 		removePorfileIamge, _ = repo.getProfileImage()
+	}
 
-		profileImageUpdateQuery := fmt.Sprintf("UPDATE about SET profileImage = \"%v\" WHERE id = $1", *profileImage)
+	err = bumpVersionTransaction(transaction, "about", AboutType, aboutId, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
 
-		_, err = transaction.Exec(profileImageUpdateQuery, aboutId)
+	if profileImage != nil {
+		profileImageUpdateQuery := "UPDATE about SET profileImage = $1 WHERE id = $2"
+
+		_, err = transaction.Exec(profileImageUpdateQuery, *profileImage, aboutId)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if profileName != nil {
-		profileNameUpdateQuery := fmt.Sprintf("UPDATE about SET profileName = \"%v\" WHERE id = $1", *profileName)
+		profileNameUpdateQuery := "UPDATE about SET profileName = $1 WHERE id = $2"
 
-		_, err = transaction.Exec(profileNameUpdateQuery, aboutId)
+		_, err = transaction.Exec(profileNameUpdateQuery, *profileName, aboutId)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if contact != nil {
-		profileContactUpdateQuery := fmt.Sprintf("UPDATE about SET contact = \"%v\" WHERE id = $1", *contact)
+		profileContactUpdateQuery := "UPDATE about SET contact = $1 WHERE id = $2"
 
-		_, err = transaction.Exec(profileContactUpdateQuery, aboutId)
+		_, err = transaction.Exec(profileContactUpdateQuery, *contact, aboutId)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if introduceContent != nil {
-		profileContactUpdateQuery := fmt.Sprintf("UPDATE about SET introduceContent = \"%v\" WHERE id = $1", *introduceContent)
+		profileContactUpdateQuery := "UPDATE about SET introduceContent = $1 WHERE id = $2"
 
-		_, err = transaction.Exec(profileContactUpdateQuery, aboutId)
+		_, err = transaction.Exec(profileContactUpdateQuery, *introduceContent, aboutId)
 		if err != nil {
 			return nil, err
 		}
@@ -291,29 +504,53 @@ func (repo *AboutRepository) UpdateAbout(profileImage *string, profileName *stri
 }
 
 func (repo *AboutRepository) UpdateAboutHistory(removeId []int64, updateHistoryInfos []AboutHistoryIdContent, addHistoryInfos []AboutHistoryContent) error {
+	return repo.UpdateAboutHistoryIfMatch(nil, removeId, updateHistoryInfos, addHistoryInfos)
+}
+
+// history 도 about 의 일부이므로 about 의 version 으로 확인한다.
+func (repo *AboutRepository) UpdateAboutHistoryIfMatch(expectedVersion *int64, removeId []int64, updateHistoryInfos []AboutHistoryIdContent, addHistoryInfos []AboutHistoryContent) error {
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
 		return err
 	}
 
+	aboutId, err := repo.getAboutId()
+	if err != nil {
+		return err
+	}
+
+	if aboutId == -1 {
+		aboutId, err = repo.createAbout()
+		if err != nil {
+			return err
+		}
+	}
+
 	transaction, err := db.Begin()
 	if err != nil {
 		return err
 	}
 
 	complete := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&complete, ContentChange{Content: AboutContent})
 	defer CloseTranstion(transaction, &complete)
 
+	err = bumpVersionTransaction(transaction, "about", AboutType, aboutId, expectedVersion)
+	if err != nil {
+		return err
+	}
+
 	if len(removeId) > 0 {
 		removeIdStrs := funk.Map(removeId, func(e int64) string {
 			return fmt.Sprintf("%d", e)
 		})
 
+		// 바로 지우지 않고 휴지통으로 보낸다.
 		removeIdJoined := strings.Join(removeIdStrs.([]string), ",")
-		removeQuery := fmt.Sprintf("DELETE FROM about_history WHERE id in (%s)", removeIdJoined)
+		removeQuery := fmt.Sprintf("UPDATE about_history SET deletedAt = $1 WHERE id in (%s) AND deletedAt IS NULL", removeIdJoined)
 
-		_, err := transaction.Exec(removeQuery)
+		_, err := transaction.Exec(removeQuery, time.Now().Unix())
 		if err != nil {
 			return err
 		}
@@ -322,10 +559,18 @@ func (repo *AboutRepository) UpdateAboutHistory(removeId []int64, updateHistoryI
 	if len(updateHistoryInfos) > 0 {
 
 		for _, updateHistoryInfo := range updateHistoryInfos {
-			update_history_query := fmt.Sprintf("UPDATE about_history SET category = '%s', duration = '%s', content = '%s' WHERE id = $1",
-				updateHistoryInfo.Category, updateHistoryInfo.Duration, updateHistoryInfo.Content)
+			updateHistoryInfo.resolveDates()
+			err = updateHistoryInfo.ValidateDates()
+			if err != nil {
+				return err
+			}
 
-			_, err = transaction.Exec(update_history_query, updateHistoryInfo.Id)
+			update_history_query := "UPDATE about_history SET category = $1, duration = $2, content = $3, startDate = $4, endDate = $5 WHERE id = $6"
+
+			_, err = transaction.Exec(update_history_query,
+				updateHistoryInfo.Category, updateHistoryInfo.Duration, updateHistoryInfo.Content,
+				historyDateColumn(updateHistoryInfo.StartDate), historyEndDateColumn(updateHistoryInfo.EndDate, updateHistoryInfo.IsPresent),
+				updateHistoryInfo.Id)
 			if err != nil {
 				return err
 			}
@@ -334,7 +579,7 @@ func (repo *AboutRepository) UpdateAboutHistory(removeId []int64, updateHistoryI
 
 	if len(addHistoryInfos) > 0 {
 
-		stmt, err := transaction.Prepare("INSERT INTO about_history (category, duration, content) VALUES ($1, $2, $3)")
+		stmt, err := transaction.Prepare("INSERT INTO about_history (category, duration, content, startDate, endDate) VALUES ($1, $2, $3, $4, $5)")
 		if err != nil {
 			return err
 		}
@@ -342,7 +587,14 @@ func (repo *AboutRepository) UpdateAboutHistory(removeId []int64, updateHistoryI
 		defer stmt.Close()
 
 		for _, addHistoryInfo := range addHistoryInfos {
-			_, err = stmt.Exec(addHistoryInfo.Category, addHistoryInfo.Duration, addHistoryInfo.Content)
+			addHistoryInfo.resolveDates()
+			err = addHistoryInfo.ValidateDates()
+			if err != nil {
+				return err
+			}
+
+			_, err = stmt.Exec(addHistoryInfo.Category, addHistoryInfo.Duration, addHistoryInfo.Content,
+				historyDateColumn(addHistoryInfo.StartDate), historyEndDateColumn(addHistoryInfo.EndDate, addHistoryInfo.IsPresent))
 			if err != nil {
 				return err
 			}
@@ -353,3 +605,75 @@ func (repo *AboutRepository) UpdateAboutHistory(removeId []int64, updateHistoryI
 
 	return nil
 }
+
+func (repo *AboutRepository) RestoreHistory(historyId int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	aboutId, err := repo.getAboutId()
+	if err != nil {
+		return err
+	}
+
+	if aboutId == -1 {
+		aboutId, err = repo.createAbout()
+		if err != nil {
+			return err
+		}
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	complete := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&complete, ContentChange{Content: AboutContent})
+	defer CloseTranstion(transaction, &complete)
+
+	err = restoreRow(transaction, "about_history", AboutHistoryType, historyId)
+	if err != nil {
+		return err
+	}
+
+	// 되살린 history 도 GET /about 에 보이므로 about 의 version 을 올린다. (ETag)
+	err = bumpVersionTransaction(transaction, "about", AboutType, aboutId, nil)
+	if err != nil {
+		return err
+	}
+
+	complete = true
+
+	return nil
+}
+
+func (repo *AboutRepository) GetTrashedHistoryList() ([]TrashModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return getTrashList(db, "about_history", "content", AboutHistoryType)
+}
+
+// deletedBefore 이전에 휴지통으로 간 history 를 영구 삭제한다.
+func (repo *AboutRepository) PurgeTrashedHistories(deletedBefore time.Time) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("DELETE FROM about_history WHERE deletedAt IS NOT NULL AND deletedAt <= $1", deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+
+	repo.DBConnect.notifyContentChanges(ContentChange{Content: AboutContent})
+
+	return nil
+}
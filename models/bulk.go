@@ -0,0 +1,201 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type BulkAction string
+
+const (
+	BulkActionDelete       BulkAction = "delete"
+	BulkActionRetitle      BulkAction = "retitle"
+	BulkActionRemoveImages BulkAction = "remove_images"
+)
+
+// potofolio, essay 하나에 대한 작업
+type BulkOperation struct {
+	Type   RepositoryType
+	Id     int64
+	Action BulkAction
+
+	// 있으면 현재 version 과 같을 때만 처리한다.
+	ExpectedVersion *int64
+
+	// retitle
+	Title *string
+
+	// remove_images, nil 이면 모든 image 를 뺀다.
+	ImageIds []int64
+}
+
+type BulkResultModel struct {
+	Operation BulkOperation
+	Err       error
+
+	// 처리된 뒤의 version (실패하면 0)
+	Version int64
+
+	// potofolio 에서 빠진 image 경로 (commit 된 뒤에 파일을 지운다.)
+	// essay 의 image 는 revision 이 참조하므로 넣지 않는다.
+	RemoveImagePaths []string
+}
+
+type BulkActionError struct {
+	Type   RepositoryType
+	Action BulkAction
+}
+
+func (e *BulkActionError) Error() string {
+	return fmt.Sprintf("%v can not be %v", e.Type, e.Action)
+}
+
+// 여러 potofolio, essay 를 한 transaction 으로 처리한다.
+type BulkRepository struct {
+	DBConnect     *DBConnection
+	PotofolioRepo *PotofolioRepository
+	EssayRepo     *EssayRepository
+}
+
+// 작업마다 savepoint 를 두어서 실패한 작업만 되돌린다.
+// atomic 이면 하나라도 실패했을 때 모두 되돌린다. (반환되는 committed 가 false)
+func (repo *BulkRepository) ExecuteBulk(author string, operations []BulkOperation, atomic bool) ([]BulkResultModel, bool, error) {
+
+	completed := false
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 성공한 작업의 content 만 알린다.
+	changes := make([]ContentChange, 0, len(operations))
+	defer func() {
+		repo.DBConnect.notifyContentChangesIfCompleted(&completed, changes...)
+	}()
+	defer CloseTranstion(transaction, &completed)
+
+	failed := false
+	results := make([]BulkResultModel, 0, len(operations))
+	for index, operation := range operations {
+
+		savepoint := fmt.Sprintf("bulk_%d", index)
+		_, err = transaction.Exec("SAVEPOINT " + savepoint)
+		if err != nil {
+			return nil, false, err
+		}
+
+		result := BulkResultModel{Operation: operation}
+		result.RemoveImagePaths, result.Err = repo.executeOperationTransaction(transaction, author, &operation)
+		if result.Err == nil {
+			result.Version, result.Err = getVersionTransaction(transaction, operation.Type, operation.Id)
+		}
+
+		if result.Err != nil {
+			failed = true
+			result.RemoveImagePaths = nil
+
+			_, err = transaction.Exec("ROLLBACK TO " + savepoint)
+			if err != nil {
+				return nil, false, err
+			}
+		} else {
+			changes = append(changes, repositoryContentChange(operation.Type, operation.Id))
+		}
+
+		_, err = transaction.Exec("RELEASE " + savepoint)
+		if err != nil {
+			return nil, false, err
+		}
+
+		results = append(results, result)
+	}
+
+	completed = !(atomic && failed)
+
+	return results, completed, nil
+}
+
+func (repo *BulkRepository) executeOperationTransaction(tx *sql.Tx, author string, operation *BulkOperation) ([]string, error) {
+
+	table := ""
+	switch operation.Type {
+	case PotofolioType:
+		table = "potofolio"
+	case EssayType:
+		table = "essay"
+	default:
+		return nil, &BulkActionError{Type: operation.Type, Action: operation.Action}
+	}
+
+	// 휴지통에 있으면 없는 것으로 처리한다.
+	var currentVersion int64
+	err := tx.QueryRow(fmt.Sprintf("SELECT version FROM \"%s\" WHERE id = $1 AND deletedAt IS NULL", table), operation.Id).Scan(&currentVersion)
+	if err == sql.ErrNoRows {
+		if operation.Type == PotofolioType {
+			return nil, &PotofolioNotFoundError{Id: operation.Id}
+		}
+
+		return nil, &EssayNotFoundError{Id: operation.Id}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Action {
+	case BulkActionDelete:
+		return nil, trashRowTransaction(tx, table, operation.Type, operation.Id, operation.ExpectedVersion)
+
+	case BulkActionRetitle:
+		if operation.Title == nil {
+			return nil, &BulkActionError{Type: operation.Type, Action: operation.Action}
+		}
+
+		if operation.Type == PotofolioType {
+			return repo.PotofolioRepo.updatePotofolioTransaction(tx, operation.Id, operation.ExpectedVersion, nil, nil, operation.Title, nil, nil)
+		}
+
+		_, err = repo.EssayRepo.updateEssayTransaction(tx, author, operation.Id, operation.ExpectedVersion, nil, operation.Title, nil, nil, nil, nil)
+		return nil, err
+
+	case BulkActionRemoveImages:
+		imageIds := operation.ImageIds
+		if imageIds == nil {
+			images, err := repo.PotofolioRepo.ImageRepo.GetImagesTransaction(tx, operation.Type, operation.Id)
+			if err != nil {
+				return nil, err
+			}
+
+			imageIds = make([]int64, 0, len(images))
+			for _, image := range images {
+				imageIds = append(imageIds, image.Id)
+			}
+		}
+
+		if operation.Type == PotofolioType {
+			return repo.PotofolioRepo.updatePotofolioTransaction(tx, operation.Id, operation.ExpectedVersion, nil, nil, nil, imageIds, nil)
+		}
+
+		_, err = repo.EssayRepo.updateEssayTransaction(tx, author, operation.Id, operation.ExpectedVersion, nil, nil, nil, nil, imageIds, nil)
+		return nil, err
+	}
+
+	return nil, &BulkActionError{Type: operation.Type, Action: operation.Action}
+}
+
+func getVersionTransaction(tx *sql.Tx, repositoryType RepositoryType, id int64) (int64, error) {
+
+	var version int64
+	err := tx.QueryRow(fmt.Sprintf("SELECT version FROM \"%s\" WHERE id = $1", repositoryType.String()), id).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
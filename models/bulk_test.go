@@ -0,0 +1,114 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBulk(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:bulk_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	essayRepo := repositoryConfigure.EssayRepository
+	bulkRepo := repositoryConfigure.BulkRepository
+
+	potofolioRepo.AddPotofolio("stone", []string{"/images/stone1.jpg", "/images/stone2.jpg"})
+	potofolioRepo.AddPotofolio("sea", nil)
+	essayRepo.AddEssay("trip", "", "content", []string{"/images/trip.jpg"})
+
+	title := "stone series"
+	wrongVersion := int64(100)
+	results, committed, err := bulkRepo.ExecuteBulk("cho", []BulkOperation{
+		{Type: PotofolioType, Id: 1, Action: BulkActionRetitle, Title: &title},
+		{Type: PotofolioType, Id: 1, Action: BulkActionRemoveImages},
+		{Type: PotofolioType, Id: 2, Action: BulkActionDelete, ExpectedVersion: &wrongVersion},
+		{Type: PotofolioType, Id: 100, Action: BulkActionDelete},
+		{Type: EssayType, Id: 1, Action: BulkActionDelete},
+	}, false)
+	assert.Nil(t, err)
+	assert.True(t, committed)
+	assert.Equal(t, len(results), 5)
+
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, results[0].Version, int64(2))
+	assert.Nil(t, results[1].Err)
+	assert.Equal(t, results[1].RemoveImagePaths, []string{"/images/stone1.jpg", "/images/stone2.jpg"})
+	assert.Equal(t, results[2].Err, &VersionConflictError{Type: PotofolioType, Id: 2, CurrentVersion: 1})
+	assert.Equal(t, results[3].Err, &PotofolioNotFoundError{Id: 100})
+	assert.Nil(t, results[4].Err)
+
+	potofolio, err := potofolioRepo.FindPotofolio(1)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Title, "stone series")
+	assert.Equal(t, len(potofolio.Images), 0)
+
+	_, err = potofolioRepo.FindPotofolio(2)
+	assert.Nil(t, err)
+
+	trashModels, err := essayRepo.GetTrashedEssayList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(trashModels), 1)
+
+	// atomic 이면 하나라도 실패했을 때 모두 되돌린다.
+	title = "sea series"
+	results, committed, err = bulkRepo.ExecuteBulk("cho", []BulkOperation{
+		{Type: PotofolioType, Id: 2, Action: BulkActionRetitle, Title: &title},
+		{Type: EssayType, Id: 1, Action: BulkActionRetitle, Title: &title},
+	}, true)
+	assert.Nil(t, err)
+	assert.False(t, committed)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, results[1].Err, &EssayNotFoundError{Id: 1})
+
+	potofolio, err = potofolioRepo.FindPotofolio(2)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Title, "sea")
+	assert.Equal(t, potofolio.Version, int64(1))
+}
+
+// title 은 SQL 문에 넣지 않으므로 따옴표, column 이름도 그대로 저장한다.
+func TestExecuteBulkRetitleText(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:bulk_retitle_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	essayRepo := repositoryConfigure.EssayRepository
+
+	potofolioRepo.AddPotofolio("stone", nil)
+	potofolioRepo.AddPotofolio("sea", nil)
+	essayRepo.AddEssay("trip", "", "content", nil)
+
+	quotedTitle := `12" stone`
+	columnTitle := "id"
+	results, committed, err := repositoryConfigure.BulkRepository.ExecuteBulk("cho", []BulkOperation{
+		{Type: PotofolioType, Id: 1, Action: BulkActionRetitle, Title: &quotedTitle},
+		{Type: PotofolioType, Id: 2, Action: BulkActionRetitle, Title: &columnTitle},
+		{Type: EssayType, Id: 1, Action: BulkActionRetitle, Title: &columnTitle},
+	}, true)
+	assert.Nil(t, err)
+	assert.True(t, committed)
+
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+	}
+
+	potofolio, err := potofolioRepo.FindPotofolio(1)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Title, `12" stone`)
+
+	potofolio, err = potofolioRepo.FindPotofolio(2)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Title, "id")
+
+	essay, err := essayRepo.FindEssay(1)
+	assert.Nil(t, err)
+	assert.Equal(t, essay.Title, "id")
+}
@@ -0,0 +1,545 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// potofolio 를 묶는 series, project
+type CollectionModel struct {
+	Id          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CoverImage  string    `json:"coverImage"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// 정해진 순서대로 (휴지통에 있는 potofolio 는 빠진다.)
+	PotofolioIds []int64 `json:"potofolioIds"`
+}
+
+// potofolio 가 속한 collection
+type CollectionRefModel struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type CollectionNotFoundError struct {
+	Id int64
+}
+
+func (e *CollectionNotFoundError) Error() string {
+	return fmt.Sprintf("collection not found [id:%v]", e.Id)
+}
+
+// collection 에 넣을 potofolio 가 잘못된 경우 (없는 id, 중복된 id)
+type CollectionItemError struct {
+	PotofolioId int64
+	Reason      string
+}
+
+func (e *CollectionItemError) Error() string {
+	return fmt.Sprintf("collection item is wrong [potofolioId:%v] (%s)", e.PotofolioId, e.Reason)
+}
+
+type CollectionRepository struct {
+	DBConnect *DBConnection
+}
+
+// potofolio table 이 만들어진 뒤에 호출해야 한다.
+func (repo *CollectionRepository) CreateTable() error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	createCollectionTableQuery := `
+		CREATE TABLE IF NOT EXISTS "collection"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"title" TEXT NOT NULL,
+			"description" TEXT NOT NULL DEFAULT '',
+			"coverImage" TEXT NOT NULL DEFAULT '',
+			"createdAt" INTEGER,
+			"updatedAt" INTEGER
+		)`
+
+	_, err = db.Exec(createCollectionTableQuery)
+	if err != nil {
+		return err
+	}
+
+	createCollectionItemTableQuery := `
+		CREATE TABLE IF NOT EXISTS "collection_item"
+		(
+			"collectionId" INTEGER NOT NULL,
+			"potofolioId" INTEGER NOT NULL,
+			"itemOrder" INTEGER,
+			PRIMARY KEY ("collectionId", "potofolioId")
+		)`
+
+	_, err = db.Exec(createCollectionItemTableQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS "collection_item_potofolio" ON "collection_item" ("potofolioId")`)
+	if err != nil {
+		return err
+	}
+
+	// collection 이 지워지거나 potofolio 가 영구 삭제되면 연결도 지운다.
+	triggerQueries := []string{`
+		CREATE TRIGGER IF NOT EXISTS "collection_item_delete"
+		AFTER DELETE ON "collection"
+		BEGIN
+			DELETE FROM collection_item WHERE collectionId = OLD.id;
+		END`, `
+		CREATE TRIGGER IF NOT EXISTS "potofolio_collection_item_delete"
+		AFTER DELETE ON "potofolio"
+		BEGIN
+			DELETE FROM collection_item WHERE potofolioId = OLD.id;
+		END`,
+	}
+
+	for _, triggerQuery := range triggerQueries {
+		_, err = db.Exec(triggerQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// statuses 가 비어 있으면 모든 공개 상태의 potofolio 를 넣는다.
+func collectionItemCondition(statuses []PublishStatus, args []interface{}) (string, []interface{}) {
+
+	condition := "potofolio.deletedAt IS NULL"
+	if len(statuses) == 0 {
+		return condition, args
+	}
+
+	placeholders := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		args = append(args, string(status))
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	return condition + fmt.Sprintf(" AND potofolio.status IN (%s)", strings.Join(placeholders, ", ")), args
+}
+
+// collection 의 potofolio id 들을 한번에 가져온다.
+func (repo *CollectionRepository) getPotofolioIdsByCollectionIds(db *sql.DB, collectionIds []int64, statuses []PublishStatus) (map[int64][]int64, error) {
+
+	potofolioIdsById := make(map[int64][]int64)
+	for _, collectionId := range collectionIds {
+		potofolioIdsById[collectionId] = make([]int64, 0)
+	}
+
+	if len(collectionIds) == 0 {
+		return potofolioIdsById, nil
+	}
+
+	args := make([]interface{}, 0)
+	placeholders := make([]string, 0, len(collectionIds))
+	for _, collectionId := range collectionIds {
+		args = append(args, collectionId)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	condition, args := collectionItemCondition(statuses, args)
+
+	selectQuery := fmt.Sprintf(`
+		SELECT collection_item.collectionId, collection_item.potofolioId
+		FROM collection_item
+		JOIN potofolio ON potofolio.id = collection_item.potofolioId
+		WHERE collection_item.collectionId IN (%s) AND %s
+		ORDER BY collection_item.collectionId, collection_item.itemOrder`, strings.Join(placeholders, ", "), condition)
+
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var collectionId int64
+		var potofolioId int64
+		err = rows.Scan(&collectionId, &potofolioId)
+		if err != nil {
+			return nil, err
+		}
+
+		potofolioIdsById[collectionId] = append(potofolioIdsById[collectionId], potofolioId)
+	}
+
+	return potofolioIdsById, nil
+}
+
+func (repo *CollectionRepository) queryCollections(db *sql.DB, whereClause string, args []interface{}, statuses []PublishStatus) ([]CollectionModel, error) {
+
+	selectQuery := "SELECT id, title, description, coverImage, createdAt, updatedAt FROM collection " + whereClause + " ORDER BY id"
+
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]CollectionModel, 0)
+	for rows.Next() {
+		var collection CollectionModel
+		var createdAt int64
+		var updatedAt int64
+		err = rows.Scan(&collection.Id, &collection.Title, &collection.Description, &collection.CoverImage, &createdAt, &updatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		collection.CreatedAt = time.Unix(createdAt, 0)
+		collection.UpdatedAt = time.Unix(updatedAt, 0)
+		collections = append(collections, collection)
+	}
+	rows.Close()
+
+	collectionIds := make([]int64, 0, len(collections))
+	for _, collection := range collections {
+		collectionIds = append(collectionIds, collection.Id)
+	}
+
+	potofolioIdsById, err := repo.getPotofolioIdsByCollectionIds(db, collectionIds, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range collections {
+		collections[i].PotofolioIds = potofolioIdsById[collections[i].Id]
+	}
+
+	return collections, nil
+}
+
+// statuses 가 있으면 그 공개 상태의 potofolio 만 넣는다.
+func (repo *CollectionRepository) GetCollectionList(statuses []PublishStatus) ([]CollectionModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.queryCollections(db, "", nil, statuses)
+}
+
+func (repo *CollectionRepository) FindCollection(collectionId int64, statuses []PublishStatus) (*CollectionModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := repo.queryCollections(db, "WHERE id = $1", []interface{}{collectionId}, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(collections) == 0 {
+		return nil, &CollectionNotFoundError{Id: collectionId}
+	}
+
+	return &collections[0], nil
+}
+
+// collection 에 들어 있는 potofolio 의 version 을 올린다.
+// 단건 조회의 ETag 가 version 이라서 collection 이름이 바뀌어도 version 이 같으면 이전 응답으로 304 가 된다.
+func bumpCollectionItemVersionsTransaction(tx *sql.Tx, collectionId int64) error {
+
+	bumpQuery := "UPDATE potofolio SET version = version + 1 WHERE id IN (SELECT potofolioId FROM collection_item WHERE collectionId = $1)"
+
+	_, err := tx.Exec(bumpQuery, collectionId)
+	return err
+}
+
+// collection 의 potofolio 를 potofolioIds 순서대로 바꾼다.
+func setCollectionItemsTransaction(tx *sql.Tx, collectionId int64, potofolioIds []int64) error {
+
+	requested := make(map[int64]bool)
+	for _, potofolioId := range potofolioIds {
+		if requested[potofolioId] {
+			return &CollectionItemError{PotofolioId: potofolioId, Reason: "duplicated"}
+		}
+
+		requested[potofolioId] = true
+
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM potofolio WHERE id = $1 AND deletedAt IS NULL)", potofolioId).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return &CollectionItemError{PotofolioId: potofolioId, Reason: "not found"}
+		}
+	}
+
+	// 빠지거나 새로 들어간 potofolio 는 collection 목록이 바뀌므로 version 을 올린다.
+	previous := make(map[int64]bool)
+	rows, err := tx.Query("SELECT potofolioId FROM collection_item WHERE collectionId = $1", collectionId)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var potofolioId int64
+		err = rows.Scan(&potofolioId)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		previous[potofolioId] = true
+	}
+
+	rows.Close()
+
+	for potofolioId := range previous {
+		if !requested[potofolioId] {
+			err = bumpVersionTransaction(tx, "potofolio", PotofolioType, potofolioId, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, potofolioId := range potofolioIds {
+		if !previous[potofolioId] {
+			err = bumpVersionTransaction(tx, "potofolio", PotofolioType, potofolioId, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM collection_item WHERE collectionId = $1", collectionId)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := "INSERT INTO collection_item (collectionId, potofolioId, itemOrder) VALUES ($1, $2, $3)"
+	for order, potofolioId := range potofolioIds {
+		_, err = tx.Exec(insertQuery, collectionId, potofolioId, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *CollectionRepository) AddCollection(title string, description string, coverImage string, potofolioIds []int64) (int64, error) {
+
+	completed := false
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return 0, err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: CollectionContent})
+	defer CloseTranstion(transaction, &completed)
+
+	now := time.Now().Unix()
+	insertQuery := "INSERT INTO collection (title, description, coverImage, createdAt, updatedAt) VALUES ($1, $2, $3, $4, $5)"
+
+	result, err := transaction.Exec(insertQuery, title, description, coverImage, now, now)
+	if err != nil {
+		return 0, err
+	}
+
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = setCollectionItemsTransaction(transaction, insertId, potofolioIds)
+	if err != nil {
+		return 0, err
+	}
+
+	completed = true
+
+	return insertId, nil
+}
+
+// nil 인 항목은 바꾸지 않는다. cover image 가 바뀌면 이전 cover image 를 반환한다.
+func (repo *CollectionRepository) UpdateCollection(collectionId int64, title *string, description *string, coverImage *string, potofolioIds []int64) (string, error) {
+
+	completed := false
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return "", err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: CollectionContent, Id: collectionId})
+	defer CloseTranstion(transaction, &completed)
+
+	var prevCoverImage string
+	err = transaction.QueryRow("SELECT coverImage FROM collection WHERE id = $1", collectionId).Scan(&prevCoverImage)
+	if err == sql.ErrNoRows {
+		return "", &CollectionNotFoundError{Id: collectionId}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	err = touchUpdatedAtTransaction(transaction, "collection", collectionId)
+	if err != nil {
+		return "", err
+	}
+
+	if title != nil {
+		_, err = transaction.Exec("UPDATE collection SET title = $1 WHERE id = $2", *title, collectionId)
+		if err != nil {
+			return "", err
+		}
+
+		err = bumpCollectionItemVersionsTransaction(transaction, collectionId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if description != nil {
+		_, err = transaction.Exec("UPDATE collection SET description = $1 WHERE id = $2", *description, collectionId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	removeCoverImage := ""
+	if coverImage != nil {
+		_, err = transaction.Exec("UPDATE collection SET coverImage = $1 WHERE id = $2", *coverImage, collectionId)
+		if err != nil {
+			return "", err
+		}
+
+		removeCoverImage = prevCoverImage
+	}
+
+	if potofolioIds != nil {
+		err = setCollectionItemsTransaction(transaction, collectionId, potofolioIds)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	completed = true
+
+	return removeCoverImage, nil
+}
+
+// collection 만 지우고 potofolio 는 그대로 둔다. 지워진 collection 의 cover image 를 반환한다.
+func (repo *CollectionRepository) RemoveCollection(collectionId int64) (string, error) {
+
+	completed := false
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return "", err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: CollectionContent, Id: collectionId})
+	defer CloseTranstion(transaction, &completed)
+
+	var coverImage string
+	err = transaction.QueryRow("SELECT coverImage FROM collection WHERE id = $1", collectionId).Scan(&coverImage)
+	if err == sql.ErrNoRows {
+		return "", &CollectionNotFoundError{Id: collectionId}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	err = bumpCollectionItemVersionsTransaction(transaction, collectionId)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = transaction.Exec("DELETE FROM collection WHERE id = $1", collectionId)
+	if err != nil {
+		return "", err
+	}
+
+	completed = true
+
+	return coverImage, nil
+}
+
+// 여러 potofolio 가 속한 collection 을 한번에 가져온다. collection 이 없는 potofolio 도 빈 목록으로 채운다.
+func getCollectionsByPotofolioIds(db *sql.DB, potofolioIds []int64) (map[int64][]CollectionRefModel, error) {
+
+	collectionsById := make(map[int64][]CollectionRefModel)
+	for _, potofolioId := range potofolioIds {
+		collectionsById[potofolioId] = make([]CollectionRefModel, 0)
+	}
+
+	if len(potofolioIds) == 0 {
+		return collectionsById, nil
+	}
+
+	args := make([]interface{}, 0, len(potofolioIds))
+	placeholders := make([]string, 0, len(potofolioIds))
+	for _, potofolioId := range potofolioIds {
+		args = append(args, potofolioId)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT collection_item.potofolioId, collection.id, collection.title
+		FROM collection_item
+		JOIN collection ON collection.id = collection_item.collectionId
+		WHERE collection_item.potofolioId IN (%s)
+		ORDER BY collection.id`, strings.Join(placeholders, ", "))
+
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var potofolioId int64
+		var collection CollectionRefModel
+		err = rows.Scan(&potofolioId, &collection.Id, &collection.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		collectionsById[potofolioId] = append(collectionsById[potofolioId], collection)
+	}
+
+	return collectionsById, nil
+}
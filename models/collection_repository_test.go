@@ -0,0 +1,97 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:collection_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	collectionRepo := repositoryConfigure.CollectionRepository
+
+	potofolioRepo.AddPotofolio("stone", nil)
+	potofolioRepo.AddPotofolio("sea", nil)
+	potofolioRepo.AddPotofolioWithOption(&ContentOption{Publish: &PublishOption{Status: PublishStatusDraft}}, "draft", nil)
+
+	collectionId, err := collectionRepo.AddCollection("summer", "여름 작업", "/images/cover.jpg", []int64{2, 1, 3})
+	assert.Nil(t, err)
+
+	collection, err := collectionRepo.FindCollection(collectionId, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, collection.Title, "summer")
+	assert.Equal(t, collection.CoverImage, "/images/cover.jpg")
+	assert.Equal(t, collection.PotofolioIds, []int64{2, 1, 3})
+
+	// collection 의 potofolio 는 한번에 가져온다. (collection 순서)
+	potofolios, err := potofolioRepo.FindPotofolios(collection.PotofolioIds)
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 3)
+	assert.Equal(t, potofolios[0].Title, "sea")
+	assert.Equal(t, potofolios[1].Title, "stone")
+	assert.Equal(t, potofolios[1].Collections, []CollectionRefModel{{Id: collectionId, Title: "summer"}})
+
+	potofolios, err = potofolioRepo.FindPotofolios([]int64{100, 1})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 1)
+	assert.Equal(t, potofolios[0].Id, int64(1))
+
+	// 공개 상태로 거른다.
+	collection, err = collectionRepo.FindCollection(collectionId, []PublishStatus{PublishStatusPublished})
+	assert.Nil(t, err)
+	assert.Equal(t, collection.PotofolioIds, []int64{2, 1})
+
+	// potofolio 에서도 collection 이 보인다.
+	potofolio, err := potofolioRepo.FindPotofolio(1)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Collections, []CollectionRefModel{{Id: collectionId, Title: "summer"}})
+
+	// 잘못된 potofolio
+	_, err = collectionRepo.AddCollection("wrong", "", "", []int64{1, 1})
+	assert.Equal(t, err, &CollectionItemError{PotofolioId: 1, Reason: "duplicated"})
+
+	_, err = collectionRepo.UpdateCollection(collectionId, nil, nil, nil, []int64{100})
+	assert.Equal(t, err, &CollectionItemError{PotofolioId: 100, Reason: "not found"})
+
+	// 수정
+	title := "summer series"
+	coverImage := "/images/cover2.jpg"
+	removeCoverImage, err := collectionRepo.UpdateCollection(collectionId, &title, nil, &coverImage, []int64{1})
+	assert.Nil(t, err)
+	assert.Equal(t, removeCoverImage, "/images/cover.jpg")
+
+	collection, err = collectionRepo.FindCollection(collectionId, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, collection.Title, "summer series")
+	assert.Equal(t, collection.Description, "여름 작업")
+	assert.Equal(t, collection.PotofolioIds, []int64{1})
+
+	// 휴지통에 있는 potofolio 는 빠진다.
+	assert.Nil(t, potofolioRepo.TrashPotofolio(1, nil))
+
+	collections, err := collectionRepo.GetCollectionList(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(collections), 1)
+	assert.Equal(t, len(collections[0].PotofolioIds), 0)
+
+	// 삭제
+	removeCoverImage, err = collectionRepo.RemoveCollection(collectionId)
+	assert.Nil(t, err)
+	assert.Equal(t, removeCoverImage, "/images/cover2.jpg")
+
+	_, err = collectionRepo.FindCollection(collectionId, nil)
+	assert.Equal(t, err, &CollectionNotFoundError{Id: collectionId})
+
+	_, err = collectionRepo.RemoveCollection(collectionId)
+	assert.Equal(t, err, &CollectionNotFoundError{Id: collectionId})
+
+	potofolio, err = potofolioRepo.FindPotofolio(2)
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolio.Collections), 0)
+}
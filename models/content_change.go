@@ -0,0 +1,86 @@
+package models
+
+import "sync"
+
+// ContentChange.Content
+const (
+	PotofolioContent  = "potofolio"
+	EssayContent      = "essay"
+	AboutContent      = "about"
+	TagContent        = "tag"
+	CollectionContent = "collection"
+)
+
+// ContentChange.Id 가 이 값이면 그 종류의 content 가 모두 바뀐 것이다. (순서 변경)
+const AllContentIds int64 = -1
+
+// repository 가 공개 content 를 바꾸고 commit 한 뒤에 알린다. (조회 응답 캐시를 지울 때 사용한다.)
+// Id 가 0 이면 특정 content 가 아니라 목록만 바뀐 것이다. (추가, about)
+type ContentChange struct {
+	Content string
+	Id      int64
+}
+
+type ContentChangeListener func(changes []ContentChange)
+
+type contentChangeNotifier struct {
+	mutex     sync.RWMutex
+	listeners []ContentChangeListener
+}
+
+// about_history 는 about 응답에 들어간다.
+func repositoryContentChange(repositoryType RepositoryType, id int64) ContentChange {
+
+	switch repositoryType {
+	case PotofolioType:
+		return ContentChange{Content: PotofolioContent, Id: id}
+	case EssayType:
+		return ContentChange{Content: EssayContent, Id: id}
+	}
+
+	return ContentChange{Content: AboutContent}
+}
+
+func repositoryContentChanges(repositoryType RepositoryType, ids []int64) []ContentChange {
+
+	changes := make([]ContentChange, 0, len(ids))
+	for _, id := range ids {
+		changes = append(changes, repositoryContentChange(repositoryType, id))
+	}
+
+	return changes
+}
+
+// listener 는 변경한 goroutine 에서 바로 호출되므로 오래 걸리는 일을 하면 안된다.
+func (connect *DBConnection) AddContentChangeListener(listener ContentChangeListener) {
+
+	connect.changeNotifier.mutex.Lock()
+	defer connect.changeNotifier.mutex.Unlock()
+
+	connect.changeNotifier.listeners = append(connect.changeNotifier.listeners, listener)
+}
+
+func (connect *DBConnection) notifyContentChanges(changes ...ContentChange) {
+
+	if len(changes) == 0 {
+		return
+	}
+
+	connect.changeNotifier.mutex.RLock()
+	defer connect.changeNotifier.mutex.RUnlock()
+
+	for _, listener := range connect.changeNotifier.listeners {
+		listener(changes)
+	}
+}
+
+// CloseTranstion 보다 먼저 defer 해서 commit 된 뒤에 알리도록 한다.
+// (commit 전에 알리면 그 사이의 조회가 이전 내용을 다시 캐시할 수 있다.)
+func (connect *DBConnection) notifyContentChangesIfCompleted(completed *bool, changes ...ContentChange) {
+
+	if !*completed {
+		return
+	}
+
+	connect.notifyContentChanges(changes...)
+}
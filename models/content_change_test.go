@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentChange(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:content_change_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	changes := make([]ContentChange, 0)
+	repositoryConfigure.AddContentChangeListener(func(notified []ContentChange) {
+		changes = append(changes, notified...)
+	})
+
+	// 추가는 목록만 바뀐다.
+	essayId, err := repositoryConfigure.EssayRepository.AddEssay("trip", "", "content", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, changes, []ContentChange{{Content: EssayContent}})
+
+	changes = changes[:0]
+
+	title := "diary"
+	_, err = repositoryConfigure.EssayRepository.UpdateEssay(essayId, &title, nil, nil, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, changes, []ContentChange{{Content: EssayContent, Id: essayId}})
+
+	// 실패해서 되돌린 변경은 알리지 않는다.
+	changes = changes[:0]
+
+	expectedVersion := int64(100)
+	_, err = repositoryConfigure.EssayRepository.UpdateEssayBy("cho", essayId, &expectedVersion, &title, nil, nil, nil, nil)
+	assert.IsType(t, &VersionConflictError{}, err)
+	assert.Equal(t, len(changes), 0)
+
+	// bulk 는 성공한 작업만 알린다.
+	potofolioId, err := repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+	assert.Nil(t, err)
+
+	changes = changes[:0]
+
+	_, _, err = repositoryConfigure.BulkRepository.ExecuteBulk("cho", []BulkOperation{
+		{Type: PotofolioType, Id: potofolioId, Action: BulkActionRetitle, Title: &title},
+		{Type: EssayType, Id: 100, Action: BulkActionDelete},
+	}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, changes, []ContentChange{{Content: PotofolioContent, Id: potofolioId}})
+
+	// 순서를 바꾸면 다른 potofolio 의 position 도 바뀐다.
+	changes = changes[:0]
+
+	err = repositoryConfigure.PotofolioRepository.ReorderPotofolios([]int64{potofolioId})
+	assert.Nil(t, err)
+	assert.Equal(t, changes, []ContentChange{{Content: PotofolioContent, Id: AllContentIds}})
+
+	changes = changes[:0]
+
+	tagId, err := repositoryConfigure.TagRepository.AddTag("sculpture")
+	assert.Nil(t, err)
+
+	err = repositoryConfigure.TagRepository.UpdateTag(tagId, "stone")
+	assert.Nil(t, err)
+	assert.Equal(t, changes, []ContentChange{{Content: TagContent}, {Content: TagContent, Id: tagId}})
+}
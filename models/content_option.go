@@ -0,0 +1,48 @@
+package models
+
+// essay, potofolio 를 추가/수정할 때 본문 외의 설정
+// 값이 없는 항목은 추가할 때 기본값을 사용하고, 수정할 때는 바꾸지 않는다.
+type ContentOption struct {
+	Publish *PublishOption
+
+	// 없으면 추가할 때 title 로 만든다.
+	Slug *string
+
+	// nil 이면 바꾸지 않는다. (빈 목록이면 tag 를 모두 뺀다.)
+	Tags []string
+
+	// 첫 화면 (featured) 에 보여줄지 (없으면 추가할 때 false)
+	Featured *bool
+}
+
+func (option *ContentOption) slugOrNil() *string {
+	if option == nil {
+		return nil
+	}
+
+	return option.Slug
+}
+
+func (option *ContentOption) tagsOrNil() []string {
+	if option == nil {
+		return nil
+	}
+
+	return option.Tags
+}
+
+func (option *ContentOption) featuredOrDefault() bool {
+	if option == nil || option.Featured == nil {
+		return false
+	}
+
+	return *option.Featured
+}
+
+func (option *ContentOption) publishOrDefault() *PublishOption {
+	if option == nil || option.Publish == nil {
+		return &PublishOption{Status: PublishStatusPublished}
+	}
+
+	return option.Publish
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// 공개 조회 응답에 들어가는 table
+// 이 table 이 바뀌면 content revision 이 올라간다.
+var contentRevisionTables = []string{
+	"potofolio",
+	"essay",
+	"images",
+	"tag",
+	"tag_link",
+	"collection",
+	"collection_item",
+	"about",
+	"about_history",
+	"about_history_category",
+}
+
+// 공개 content 전체의 변경 번호 (조회 캐시의 ETag, Last-Modified)
+type ContentRevisionModel struct {
+	Revision  int64
+	UpdatedAt time.Time
+}
+
+type ContentRevisionRepository struct {
+	DBConnect *DBConnection
+}
+
+// contentRevisionTables 가 모두 만들어진 뒤에 호출해야 한다.
+func (repo *ContentRevisionRepository) CreateTable() error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	createQuery := `
+		CREATE TABLE IF NOT EXISTS "content_revision" (
+			"id"	INTEGER NOT NULL UNIQUE,
+			"revision"	INTEGER NOT NULL DEFAULT 0,
+			"updatedAt"	INTEGER NOT NULL,
+			PRIMARY KEY("id")
+		)
+	`
+
+	_, err = db.Exec(createQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("INSERT OR IGNORE INTO content_revision (id, revision, updatedAt) VALUES (1, 0, $1)", time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	// trigger 로 올리기 때문에 repository 를 거치지 않는 변경 (예약 공개, 휴지통 비우기) 도 반영된다.
+	for _, table := range contentRevisionTables {
+		for _, event := range []string{"insert", "update", "delete"} {
+
+			triggerQuery := fmt.Sprintf(`
+			CREATE TRIGGER IF NOT EXISTS "%[1]s_content_revision_%[2]s"
+			AFTER %[2]s ON "%[1]s"
+			BEGIN
+				UPDATE content_revision SET revision = revision + 1, updatedAt = CAST(strftime('%%s', 'now') AS INTEGER) WHERE id = 1;
+			END`, table, event)
+
+			_, err = db.Exec(triggerQuery)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (repo *ContentRevisionRepository) GetContentRevision() (*ContentRevisionModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var revision int64
+	var updatedAt int64
+	err = db.QueryRow("SELECT revision, updatedAt FROM content_revision WHERE id = 1").Scan(&revision, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContentRevisionModel{Revision: revision, UpdatedAt: time.Unix(updatedAt, 0)}, nil
+}
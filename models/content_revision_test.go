@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentRevision(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:content_revision_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	revisionRepo := repositoryConfigure.ContentRevisionRepository
+
+	revision, err := revisionRepo.GetContentRevision()
+	assert.Nil(t, err)
+
+	// 조회는 revision 을 올리지 않는다.
+	potofolioId, err := repositoryConfigure.PotofolioRepository.AddPotofolio("stone", nil)
+	assert.Nil(t, err)
+
+	added, err := revisionRepo.GetContentRevision()
+	assert.Nil(t, err)
+	assert.Greater(t, added.Revision, revision.Revision)
+
+	_, err = repositoryConfigure.PotofolioRepository.FindPotofolio(potofolioId)
+	assert.Nil(t, err)
+
+	found, err := revisionRepo.GetContentRevision()
+	assert.Nil(t, err)
+	assert.Equal(t, found.Revision, added.Revision)
+
+	// tag 이름만 바뀌어도 올라간다.
+	tagId, err := repositoryConfigure.TagRepository.AddTag("sculpture")
+	assert.Nil(t, err)
+
+	tagged, err := revisionRepo.GetContentRevision()
+	assert.Nil(t, err)
+
+	err = repositoryConfigure.TagRepository.UpdateTag(tagId, "stone")
+	assert.Nil(t, err)
+
+	renamed, err := revisionRepo.GetContentRevision()
+	assert.Nil(t, err)
+	assert.Greater(t, renamed.Revision, tagged.Revision)
+}
@@ -3,14 +3,73 @@ package models
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"errors"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// sqlite connection 설정
+// 모든 connection 에 동일하게 적용되도록 dataSourceName 의 parameter 로 전달한다.
+type DBOptions struct {
+	JournalMode  string // DELETE, WAL, MEMORY ...
+	BusyTimeout  int    // millisecond
+	ForeignKeys  bool
+	Synchronous  string // OFF, NORMAL, FULL, EXTRA
+	MaxOpenConns int    // 0 이면 제한 없음
+}
+
+// 운영 환경 기본 설정
+// 방문자 조회 중에 관리자 수정이 들어와도 'database is locked' 가 나지 않도록 WAL + busy timeout 을 사용한다.
+// repository 들이 transaction 진행 중에 다른 connection 으로 조회하는 경우가 있으므로 MaxOpenConns 는 1 로 두면 안된다.
+func DefaultDBOptions() DBOptions {
+	return DBOptions{
+		JournalMode:  "WAL",
+		BusyTimeout:  5000,
+		ForeignKeys:  true,
+		Synchronous:  "NORMAL",
+		MaxOpenConns: 8,
+	}
+}
+
+func (options *DBOptions) applyDataSourceName(dataSourceName string) string {
+
+	params := url.Values{}
+
+	if len(options.JournalMode) > 0 {
+		params.Set("_journal_mode", options.JournalMode)
+	}
+
+	if options.BusyTimeout > 0 {
+		params.Set("_busy_timeout", fmt.Sprintf("%d", options.BusyTimeout))
+	}
+
+	if options.ForeignKeys {
+		params.Set("_foreign_keys", "1")
+	}
+
+	if len(options.Synchronous) > 0 {
+		params.Set("_synchronous", options.Synchronous)
+	}
+
+	if len(params) == 0 {
+		return dataSourceName
+	}
+
+	separator := "?"
+	if strings.Contains(dataSourceName, "?") {
+		separator = "&"
+	}
+
+	return dataSourceName + separator + params.Encode()
+}
+
 type DBConnection struct {
 	db *sql.DB
+
+	changeNotifier contentChangeNotifier
 }
 
 func (connect *DBConnection) Open(dataSourceName string) error {
@@ -25,6 +84,29 @@ func (connect *DBConnection) Open(dataSourceName string) error {
 	return nil
 }
 
+func (connect *DBConnection) OpenWithOptions(dataSourceName string, options DBOptions) error {
+
+	err := connect.Open(options.applyDataSourceName(dataSourceName))
+	if err != nil {
+		return err
+	}
+
+	if options.MaxOpenConns > 0 {
+		connect.db.SetMaxOpenConns(options.MaxOpenConns)
+		connect.db.SetMaxIdleConns(options.MaxOpenConns)
+	}
+
+	// sql.Open 은 실제 접속을 하지 않으므로 여기서 pragma 적용 여부까지 확인한다.
+	err = connect.db.Ping()
+	if err != nil {
+		connect.db.Close()
+		connect.db = nil
+		return err
+	}
+
+	return nil
+}
+
 func (connect *DBConnection) GetDB() (*sql.DB, error) {
 
 	if connect.db == nil {
@@ -43,6 +125,96 @@ func (connect *DBConnection) Close() {
 	connect.db.Close()
 }
 
+// PRAGMA integrity_check 결과
+// 문제가 없으면 빈 slice 를 반환한다.
+func (connect *DBConnection) IntegrityCheck() ([]string, error) {
+
+	db, err := connect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	problems := make([]string, 0)
+	for rows.Next() {
+		var message string
+		err = rows.Scan(&message)
+		if err != nil {
+			return nil, err
+		}
+
+		if message == "ok" {
+			continue
+		}
+
+		problems = append(problems, message)
+	}
+
+	return problems, rows.Err()
+}
+
+func (connect *DBConnection) Vacuum() error {
+
+	db, err := connect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("VACUUM")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 기존 db 파일에 없는 column 을 추가한다. (CREATE TABLE IF NOT EXISTS 로는 column 이 추가되지 않는다.)
+// 추가되었으면 true 를 반환하므로 호출하는 쪽에서 기존 row 를 채울 수 있다.
+func addColumnIfNotExists(db *sql.DB, table string, column string, definition string) (bool, error) {
+
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(\"%s\")", table))
+	if err != nil {
+		return false, err
+	}
+
+	exists := false
+	for rows.Next() {
+		var cid int
+		var name string
+		var columnType string
+		var notNull int
+		var defaultValue interface{}
+		var primaryKey int
+		err = rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey)
+		if err != nil {
+			rows.Close()
+			return false, err
+		}
+
+		if name == column {
+			exists = true
+		}
+	}
+	rows.Close()
+
+	if exists {
+		return false, nil
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE \"%s\" ADD COLUMN \"%s\" %s", table, column, definition))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func CloseTranstion(tx *sql.Tx, completed *bool) {
 
 	var err error
@@ -55,4 +227,4 @@ func CloseTranstion(tx *sql.Tx, completed *bool) {
 	if err != nil {
 		fmt.Println(err)
 	}
-}
\ No newline at end of file
+}
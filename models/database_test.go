@@ -0,0 +1,69 @@
+package models
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenWithOptions(t *testing.T) {
+
+	dataSourcePath := filepath.Join(t.TempDir(), "options.db")
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.OpenWithOptions(dataSourcePath, DefaultDBOptions())
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	db, err := dbConnection.GetDB()
+	assert.Nil(t, err)
+
+	var journalMode string
+	err = db.QueryRow("PRAGMA journal_mode").Scan(&journalMode)
+	assert.Nil(t, err)
+	assert.Equal(t, journalMode, "wal")
+
+	var busyTimeout int
+	err = db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout)
+	assert.Nil(t, err)
+	assert.Equal(t, busyTimeout, 5000)
+
+	var foreignKeys int
+	err = db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys)
+	assert.Nil(t, err)
+	assert.Equal(t, foreignKeys, 1)
+
+	var synchronous int
+	err = db.QueryRow("PRAGMA synchronous").Scan(&synchronous)
+	assert.Nil(t, err)
+	assert.Equal(t, synchronous, 1)
+
+	assert.Equal(t, db.Stats().MaxOpenConnections, 8)
+}
+
+func TestIntegrityCheckAndVacuum(t *testing.T) {
+
+	dataSourcePath := filepath.Join(t.TempDir(), "check.db")
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.OpenWithOptions(dataSourcePath, DefaultDBOptions())
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	essayRepo := &EssayRepository{DBConnect: dbConnection, ImageRepo: &ImageRepository{DBConnect: dbConnection}}
+	essayRepo.ImageRepo.CreateTable()
+	essayRepo.CreateTable()
+
+	_, err = essayRepo.AddEssay("essay", "thumbnail", "content", []string{"image1"})
+	assert.Nil(t, err)
+
+	problems, err := dbConnection.IntegrityCheck()
+	assert.Nil(t, err)
+	assert.Equal(t, len(problems), 0)
+
+	err = dbConnection.Vacuum()
+	assert.Nil(t, err)
+}
@@ -1,22 +1,51 @@
 package models
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/thoas/go-funk"
 )
 
+type EssayNotFoundError struct {
+	Id int64
+}
+
+func (e *EssayNotFoundError) Error() string {
+	return fmt.Sprintf("essay not found [id:%v]", e.Id)
+}
+
 type EssayThumnailModel struct {
-	Id             int64  `json:"id"`
-	Title          string `json:"title"`
-	ThumbnailImage string `json:"thumbnail"`
+	Id             int64         `json:"id"`
+	Slug           string        `json:"slug"`
+	Title          string        `json:"title"`
+	ThumbnailImage string        `json:"thumbnail"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+	PublishedAt    *time.Time    `json:"publishedAt"`
+	Status         PublishStatus `json:"status"`
+	PublishAt      *time.Time    `json:"publishAt"`
+	Tags           []TagModel    `json:"tags"`
+	Featured       bool          `json:"featured"`
 }
 
 type EssayModel struct {
-	Id             int64        `json:"id"`
-	Title          string       `json:"title"`
-	ThumbnailImage string       `json:"thumbnail"`
-	Images         []ImageModel `json:"images"`
-	EssayContent   string       `json:"essayContent"`
+	Id             int64         `json:"id"`
+	Slug           string        `json:"slug"`
+	Title          string        `json:"title"`
+	ThumbnailImage string        `json:"thumbnail"`
+	Images         []ImageModel  `json:"images"`
+	EssayContent   string        `json:"essayContent"`
+	Version        int64         `json:"version"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+	PublishedAt    *time.Time    `json:"publishedAt"`
+	Status         PublishStatus `json:"status"`
+	PublishAt      *time.Time    `json:"publishAt"`
+	Tags           []TagModel    `json:"tags"`
+	Featured       bool          `json:"featured"`
 }
 
 func (essayModel *EssayModel) GetThumbnailImagePath(saveDir string, prefixUri string) string {
@@ -49,19 +78,88 @@ func (repo *EssayRepository) CreateTable() error {
 		return err
 	}
 
+	// 휴지통 (NULL 이면 삭제되지 않은 상태)
+	_, err = addColumnIfNotExists(db, "essay", "deletedAt", "INTEGER")
+	if err != nil {
+		return err
+	}
+
+	// 동시 수정 확인용 (수정될 때 마다 1 씩 증가)
+	_, err = addColumnIfNotExists(db, "essay", "version", "INTEGER NOT NULL DEFAULT 1")
+	if err != nil {
+		return err
+	}
+
+	err = repo.createRevisionTable(db)
+	if err != nil {
+		return err
+	}
+
+	// 기존 essay 는 revision 이 있으면 revision 시간으로 채운다.
+	backfillQuery := `
+		UPDATE essay SET
+			createdAt = (SELECT MIN(createdAt) FROM essay_revision WHERE essayId = essay.id),
+			updatedAt = (SELECT MAX(createdAt) FROM essay_revision WHERE essayId = essay.id)
+		WHERE createdAt IS NULL`
+
+	err = addTimestampColumns(db, "essay", backfillQuery)
+	if err != nil {
+		return err
+	}
+
+	err = addPublishColumns(db, "essay")
+	if err != nil {
+		return err
+	}
+
+	err = addSlugColumn(db, "essay", EssayType)
+	if err != nil {
+		return err
+	}
+
+	err = addTagLinks(db, "essay", EssayType)
+	if err != nil {
+		return err
+	}
+
+	err = addFeaturedColumn(db, "essay")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (repo *EssayRepository) GetEssayList() ([]EssayThumnailModel, error) {
+	return repo.GetEssayListOrderBy(DefaultListSortOption())
+}
+
+func (repo *EssayRepository) GetEssayListOrderBy(sortOption ListSortOption) ([]EssayThumnailModel, error) {
+	essaies, _, err := repo.GetEssayPage(ListPageOption{Sort: sortOption})
+	return essaies, err
+}
+
+// pageOption.Limit 만큼 가져온다. 다음 page 가 있으면 다음 page 의 cursor 를 같이 반환한다.
+func (repo *EssayRepository) GetEssayPage(pageOption ListPageOption) ([]EssayThumnailModel, string, error) {
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// 직접 정한 순서는 potofolio 만 있다.
+	if pageOption.Sort.Key == SortByPosition {
+		return nil, "", fmt.Errorf("essay can not be sorted by %s", SortByPosition)
 	}
 
-	essayRows, err := db.Query("SELECT id, title, thumbImage FROM essay")
+	selectQuery, args, err := pageOption.buildListQuery("id, COALESCE(slug, ''), title, thumbImage, createdAt, updatedAt, publishedAt, status, publishAt, featured", "essay", EssayType)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	essayRows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, "", err
 	}
 
 	defer essayRows.Close()
@@ -72,17 +170,57 @@ func (repo *EssayRepository) GetEssayList() ([]EssayThumnailModel, error) {
 		var id int64
 		var title string
 		var thumbnailImage string
-		err = essayRows.Scan(&id, &title, &thumbnailImage)
+		var createdAt int64
+		var updatedAt int64
+		var publishedAt sql.NullInt64
+		var status string
+		var publishAt sql.NullInt64
+		var slug string
+		var featured bool
+		err = essayRows.Scan(&id, &slug, &title, &thumbnailImage, &createdAt, &updatedAt, &publishedAt, &status, &publishAt, &featured)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
-		essay := EssayThumnailModel{Id: id, Title: title, ThumbnailImage: thumbnailImage}
+		essay := EssayThumnailModel{
+			Id:             id,
+			Slug:           slug,
+			Title:          title,
+			ThumbnailImage: thumbnailImage,
+			CreatedAt:      time.Unix(createdAt, 0),
+			UpdatedAt:      time.Unix(updatedAt, 0),
+			PublishedAt:    unixToTimePtr(publishedAt),
+			Status:         PublishStatus(status),
+			PublishAt:      unixToTimePtr(publishAt),
+			Featured:       featured,
+		}
 		essaies = append(essaies, essay)
 
 	}
 
-	return essaies, nil
+	nextCursor := ""
+	if pageOption.Limit > 0 && len(essaies) > pageOption.Limit {
+		essaies = essaies[:pageOption.Limit]
+
+		last := essaies[len(essaies)-1]
+		nextCursor = encodeListCursor(pageOption.Sort, last.Id, last.Title, last.CreatedAt, last.UpdatedAt, last.PublishedAt, 0)
+	}
+
+	essayIds := make([]int64, 0, len(essaies))
+	for _, essay := range essaies {
+		essayIds = append(essayIds, essay.Id)
+	}
+
+	tagsById, err := getTagsByDependencyIds(db, EssayType, essayIds)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i := range essaies {
+		essaies[i].Tags = tagsById[essaies[i].Id]
+	}
+
+	return essaies, nextCursor, nil
 }
 
 func (repo *EssayRepository) FindEssay(essayId int64) (*EssayModel, error) {
@@ -92,7 +230,7 @@ func (repo *EssayRepository) FindEssay(essayId int64) (*EssayModel, error) {
 		return nil, err
 	}
 
-	findQuery := "SELECT id, title, thumbImage, essayContent FROM essay WHERE id = $1"
+	findQuery := "SELECT id, COALESCE(slug, ''), title, thumbImage, essayContent, version, createdAt, updatedAt, publishedAt, status, publishAt, featured FROM essay WHERE id = $1 AND deletedAt IS NULL"
 	essayRow, err := db.Query(findQuery, essayId)
 	if err != nil {
 		return nil, err
@@ -100,19 +238,40 @@ func (repo *EssayRepository) FindEssay(essayId int64) (*EssayModel, error) {
 
 	defer essayRow.Close()
 	if !essayRow.Next() {
-		return nil, fmt.Errorf("essay not found [id:%v]", essayId)
+		return nil, &EssayNotFoundError{Id: essayId}
 	}
 
 	var id int64
 	var title string
 	var thumbnail string
 	var essayContent string
-	err = essayRow.Scan(&id, &title, &thumbnail, &essayContent)
+	var version int64
+	var createdAt int64
+	var updatedAt int64
+	var publishedAt sql.NullInt64
+	var status string
+	var publishAt sql.NullInt64
+	var slug string
+	var featured bool
+	err = essayRow.Scan(&id, &slug, &title, &thumbnail, &essayContent, &version, &createdAt, &updatedAt, &publishedAt, &status, &publishAt, &featured)
 	if err != nil {
 		return nil, err
 	}
 
-	essayModel := EssayModel{Id: id, Title: title, ThumbnailImage: thumbnail, EssayContent: essayContent}
+	essayModel := EssayModel{
+		Id:             id,
+		Slug:           slug,
+		Title:          title,
+		ThumbnailImage: thumbnail,
+		EssayContent:   essayContent,
+		Version:        version,
+		CreatedAt:      time.Unix(createdAt, 0),
+		UpdatedAt:      time.Unix(updatedAt, 0),
+		PublishedAt:    unixToTimePtr(publishedAt),
+		Status:         PublishStatus(status),
+		PublishAt:      unixToTimePtr(publishAt),
+		Featured:       featured,
+	}
 	images, err := repo.ImageRepo.GetImages(EssayType, id)
 	if err != nil {
 		return nil, err
@@ -120,10 +279,27 @@ func (repo *EssayRepository) FindEssay(essayId int64) (*EssayModel, error) {
 
 	essayModel.Images = images
 
+	tagsById, err := getTagsByDependencyIds(db, EssayType, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	essayModel.Tags = tagsById[id]
+
 	return &essayModel, nil
 }
 
 func (repo *EssayRepository) AddEssay(title string, thumbnailPath string, essayContent string, images []string) (int64, error) {
+	return repo.AddEssayBy("", title, thumbnailPath, essayContent, images)
+}
+
+// author 는 revision 에 남는 작성자
+func (repo *EssayRepository) AddEssayBy(author string, title string, thumbnailPath string, essayContent string, images []string) (int64, error) {
+	return repo.AddEssayWithOption(author, nil, title, thumbnailPath, essayContent, images)
+}
+
+// contentOption 의 공개 상태, slug, tag, featured 로 추가한다.
+func (repo *EssayRepository) AddEssayWithOption(author string, contentOption *ContentOption, title string, thumbnailPath string, essayContent string, images []string) (int64, error) {
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
@@ -136,10 +312,12 @@ func (repo *EssayRepository) AddEssay(title string, thumbnailPath string, essayC
 	}
 
 	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: EssayContent})
 	defer CloseTranstion(transaction, &completed)
 
-	insertQuery := "INSERT INTO essay (title, thumbImage, essayContent) VALUES ($1, $2, $3)"
-	insertResult, err := transaction.Exec(insertQuery, title, thumbnailPath, essayContent)
+	now := time.Now().Unix()
+	insertQuery := "INSERT INTO essay (title, thumbImage, essayContent, createdAt, updatedAt) VALUES ($1, $2, $3, $4, $5)"
+	insertResult, err := transaction.Exec(insertQuery, title, thumbnailPath, essayContent, now, now)
 	if err != nil {
 		return 0, err
 	}
@@ -149,20 +327,56 @@ func (repo *EssayRepository) AddEssay(title string, thumbnailPath string, essayC
 		return 0, err
 	}
 
+	err = setPublishTransaction(transaction, "essay", insertId, contentOption.publishOrDefault())
+	if err != nil {
+		return 0, err
+	}
+
+	err = assignSlugTransaction(transaction, "essay", EssayType, insertId, title, contentOption.slugOrNil())
+	if err != nil {
+		return 0, err
+	}
+
+	err = setTagsTransaction(transaction, EssayType, insertId, contentOption.tagsOrNil())
+	if err != nil {
+		return 0, err
+	}
+
+	err = setFeaturedTransaction(transaction, "essay", insertId, contentOption.featuredOrDefault())
+	if err != nil {
+		return 0, err
+	}
+
 	err = repo.ImageRepo.AddImgesTransaction(transaction, EssayType, insertId, images)
 	if err != nil {
 		return 0, err
 	}
 
+	err = repo.addRevisionTransaction(transaction, insertId, author, nil)
+	if err != nil {
+		return 0, err
+	}
+
 	completed = true
 
 	return insertId, nil
 }
 
 func (repo *EssayRepository) UpdateEssay(essayId int64, title *string, thumbnailPath *string, essayContent *string, removeImageIds []int64, addIamge []string) ([]string, error) {
+	return repo.UpdateEssayBy("", essayId, nil, title, thumbnailPath, essayContent, removeImageIds, addIamge)
+}
+
+// 수정 결과를 author 의 revision 으로 남긴다.
+// expectedVersion 이 있으면 현재 version 과 같을 때만 수정한다. (다르면 VersionConflictError)
+// 반환되는 image 경로는 essay 에서 빠진 것일 뿐, 이전 revision 이 참조하므로 파일은 영구 삭제 전까지 남겨둔다.
+func (repo *EssayRepository) UpdateEssayBy(author string, essayId int64, expectedVersion *int64, title *string, thumbnailPath *string, essayContent *string, removeImageIds []int64, addIamge []string) ([]string, error) {
+	return repo.UpdateEssayWithOption(author, essayId, expectedVersion, nil, title, thumbnailPath, essayContent, removeImageIds, addIamge)
+}
+
+// contentOption 이 있으면 공개 상태, slug, tag, featured 도 같이 바꾼다.
+func (repo *EssayRepository) UpdateEssayWithOption(author string, essayId int64, expectedVersion *int64, contentOption *ContentOption, title *string, thumbnailPath *string, essayContent *string, removeImageIds []int64, addIamge []string) ([]string, error) {
 
 	completed := false
-	removeImagePaths := make([]string, 0)
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
@@ -174,39 +388,106 @@ func (repo *EssayRepository) UpdateEssay(essayId int64, title *string, thumbnail
 		return nil, err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: EssayContent, Id: essayId})
 	defer CloseTranstion(transaction, &completed)
 
-	updateColumns := ""
-	if title != nil {
-		updateColumns = fmt.Sprintf("title = \"%v\"", *title)
+	removeImagePaths, err := repo.updateEssayTransaction(transaction, author, essayId, expectedVersion, contentOption, title, thumbnailPath, essayContent, removeImageIds, addIamge)
+	if err != nil {
+		return nil, err
 	}
 
-	if thumbnailPath != nil {
-		if len(updateColumns) > 0 {
-			updateColumns += ", "
+	completed = true
+
+	return removeImagePaths, nil
+}
+
+// 여러 수정을 한 transaction 으로 처리할 때 (bulk) 사용한다.
+func (repo *EssayRepository) updateEssayTransaction(tx *sql.Tx, author string, essayId int64, expectedVersion *int64, contentOption *ContentOption, title *string, thumbnailPath *string, essayContent *string, removeImageIds []int64, addIamge []string) ([]string, error) {
+
+	removeImagePaths := make([]string, 0)
+
+	err := bumpVersionTransaction(tx, "essay", EssayType, essayId, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	err = touchUpdatedAtTransaction(tx, "essay", essayId)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentOption != nil && contentOption.Publish != nil {
+		err = setPublishTransaction(tx, "essay", essayId, contentOption.Publish)
+		if err != nil {
+			return nil, err
 		}
-		updateColumns += fmt.Sprintf("thumbImage = \"%v\"", *thumbnailPath)
 	}
 
-	if essayContent != nil {
-		if len(updateColumns) > 0 {
-			updateColumns += ", "
+	if contentOption != nil && contentOption.Slug != nil {
+		err = assignSlugTransaction(tx, "essay", EssayType, essayId, "", contentOption.Slug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentOption != nil && contentOption.Tags != nil {
+		err = setTagsTransaction(tx, EssayType, essayId, contentOption.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentOption != nil && contentOption.Featured != nil {
+		err = setFeaturedTransaction(tx, "essay", essayId, *contentOption.Featured)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// revision 기능 이전에 만들어진 essay 는 수정 전 내용을 먼저 남긴다.
+	hasRevision, err := repo.hasRevisionTransaction(tx, essayId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasRevision {
+		err = repo.addRevisionTransaction(tx, essayId, "", nil)
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	setClauses := make([]string, 0)
+	args := make([]interface{}, 0)
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if title != nil {
+		set("title", *title)
+	}
+
+	if thumbnailPath != nil {
+		set("thumbImage", *thumbnailPath)
+	}
 
-		updateColumns += fmt.Sprintf("essayContent = \"%v\"", *essayContent)
+	if essayContent != nil {
+		set("essayContent", *essayContent)
 	}
 
-	if len(updateColumns) > 0 {
-		updateColumns = fmt.Sprintf("UPDATE essay SET %v WHERE id = $1", updateColumns)
+	if len(setClauses) > 0 {
+		args = append(args, essayId)
+		essayUpdateQuery := fmt.Sprintf("UPDATE essay SET %s WHERE id = $%d", strings.Join(setClauses, ", "), len(args))
 
-		_, err = db.Exec(updateColumns, essayId)
+		_, err = tx.Exec(essayUpdateQuery, args...)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if removeImageIds != nil {
-		essayImages, err := repo.ImageRepo.GetImages(EssayType, essayId)
+		essayImages, err := repo.ImageRepo.GetImagesTransaction(tx, EssayType, essayId)
 		if err != nil {
 			return nil, err
 		}
@@ -224,7 +505,7 @@ func (repo *EssayRepository) UpdateEssay(essayId int64, title *string, thumbnail
 				continue
 			}
 
-			err = repo.ImageRepo.RemoveImageIdTransaction(transaction, EssayType, essayId, essayImage.Id)
+			err = repo.ImageRepo.RemoveImageIdTransaction(tx, EssayType, essayId, essayImage.Id)
 			if err != nil {
 				return nil, err
 			}
@@ -234,21 +515,24 @@ func (repo *EssayRepository) UpdateEssay(essayId int64, title *string, thumbnail
 	}
 
 	if addIamge != nil {
-		err = repo.ImageRepo.AddImgesTransaction(transaction, EssayType, essayId, addIamge)
+		err = repo.ImageRepo.AddImgesTransaction(tx, EssayType, essayId, addIamge)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if removeImageIds != nil || addIamge != nil {
-		err = repo.ImageRepo.SortImageOrderTransation(transaction, EssayType, essayId)
+		err = repo.ImageRepo.SortImageOrderTransation(tx, EssayType, essayId)
 
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	completed = true
+	err = repo.addRevisionTransaction(tx, essayId, author, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return removeImagePaths, nil
 }
@@ -267,6 +551,7 @@ func (repo *EssayRepository) RemoveEssay(essayId int64) error {
 		return err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: EssayContent, Id: essayId})
 	defer CloseTranstion(transaction, &completed)
 
 	essayDeleteQuery := "DELETE FROM essay WHERE id = $1"
@@ -280,7 +565,176 @@ func (repo *EssayRepository) RemoveEssay(essayId int64) error {
 		return err
 	}
 
+	_, err = transaction.Exec("DELETE FROM essay_revision WHERE essayId = $1", essayId)
+	if err != nil {
+		return err
+	}
+
 	completed = true
 
 	return nil
 }
+
+// 휴지통으로 보낸다. image 파일은 영구 삭제 전까지 남겨둔다.
+func (repo *EssayRepository) TrashEssay(essayId int64, expectedVersion *int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	err = trashRow(db, "essay", EssayType, essayId, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	repo.DBConnect.notifyContentChanges(ContentChange{Content: EssayContent, Id: essayId})
+
+	return nil
+}
+
+func (repo *EssayRepository) RestoreEssay(essayId int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	err = restoreRow(db, "essay", EssayType, essayId)
+	if err != nil {
+		return err
+	}
+
+	repo.DBConnect.notifyContentChanges(ContentChange{Content: EssayContent, Id: essayId})
+
+	return nil
+}
+
+func (repo *EssayRepository) GetTrashedEssayList() ([]TrashModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return getTrashList(db, "essay", "title", EssayType)
+}
+
+// deletedBefore 이전에 휴지통으로 간 essay 를 영구 삭제하고, 지워야 할 image 경로 (thumbnail, revision 포함) 를 반환한다.
+func (repo *EssayRepository) PurgeTrashedEssays(deletedBefore time.Time) ([]string, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	essayIds, err := getExpiredTrashIds(db, "essay", deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	removeImagePaths := make([]string, 0)
+	if len(essayIds) == 0 {
+		return removeImagePaths, nil
+	}
+
+	// transaction 시작 전에 image 경로를 모아둔다.
+	for _, essayId := range essayIds {
+		var thumbnail sql.NullString
+		err = db.QueryRow("SELECT thumbImage FROM essay WHERE id = $1", essayId).Scan(&thumbnail)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(thumbnail.String) > 0 {
+			removeImagePaths = append(removeImagePaths, thumbnail.String)
+		}
+
+		images, err := repo.ImageRepo.GetImages(EssayType, essayId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range images {
+			removeImagePaths = append(removeImagePaths, image.Path)
+		}
+
+		revisionImagePaths, err := repo.getRevisionImagePaths(essayId)
+		if err != nil {
+			return nil, err
+		}
+
+		removeImagePaths = append(removeImagePaths, revisionImagePaths...)
+	}
+
+	removeImagePaths = funk.UniqString(removeImagePaths)
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, repositoryContentChanges(EssayType, essayIds)...)
+	defer CloseTranstion(transaction, &completed)
+
+	for _, essayId := range essayIds {
+		_, err = transaction.Exec("DELETE FROM essay WHERE id = $1", essayId)
+		if err != nil {
+			return nil, err
+		}
+
+		err = repo.ImageRepo.RemoveImagesTransaction(transaction, EssayType, essayId)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = transaction.Exec("DELETE FROM essay_revision WHERE essayId = $1", essayId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completed = true
+
+	return removeImagePaths, nil
+}
+
+// 예약 시간이 지난 essay 를 공개한다.
+func (repo *EssayRepository) PublishDueEssays(now time.Time) ([]int64, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	essayIds, err := publishDueRows(db, "essay", EssayType, now)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.DBConnect.notifyContentChanges(repositoryContentChanges(EssayType, essayIds)...)
+
+	return essayIds, nil
+}
+
+func (repo *EssayRepository) GetPublishLogs(essayId int64) ([]PublishLogModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return getPublishLogs(db, EssayType, essayId)
+}
+
+// slug 로 essay id 를 찾는다. 바뀌기 전 slug 이면 redirected 가 true 이다.
+func (repo *EssayRepository) ResolveEssaySlug(slug string) (int64, bool, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return resolveSlug(db, "essay", EssayType, slug)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -217,3 +218,69 @@ func TestRemoveEssay(t *testing.T) {
 
 	assert.Nil(t, findEssay)
 }
+
+func TestTrashEssay(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:essay_trash_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repo := &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = repo.CreateTable()
+	assert.Nil(t, err)
+
+	essayId, err := repo.AddEssay("trash essay", "trash thumbnail", "trash content", []string{"trash image1", "trash image2"})
+	assert.Nil(t, err)
+
+	err = repo.TrashEssay(essayId, nil)
+	assert.Nil(t, err)
+
+	// 휴지통에 있는 essay 는 목록/조회에서 제외된다.
+	essies, err := repo.GetEssayList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(essies), 0)
+
+	_, err = repo.FindEssay(essayId)
+	assert.NotNil(t, err)
+
+	trashModels, err := repo.GetTrashedEssayList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(trashModels), 1)
+	assert.Equal(t, trashModels[0].Type, EssayType)
+	assert.Equal(t, trashModels[0].Title, "trash essay")
+
+	err = repo.RestoreEssay(essayId)
+	assert.Nil(t, err)
+
+	essay, err := repo.FindEssay(essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(essay.Images), 2)
+
+	err = repo.RestoreEssay(essayId)
+	assert.NotNil(t, err)
+
+	// 보관 기간이 지나지 않았으면 영구 삭제 되지 않는다.
+	err = repo.TrashEssay(essayId, nil)
+	assert.Nil(t, err)
+
+	removeImages, err := repo.PurgeTrashedEssays(time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, len(removeImages), 0)
+
+	removeImages, err = repo.PurgeTrashedEssays(time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, removeImages, []string{"trash thumbnail", "trash image1", "trash image2"})
+
+	trashModels, err = repo.GetTrashedEssayList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(trashModels), 0)
+
+	images, err := imageRepo.GetImages(EssayType, essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(images), 0)
+}
@@ -0,0 +1,288 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// essay 를 수정할 때 마다 남는 essay 전체 내용
+type EssayRevisionModel struct {
+	Id             int64     `json:"id"`
+	EssayId        int64     `json:"essayId"`
+	Revision       int64     `json:"revision"`
+	Title          string    `json:"title"`
+	ThumbnailImage string    `json:"thumbnail"`
+	EssayContent   string    `json:"essayContent"`
+	Images         []string  `json:"images"`
+	Author         string    `json:"author"`
+	RestoredFrom   *int64    `json:"restoredFrom"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type EssayRevisionNotFoundError struct {
+	EssayId  int64
+	Revision int64
+}
+
+func (e *EssayRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("essay revision not found [id:%v, revision:%v]", e.EssayId, e.Revision)
+}
+
+func (repo *EssayRepository) createRevisionTable(db *sql.DB) error {
+
+	createEssayRevisionTableQuery := `
+		CREATE TABLE IF NOT EXISTS "essay_revision"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"essayId" INTEGER REFERENCES "essay" ("id") ON DELETE CASCADE,
+			"revision" INTEGER,
+			"title" TEXT,
+			"thumbImage" TEXT,
+			"essayContent" TEXT,
+			"images" TEXT,
+			"author" TEXT,
+			"restoredFrom" INTEGER,
+			"createdAt" INTEGER,
+			UNIQUE ("essayId", "revision")
+		)`
+
+	_, err := db.Exec(createEssayRevisionTableQuery)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *EssayRepository) hasRevisionTransaction(tx *sql.Tx, essayId int64) (bool, error) {
+
+	var count int64
+	err := tx.QueryRow("SELECT COUNT(*) FROM essay_revision WHERE essayId = $1", essayId).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// transaction 안에서 보이는 현재 essay 내용을 새 revision 으로 남긴다.
+func (repo *EssayRepository) addRevisionTransaction(tx *sql.Tx, essayId int64, author string, restoredFrom *int64) error {
+
+	var title string
+	var thumbnail string
+	var essayContent string
+	err := tx.QueryRow("SELECT title, thumbImage, essayContent FROM essay WHERE id = $1", essayId).Scan(&title, &thumbnail, &essayContent)
+	if err != nil {
+		return err
+	}
+
+	images, err := repo.ImageRepo.GetImagesTransaction(tx, EssayType, essayId)
+	if err != nil {
+		return err
+	}
+
+	imagePaths := make([]string, 0)
+	for _, image := range images {
+		imagePaths = append(imagePaths, image.Path)
+	}
+
+	imagesJson, err := json.Marshal(imagePaths)
+	if err != nil {
+		return err
+	}
+
+	var revision int64
+	err = tx.QueryRow("SELECT COALESCE(MAX(revision), 0) + 1 FROM essay_revision WHERE essayId = $1", essayId).Scan(&revision)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO essay_revision (essayId, revision, title, thumbImage, essayContent, images, author, restoredFrom, createdAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = tx.Exec(insertQuery, essayId, revision, title, thumbnail, essayContent, string(imagesJson), author, restoredFrom, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanEssayRevision(rows *sql.Rows) (*EssayRevisionModel, error) {
+
+	revisionModel := EssayRevisionModel{}
+
+	var imagesJson string
+	var author sql.NullString
+	var restoredFrom sql.NullInt64
+	var createdAt int64
+	err := rows.Scan(&revisionModel.Id, &revisionModel.EssayId, &revisionModel.Revision,
+		&revisionModel.Title, &revisionModel.ThumbnailImage, &revisionModel.EssayContent,
+		&imagesJson, &author, &restoredFrom, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal([]byte(imagesJson), &revisionModel.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	revisionModel.Author = author.String
+	if restoredFrom.Valid {
+		revisionModel.RestoredFrom = &restoredFrom.Int64
+	}
+	revisionModel.CreatedAt = time.Unix(createdAt, 0)
+
+	return &revisionModel, nil
+}
+
+// 오래된 revision 부터 반환한다.
+func (repo *EssayRepository) GetEssayRevisions(essayId int64) ([]EssayRevisionModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	selectQuery := `
+		SELECT id, essayId, revision, title, thumbImage, essayContent, images, author, restoredFrom, createdAt
+		FROM essay_revision
+		WHERE essayId = $1
+		ORDER BY revision
+	`
+
+	rows, err := db.Query(selectQuery, essayId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	revisionModels := make([]EssayRevisionModel, 0)
+	for rows.Next() {
+		revisionModel, err := scanEssayRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisionModels = append(revisionModels, *revisionModel)
+	}
+
+	return revisionModels, nil
+}
+
+func (repo *EssayRepository) FindEssayRevision(essayId int64, revision int64) (*EssayRevisionModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	selectQuery := `
+		SELECT id, essayId, revision, title, thumbImage, essayContent, images, author, restoredFrom, createdAt
+		FROM essay_revision
+		WHERE essayId = $1 AND revision = $2
+	`
+
+	rows, err := db.Query(selectQuery, essayId, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, &EssayRevisionNotFoundError{EssayId: essayId, Revision: revision}
+	}
+
+	return scanEssayRevision(rows)
+}
+
+// revision 의 내용으로 essay 를 되돌린다.
+// 되돌린 결과도 새 revision 으로 남기므로 되돌리기 자체도 되돌릴 수 있다.
+func (repo *EssayRepository) RestoreEssayRevision(essayId int64, revision int64, author string, expectedVersion *int64) error {
+
+	_, err := repo.FindEssay(essayId)
+	if err != nil {
+		return err
+	}
+
+	revisionModel, err := repo.FindEssayRevision(essayId, revision)
+	if err != nil {
+		return err
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: EssayContent, Id: essayId})
+	defer CloseTranstion(transaction, &completed)
+
+	err = bumpVersionTransaction(transaction, "essay", EssayType, essayId, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := "UPDATE essay SET title = $1, thumbImage = $2, essayContent = $3, updatedAt = $4 WHERE id = $5"
+	_, err = transaction.Exec(updateQuery, revisionModel.Title, revisionModel.ThumbnailImage, revisionModel.EssayContent, time.Now().Unix(), essayId)
+	if err != nil {
+		return err
+	}
+
+	err = repo.ImageRepo.RemoveImagesTransaction(transaction, EssayType, essayId)
+	if err != nil {
+		return err
+	}
+
+	err = repo.ImageRepo.AddImgesTransaction(transaction, EssayType, essayId, revisionModel.Images)
+	if err != nil {
+		return err
+	}
+
+	err = repo.ImageRepo.SortImageOrderTransation(transaction, EssayType, essayId)
+	if err != nil {
+		return err
+	}
+
+	err = repo.addRevisionTransaction(transaction, essayId, author, &revision)
+	if err != nil {
+		return err
+	}
+
+	completed = true
+
+	return nil
+}
+
+// revision 들이 참조하는 image 경로 (thumbnail 포함, 중복 제거)
+func (repo *EssayRepository) getRevisionImagePaths(essayId int64) ([]string, error) {
+
+	revisionModels, err := repo.GetEssayRevisions(essayId)
+	if err != nil {
+		return nil, err
+	}
+
+	imagePaths := make([]string, 0)
+	for _, revisionModel := range revisionModels {
+		if len(revisionModel.ThumbnailImage) > 0 {
+			imagePaths = append(imagePaths, revisionModel.ThumbnailImage)
+		}
+
+		imagePaths = append(imagePaths, revisionModel.Images...)
+	}
+
+	return imagePaths, nil
+}
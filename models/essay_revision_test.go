@@ -0,0 +1,106 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestRevisionEssayRepo() (*DBConnection, *EssayRepository, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:essay_revision_test?mode=memory&cache=shared")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	essayRepo := &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = essayRepo.CreateTable()
+	if err != nil {
+		dbConnection.Close()
+		return nil, nil, err
+	}
+
+	return dbConnection, essayRepo, nil
+}
+
+func TestEssayRevisions(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestRevisionEssayRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	essayId, err := repo.AddEssayBy("writer", "title1", "thumbnail1", "line1\nline2", []string{"image1", "image2"})
+	assert.Nil(t, err)
+
+	updateTitle := "title2"
+	updateContent := "line1\nline2 changed"
+	imageModel, err := repo.ImageRepo.FindImageFromPath(EssayType, essayId, "image1")
+	assert.Nil(t, err)
+
+	_, err = repo.UpdateEssayBy("editor", essayId, nil, &updateTitle, nil, &updateContent, []int64{imageModel.Id}, []string{"image3"})
+	assert.Nil(t, err)
+
+	revisions, err := repo.GetEssayRevisions(essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(revisions), 2)
+
+	assert.Equal(t, revisions[0].Revision, int64(1))
+	assert.Equal(t, revisions[0].Title, "title1")
+	assert.Equal(t, revisions[0].Author, "writer")
+	assert.Equal(t, revisions[0].Images, []string{"image1", "image2"})
+
+	assert.Equal(t, revisions[1].Revision, int64(2))
+	assert.Equal(t, revisions[1].Title, "title2")
+	assert.Equal(t, revisions[1].EssayContent, "line1\nline2 changed")
+	assert.Equal(t, revisions[1].Author, "editor")
+	assert.Equal(t, revisions[1].Images, []string{"image2", "image3"})
+
+	// 첫 revision 으로 되돌린다.
+	err = repo.RestoreEssayRevision(essayId, 1, "editor", nil)
+	assert.Nil(t, err)
+
+	essay, err := repo.FindEssay(essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, essay.Title, "title1")
+	assert.Equal(t, essay.EssayContent, "line1\nline2")
+	assert.Equal(t, len(essay.Images), 2)
+	assert.Equal(t, essay.Images[0].Path, "image1")
+	assert.Equal(t, essay.Images[1].Path, "image2")
+
+	restoredRevision, err := repo.FindEssayRevision(essayId, 3)
+	assert.Nil(t, err)
+	assert.NotNil(t, restoredRevision.RestoredFrom)
+	assert.Equal(t, *restoredRevision.RestoredFrom, int64(1))
+
+	_, err = repo.FindEssayRevision(essayId, 10)
+	assert.IsType(t, &EssayRevisionNotFoundError{}, err)
+}
+
+func TestEssayRevisionBeforeRevisionTable(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestRevisionEssayRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	// revision 기능 이전에 만들어진 essay
+	db, _ := dbConnection.GetDB()
+	result, err := db.Exec("INSERT INTO essay (title, thumbImage, essayContent) VALUES ('old title', 'old thumbnail', 'old content')")
+	assert.Nil(t, err)
+
+	essayId, _ := result.LastInsertId()
+
+	updateContent := "new content"
+	_, err = repo.UpdateEssay(essayId, nil, nil, &updateContent, nil, nil)
+	assert.Nil(t, err)
+
+	revisions, err := repo.GetEssayRevisions(essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(revisions), 2)
+	assert.Equal(t, revisions[0].EssayContent, "old content")
+	assert.Equal(t, revisions[1].EssayContent, "new content")
+}
@@ -13,10 +13,64 @@ type ImageModel struct {
 	Path string `json:"path"`
 }
 
+// 소유 content 가 없는 image row
+type OrphanImageModel struct {
+	Id             int64          `json:"id"`
+	DependencyType RepositoryType `json:"dependency_type"`
+	DependencyId   int64          `json:"dependency_id"`
+	Path           string         `json:"path"`
+}
+
 type ImageRepository struct {
 	DBConnect *DBConnection
 }
 
+// images 의 소유 관계를 db 에서 강제한다.
+// 소유 table 의 row 가 지워지면 image row 도 같이 지워지고,
+// 존재하지 않는 content 를 가리키는 image row 는 추가/수정 할 수 없다.
+// images 와 소유 table 이 모두 만들어진 뒤에 호출해야 한다.
+func (repo *ImageRepository) CreateOwnerTriggers(ownerTable string, dependencyType RepositoryType) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	triggerQueries := []string{
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_images_cascade_delete"
+		AFTER DELETE ON "%[1]s"
+		BEGIN
+			DELETE FROM images WHERE dependencyType = %[2]d AND dependencyId = OLD.id;
+		END`, ownerTable, dependencyType),
+
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_images_owner_insert"
+		BEFORE INSERT ON images
+		WHEN NEW.dependencyType = %[2]d AND NOT EXISTS (SELECT 1 FROM "%[1]s" WHERE id = NEW.dependencyId)
+		BEGIN
+			SELECT RAISE(ABORT, 'image owner not found [%[1]s]');
+		END`, ownerTable, dependencyType),
+
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_images_owner_update"
+		BEFORE UPDATE OF dependencyId, dependencyType ON images
+		WHEN NEW.dependencyType = %[2]d AND NOT EXISTS (SELECT 1 FROM "%[1]s" WHERE id = NEW.dependencyId)
+		BEGIN
+			SELECT RAISE(ABORT, 'image owner not found [%[1]s]');
+		END`, ownerTable, dependencyType),
+	}
+
+	for _, triggerQuery := range triggerQueries {
+		_, err = db.Exec(triggerQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (repo *ImageRepository) CreateTable() error {
 
 	db, err := repo.DBConnect.GetDB()
@@ -80,6 +134,91 @@ func (repo *ImageRepository) GetImages(dependencyType RepositoryType, dependency
 	return images, nil
 }
 
+// 여러 content 의 image 를 한번에 가져온다. (목록 조회에서 content 마다 GetImages 를 호출하지 않도록)
+// image 가 없는 content 도 빈 목록으로 채워서 반환한다.
+func (repo *ImageRepository) GetImagesByDependencyIds(dependencyType RepositoryType, dependencyIds []int64) (map[int64][]ImageModel, error) {
+
+	imagesById := make(map[int64][]ImageModel)
+	for _, dependencyId := range dependencyIds {
+		imagesById[dependencyId] = make([]ImageModel, 0)
+	}
+
+	if len(dependencyIds) == 0 {
+		return imagesById, nil
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	selectQuery := `
+		SELECT id, dependencyId, imagePath 
+		FROM images 
+		WHERE dependencyType = $1 AND 
+		dependencyId in (%v)
+		ORDER BY dependencyId, imageOrder
+	`
+
+	dependencyStrIds := funk.Map(dependencyIds, func(id int64) string {
+		return fmt.Sprintf("%v", id)
+	})
+	dependencyIdsJoined := strings.Join(dependencyStrIds.([]string), ",")
+	selectQuery = fmt.Sprintf(selectQuery, dependencyIdsJoined)
+
+	imageRows, err := db.Query(selectQuery, dependencyType)
+	if err != nil {
+		return nil, err
+	}
+	defer imageRows.Close()
+
+	for imageRows.Next() {
+		var imageId int64
+		var dependencyId int64
+		var imagePath string
+		err = imageRows.Scan(&imageId, &dependencyId, &imagePath)
+		if err != nil {
+			return nil, err
+		}
+
+		imagesById[dependencyId] = append(imagesById[dependencyId], ImageModel{Id: imageId, Path: imagePath})
+	}
+
+	return imagesById, nil
+}
+
+func (repo *ImageRepository) GetImagesTransaction(tx *sql.Tx, dependencyType RepositoryType, dependencyId int64) ([]ImageModel, error) {
+
+	selectQuery := `
+		SELECT id, imagePath 
+		FROM images 
+		WHERE dependencyId = $1 AND 
+		dependencyType = $2 
+		ORDER BY imageOrder
+	`
+
+	imageRows, err := tx.Query(selectQuery, dependencyId, dependencyType)
+	if err != nil {
+		return nil, err
+	}
+	defer imageRows.Close()
+
+	images := make([]ImageModel, 0)
+
+	for imageRows.Next() {
+		var imageId int64
+		var imagePath string
+		err = imageRows.Scan(&imageId, &imagePath)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, ImageModel{Id: imageId, Path: imagePath})
+	}
+
+	return images, nil
+}
+
 func (repo *ImageRepository) FindImages(dependencyType RepositoryType, dependencyId int64, imageIds []int64) ([]ImageModel, error) {
 
 	db, err := repo.DBConnect.GetDB()
@@ -183,6 +322,8 @@ func (repo *ImageRepository) AddImges(dependencyType RepositoryType, dependencyI
 		}
 	}
 
+	repo.DBConnect.notifyContentChanges(repositoryContentChange(dependencyType, dependencyId))
+
 	return nil
 }
 
@@ -220,6 +361,8 @@ func (repo *ImageRepository) RemoveImages(dependencyType RepositoryType, depende
 		return err
 	}
 
+	repo.DBConnect.notifyContentChanges(repositoryContentChange(dependencyType, dependencyId))
+
 	return nil
 }
 
@@ -246,6 +389,8 @@ func (repo *ImageRepository) RemoveImageFromPath(dependencyType RepositoryType,
 		return err
 	}
 
+	repo.DBConnect.notifyContentChanges(repositoryContentChange(dependencyType, dependencyId))
+
 	return nil
 }
 
@@ -272,6 +417,8 @@ func (repo *ImageRepository) RemoveImageFromId(dependencyType RepositoryType, de
 		return err
 	}
 
+	repo.DBConnect.notifyContentChanges(repositoryContentChange(dependencyType, dependencyId))
+
 	return nil
 }
 
@@ -317,6 +464,8 @@ func (repo *ImageRepository) SortImageOrder(dependencyType RepositoryType, depen
 		orderIndex++
 	}
 
+	repo.DBConnect.notifyContentChanges(repositoryContentChange(dependencyType, dependencyId))
+
 	return nil
 }
 
@@ -348,3 +497,81 @@ func (repo *ImageRepository) SortImageOrderTransation(tx *sql.Tx, dependencyType
 
 	return nil
 }
+
+// trigger 가 생기기 전에 만들어진 image row 중 소유 content 가 없는 것들
+func (repo *ImageRepository) FindOrphanImages() ([]OrphanImageModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	selectQuery := `
+		SELECT images.id, images.dependencyType, images.dependencyId, images.imagePath
+		FROM images
+		LEFT JOIN potofolio ON images.dependencyType = $1 AND potofolio.id = images.dependencyId
+		LEFT JOIN essay ON images.dependencyType = $2 AND essay.id = images.dependencyId
+		WHERE (images.dependencyType = $1 AND potofolio.id IS NULL) OR
+		(images.dependencyType = $2 AND essay.id IS NULL) OR
+		images.dependencyType NOT IN ($1, $2)
+		ORDER BY images.id
+	`
+
+	rows, err := db.Query(selectQuery, PotofolioType, EssayType)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orphanImages := make([]OrphanImageModel, 0)
+	for rows.Next() {
+		orphanImage := OrphanImageModel{}
+		err = rows.Scan(&orphanImage.Id, &orphanImage.DependencyType, &orphanImage.DependencyId, &orphanImage.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		orphanImages = append(orphanImages, orphanImage)
+	}
+
+	return orphanImages, nil
+}
+
+// 소유 content 가 없는 image row 를 제거하고 제거된 image 목록을 반환한다.
+// image 파일 삭제는 호출하는 쪽에서 처리한다.
+func (repo *ImageRepository) RemoveOrphanImages() ([]OrphanImageModel, error) {
+
+	orphanImages, err := repo.FindOrphanImages()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orphanImages) == 0 {
+		return orphanImages, nil
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	completed := false
+	defer CloseTranstion(transaction, &completed)
+
+	for _, orphanImage := range orphanImages {
+		_, err = transaction.Exec("DELETE FROM images WHERE id = $1", orphanImage.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completed = true
+
+	return orphanImages, nil
+}
@@ -142,3 +142,79 @@ func TestRemoveImageElement(t *testing.T) {
 
 	assert.Equal(t, len(images), 2)
 }
+
+func prepareTestOwnerImageRepo() (*DBConnection, *RepositoryConfigure, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:image_owner_test?mode=memory&cache=shared")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repositoryConfigure := &RepositoryConfigure{}
+	repositoryConfigure.Init(dbConnection)
+
+	return dbConnection, repositoryConfigure, nil
+}
+
+func TestImageOwnerTriggers(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestOwnerImageRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	imageRepo := repositoryConfigure.ImageRepository
+
+	// 없는 content 에는 image 를 추가할 수 없다.
+	err = imageRepo.AddImges(EssayType, 100, []string{"image1"})
+	assert.NotNil(t, err)
+
+	essayId, err := repositoryConfigure.EssayRepository.AddEssay("essay", "thumbnail", "content", []string{"image1", "image2"})
+	assert.Nil(t, err)
+
+	db, _ := dbConnection.GetDB()
+	_, err = db.Exec("DELETE FROM essay WHERE id = $1", essayId)
+	assert.Nil(t, err)
+
+	// content 가 지워지면 image 도 같이 지워진다.
+	images, err := imageRepo.GetImages(EssayType, essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(images), 0)
+}
+
+func TestRemoveOrphanImages(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestOwnerImageRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioId, err := repositoryConfigure.PotofolioRepository.AddPotofolio("potofolio", []string{"image1"})
+	assert.Nil(t, err)
+
+	// trigger 가 생기기 전에 남은 row 를 흉내낸다.
+	db, _ := dbConnection.GetDB()
+	_, err = db.Exec("DROP TRIGGER potofolio_images_owner_insert")
+	assert.Nil(t, err)
+
+	err = repositoryConfigure.ImageRepository.AddImges(PotofolioType, potofolioId+100, []string{"orphan1", "orphan2"})
+	assert.Nil(t, err)
+
+	orphanImages, err := repositoryConfigure.ImageRepository.FindOrphanImages()
+	assert.Nil(t, err)
+	assert.Equal(t, len(orphanImages), 2)
+	assert.Equal(t, orphanImages[0].Path, "orphan1")
+	assert.Equal(t, orphanImages[0].DependencyId, potofolioId+100)
+
+	removedImages, err := repositoryConfigure.ImageRepository.RemoveOrphanImages()
+	assert.Nil(t, err)
+	assert.Equal(t, len(removedImages), 2)
+
+	orphanImages, err = repositoryConfigure.ImageRepository.FindOrphanImages()
+	assert.Nil(t, err)
+	assert.Equal(t, len(orphanImages), 0)
+
+	images, err := repositoryConfigure.ImageRepository.GetImages(PotofolioType, potofolioId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(images), 1)
+}
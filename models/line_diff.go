@@ -0,0 +1,80 @@
+package models
+
+import "strings"
+
+type LineDiffOp string
+
+const (
+	LineDiffEqual  LineDiffOp = "equal"
+	LineDiffInsert LineDiffOp = "insert"
+	LineDiffDelete LineDiffOp = "delete"
+)
+
+type LineDiffModel struct {
+	Op   LineDiffOp `json:"op"`
+	Line string     `json:"line"`
+}
+
+// 두 text 의 줄 단위 차이 (LCS 기반)
+// essay 정도의 길이를 대상으로 하므로 O(n*m) 으로 계산한다.
+func DiffLines(before string, after string) []LineDiffModel {
+
+	beforeLines := splitLines(before)
+	afterLines := splitLines(after)
+
+	n := len(beforeLines)
+	m := len(afterLines)
+
+	// lcs[i][j] = beforeLines[i:] 와 afterLines[j:] 의 LCS 길이
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if beforeLines[i] == afterLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	diffs := make([]LineDiffModel, 0)
+
+	i, j := 0, 0
+	for i < n && j < m {
+		if beforeLines[i] == afterLines[j] {
+			diffs = append(diffs, LineDiffModel{Op: LineDiffEqual, Line: beforeLines[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			diffs = append(diffs, LineDiffModel{Op: LineDiffDelete, Line: beforeLines[i]})
+			i++
+		} else {
+			diffs = append(diffs, LineDiffModel{Op: LineDiffInsert, Line: afterLines[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		diffs = append(diffs, LineDiffModel{Op: LineDiffDelete, Line: beforeLines[i]})
+	}
+
+	for ; j < m; j++ {
+		diffs = append(diffs, LineDiffModel{Op: LineDiffInsert, Line: afterLines[j]})
+	}
+
+	return diffs
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+
+	diffs := DiffLines("a\nb\nc", "a\nc\nd")
+
+	assert.Equal(t, diffs, []LineDiffModel{
+		{Op: LineDiffEqual, Line: "a"},
+		{Op: LineDiffDelete, Line: "b"},
+		{Op: LineDiffEqual, Line: "c"},
+		{Op: LineDiffInsert, Line: "d"},
+	})
+}
+
+func TestDiffLinesEmpty(t *testing.T) {
+
+	assert.Equal(t, len(DiffLines("", "")), 0)
+
+	diffs := DiffLines("", "a\r\nb")
+	assert.Equal(t, diffs, []LineDiffModel{
+		{Op: LineDiffInsert, Line: "a"},
+		{Op: LineDiffInsert, Line: "b"},
+	})
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 목록 조회 조건 (값이 없는 조건은 적용하지 않는다.)
+type ListFilter struct {
+	TitleContains string
+	MinId         *int64
+	MaxId         *int64
+	HasImages     *bool
+
+	// 이 이름의 tag 가 붙은 content 만 (대소문자 무시)
+	Tag string
+
+	Featured *bool
+
+	// 비어 있으면 모든 공개 상태
+	Statuses []PublishStatus
+
+	// 비어 있으면 모든 id
+	Ids []int64
+}
+
+type ListPageOption struct {
+	Sort   ListSortOption
+	Filter ListFilter
+
+	// 0 이면 전체를 가져온다.
+	Limit int
+
+	// 이전 page 의 마지막 row 다음부터 가져온다. (이전 응답의 next cursor)
+	Cursor string
+}
+
+// 잘못된 cursor 이거나 정렬 조건이 다른 cursor
+type ListCursorError struct {
+	Cursor string
+	Reason string
+}
+
+func (e *ListCursorError) Error() string {
+	return fmt.Sprintf("invalid cursor [%s] (%s)", e.Cursor, e.Reason)
+}
+
+// 마지막 row 의 정렬 값과 id 를 담는다.
+// 같은 정렬 값이 여러 row 에 있어도 id 로 다음 위치가 정해지므로 page 사이에 빠지거나 겹치는 row 가 없다.
+type listCursor struct {
+	Sort       ListSortKey `json:"s"`
+	Descending bool        `json:"d"`
+	Value      int64       `json:"v,omitempty"`
+	Text       string      `json:"t,omitempty"`
+	Id         int64       `json:"id"`
+}
+
+func encodeListCursor(sortOption ListSortOption, id int64, title string, createdAt time.Time, updatedAt time.Time, publishedAt *time.Time, position int64) string {
+
+	cursor := listCursor{Sort: sortOption.Key, Descending: sortOption.Descending, Id: id}
+
+	switch sortOption.Key {
+	case SortByCreated:
+		cursor.Value = createdAt.Unix()
+	case SortByUpdated:
+		cursor.Value = updatedAt.Unix()
+	case SortByPublished:
+		if publishedAt != nil {
+			cursor.Value = publishedAt.Unix()
+		}
+	case SortByTitle:
+		cursor.Text = title
+	case SortByPosition:
+		cursor.Value = position
+	}
+
+	cursorJson, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(cursorJson)
+}
+
+func decodeListCursor(sortOption ListSortOption, encoded string) (*listCursor, error) {
+
+	cursorJson, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, &ListCursorError{Cursor: encoded, Reason: "not base64"}
+	}
+
+	var cursor listCursor
+	err = json.Unmarshal(cursorJson, &cursor)
+	if err != nil {
+		return nil, &ListCursorError{Cursor: encoded, Reason: "not json"}
+	}
+
+	if cursor.Sort != sortOption.Key || cursor.Descending != sortOption.Descending {
+		return nil, &ListCursorError{Cursor: encoded, Reason: "sort changed"}
+	}
+
+	return &cursor, nil
+}
+
+// 목록 조회 query 를 만든다. (삭제되지 않은 row 만)
+// limit 이 있으면 다음 page 가 있는지 알 수 있도록 1 개 더 가져온다.
+func (option ListPageOption) buildListQuery(selectColumns string, table string, repositoryType RepositoryType) (string, []interface{}, error) {
+
+	args := make([]interface{}, 0)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"deletedAt IS NULL"}
+
+	filter := option.Filter
+	if len(filter.TitleContains) > 0 {
+		conditions = append(conditions, fmt.Sprintf("instr(lower(title), lower(%s)) > 0", placeholder(filter.TitleContains)))
+	}
+
+	if filter.MinId != nil {
+		conditions = append(conditions, fmt.Sprintf("id >= %s", placeholder(*filter.MinId)))
+	}
+
+	if filter.MaxId != nil {
+		conditions = append(conditions, fmt.Sprintf("id <= %s", placeholder(*filter.MaxId)))
+	}
+
+	if filter.HasImages != nil {
+		existsCondition := fmt.Sprintf("EXISTS (SELECT 1 FROM images WHERE images.dependencyType = %s AND images.dependencyId = \"%s\".id)",
+			placeholder(repositoryType), table)
+
+		if !*filter.HasImages {
+			existsCondition = "NOT " + existsCondition
+		}
+
+		conditions = append(conditions, existsCondition)
+	}
+
+	if len(filter.Tag) > 0 {
+		tagCondition := fmt.Sprintf(`EXISTS (SELECT 1 FROM tag_link JOIN tag ON tag.id = tag_link.tagId
+			WHERE tag_link.dependencyType = %s AND tag_link.dependencyId = "%s".id AND tag.name = %s)`,
+			placeholder(repositoryType), table, placeholder(strings.TrimSpace(filter.Tag)))
+
+		conditions = append(conditions, tagCondition)
+	}
+
+	if filter.Featured != nil {
+		conditions = append(conditions, fmt.Sprintf("featured = %s", placeholder(*filter.Featured)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statusPlaceholders := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statusPlaceholders = append(statusPlaceholders, placeholder(status))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(statusPlaceholders, ", ")))
+	}
+
+	if len(filter.Ids) > 0 {
+		idPlaceholders := make([]string, 0, len(filter.Ids))
+		for _, id := range filter.Ids {
+			idPlaceholders = append(idPlaceholders, placeholder(id))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("id IN (%s)", strings.Join(idPlaceholders, ", ")))
+	}
+
+	if len(option.Cursor) > 0 {
+		cursor, err := decodeListCursor(option.Sort, option.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		compare := ">"
+		if option.Sort.Descending {
+			compare = "<"
+		}
+
+		column := option.Sort.sortColumn()
+		if column == "id" {
+			conditions = append(conditions, fmt.Sprintf("id %s %s", compare, placeholder(cursor.Id)))
+		} else {
+			var value interface{} = cursor.Value
+			if option.Sort.Key == SortByTitle {
+				value = cursor.Text
+			}
+
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[4]s AND id %[2]s %[5]s))",
+				column, compare, placeholder(value), placeholder(value), placeholder(cursor.Id)))
+		}
+	}
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM \"%s\" WHERE %s %s",
+		selectColumns, table, strings.Join(conditions, " AND "), option.Sort.orderByClause())
+
+	if option.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %s", placeholder(option.Limit+1))
+	}
+
+	return selectQuery, args, nil
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestListPagePotofolioRepo() (*DBConnection, *PotofolioRepository, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:list_page_test?mode=memory&cache=shared")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	potofolioRepo := &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = potofolioRepo.CreateTable()
+	if err != nil {
+		dbConnection.Close()
+		return nil, nil, err
+	}
+
+	return dbConnection, potofolioRepo, nil
+}
+
+func TestPotofolioPageCursor(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestListPagePotofolioRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	// 같은 title 이 있어도 page 사이에 빠지거나 겹치지 않아야 한다.
+	titles := []string{"b", "a", "b", "c", "a"}
+	for _, title := range titles {
+		repo.AddPotofolio(title, []string{"/images/" + title + ".jpg"})
+	}
+
+	sortOption := ListSortOption{Key: SortByTitle}
+
+	pageOption := ListPageOption{Sort: sortOption, Limit: 2}
+	pageIds := make([]int64, 0)
+	pageCount := 0
+	for {
+		potofolios, nextCursor, err := repo.GetPotofolioPage(pageOption)
+		assert.Nil(t, err)
+
+		pageCount++
+		for _, potofolio := range potofolios {
+			pageIds = append(pageIds, potofolio.Id)
+			assert.Equal(t, len(potofolio.Images), 1)
+			assert.Equal(t, potofolio.Images[0].Path, "/images/"+potofolio.Title+".jpg")
+		}
+
+		if len(nextCursor) == 0 {
+			break
+		}
+
+		pageOption.Cursor = nextCursor
+	}
+
+	assert.Equal(t, pageCount, 3)
+	assert.Equal(t, pageIds, []int64{2, 5, 1, 3, 4})
+
+	allPotofolios, err := repo.GetPotofolioListOrderBy(sortOption)
+	assert.Nil(t, err)
+	assert.Equal(t, len(allPotofolios), len(pageIds))
+
+	// 정렬 조건이 바뀐 cursor
+	firstPage, nextCursor, err := repo.GetPotofolioPage(ListPageOption{Sort: DefaultListSortOption(), Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, firstPage[1].Id, int64(2))
+
+	_, _, err = repo.GetPotofolioPage(ListPageOption{Sort: sortOption, Limit: 2, Cursor: nextCursor})
+	assert.IsType(t, &ListCursorError{}, err)
+
+	_, _, err = repo.GetPotofolioPage(ListPageOption{Sort: sortOption, Limit: 2, Cursor: "not cursor"})
+	assert.IsType(t, &ListCursorError{}, err)
+
+	// 마지막 page 는 cursor 가 없다.
+	_, nextCursor, err = repo.GetPotofolioPage(ListPageOption{Sort: sortOption, Limit: 5})
+	assert.Nil(t, err)
+	assert.Empty(t, nextCursor)
+}
+
+func TestPotofolioPageFilter(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:list_page_filter_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repo := &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	assert.Nil(t, repo.CreateTable())
+
+	repo.AddPotofolio("Summer Painting", []string{"/images/a.jpg"})
+	repo.AddPotofolio("winter painting", nil)
+	repo.AddPotofolio("summer drawing", []string{"/images/b.jpg", "/images/c.jpg"})
+	repo.AddPotofolio("sculpture", nil)
+
+	potofolios, _, err := repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{TitleContains: "PAINTING"}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 2)
+	assert.Equal(t, potofolios[0].Id, int64(1))
+	assert.Equal(t, potofolios[1].Id, int64(2))
+
+	minId := int64(2)
+	maxId := int64(3)
+	potofolios, _, err = repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{MinId: &minId, MaxId: &maxId}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 2)
+	assert.Equal(t, potofolios[0].Id, int64(2))
+	assert.Equal(t, potofolios[1].Id, int64(3))
+
+	hasImages := true
+	potofolios, _, err = repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{HasImages: &hasImages}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 2)
+	assert.Equal(t, len(potofolios[1].Images), 2)
+
+	hasImages = false
+	potofolios, _, err = repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{TitleContains: "painting", HasImages: &hasImages}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 1)
+	assert.Equal(t, potofolios[0].Id, int64(2))
+	assert.Equal(t, len(potofolios[0].Images), 0)
+
+	// 조건과 cursor 를 같이 사용
+	potofolios, nextCursor, err := repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{TitleContains: "summer"}, Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolios[0].Id, int64(1))
+
+	potofolios, nextCursor, err = repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{TitleContains: "summer"}, Limit: 1, Cursor: nextCursor})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolios[0].Id, int64(3))
+	assert.Empty(t, nextCursor)
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// 목록 정렬 기준 (api 의 sort query 값)
+type ListSortKey string
+
+const (
+	SortById        ListSortKey = "id"
+	SortByCreated   ListSortKey = "created"
+	SortByUpdated   ListSortKey = "updated"
+	SortByPublished ListSortKey = "published"
+	SortByTitle     ListSortKey = "title"
+
+	// 직접 정한 순서 (potofolio 만)
+	SortByPosition ListSortKey = "position"
+)
+
+var listSortColumns = map[ListSortKey]string{
+	SortById:        "id",
+	SortByCreated:   "createdAt",
+	SortByUpdated:   "updatedAt",
+	SortByPublished: "COALESCE(publishedAt, 0)", // 공개되지 않은 것은 가장 오래된 것으로 본다.
+	SortByTitle:     "title",
+	SortByPosition:  "position",
+}
+
+type ListSortOption struct {
+	Key        ListSortKey
+	Descending bool
+}
+
+// 기존 목록 순서 (추가된 순서)
+func DefaultListSortOption() ListSortOption {
+	return ListSortOption{Key: SortById, Descending: false}
+}
+
+// potofolio 목록 순서 (직접 정한 순서)
+func DefaultPotofolioSortOption() ListSortOption {
+	return ListSortOption{Key: SortByPosition, Descending: false}
+}
+
+// sort, order query 값으로 ListSortOption 을 만든다.
+// order 가 없으면 날짜 기준은 최신 순, 그 외는 오름차순이다.
+func ParseListSortOption(sort string, order string) (ListSortOption, error) {
+	return ParseListSortOptionOrDefault(sort, order, DefaultListSortOption())
+}
+
+// sort 가 없으면 defaultOption 의 기준을 사용한다.
+func ParseListSortOptionOrDefault(sort string, order string, defaultOption ListSortOption) (ListSortOption, error) {
+
+	option := defaultOption
+	if len(sort) > 0 {
+		key := ListSortKey(sort)
+		if _, ok := listSortColumns[key]; !ok {
+			return option, fmt.Errorf("unknown sort [%s]", sort)
+		}
+
+		option.Key = key
+		option.Descending = key == SortByCreated || key == SortByUpdated || key == SortByPublished
+	}
+
+	switch order {
+	case "":
+	case "asc":
+		option.Descending = false
+	case "desc":
+		option.Descending = true
+	default:
+		return option, fmt.Errorf("unknown order [%s]", order)
+	}
+
+	return option, nil
+}
+
+// 정렬 column (알 수 없는 기준이면 id)
+func (option ListSortOption) sortColumn() string {
+
+	column, ok := listSortColumns[option.Key]
+	if !ok {
+		return "id"
+	}
+
+	return column
+}
+
+// ORDER BY 절 (같은 값이면 id 로 순서를 고정한다.)
+func (option ListSortOption) orderByClause() string {
+
+	column := option.sortColumn()
+
+	direction := "ASC"
+	if option.Descending {
+		direction = "DESC"
+	}
+
+	if column == "id" {
+		return fmt.Sprintf("ORDER BY id %s", direction)
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, id %s", column, direction, direction)
+}
+
+// createdAt, updatedAt, publishedAt column 을 추가한다.
+// 기존 row 는 column 이 추가될 때 backfillQuery 로 채운다. (비어 있으면 현재 시간)
+func addTimestampColumns(db *sql.DB, table string, backfillQuery string) error {
+
+	added := false
+	for _, column := range []string{"createdAt", "updatedAt", "publishedAt"} {
+		columnAdded, err := addColumnIfNotExists(db, table, column, "INTEGER")
+		if err != nil {
+			return err
+		}
+
+		added = added || columnAdded
+	}
+
+	if !added {
+		return nil
+	}
+
+	if len(backfillQuery) > 0 {
+		_, err := db.Exec(backfillQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Unix()
+	backfillNowQuery := fmt.Sprintf(`
+		UPDATE "%s" SET
+			createdAt = COALESCE(createdAt, $1),
+			updatedAt = COALESCE(updatedAt, createdAt, $2),
+			publishedAt = COALESCE(publishedAt, createdAt, $3)
+		WHERE createdAt IS NULL OR updatedAt IS NULL OR publishedAt IS NULL`, table)
+
+	_, err := db.Exec(backfillNowQuery, now, now, now)
+	return err
+}
+
+// 수정 시간을 갱신한다.
+func touchUpdatedAtTransaction(tx *sql.Tx, table string, id int64) error {
+	_, err := tx.Exec(fmt.Sprintf("UPDATE \"%s\" SET updatedAt = $1 WHERE id = $2", table), time.Now().Unix(), id)
+	return err
+}
+
+func unixToTimePtr(value sql.NullInt64) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	t := time.Unix(value.Int64, 0)
+	return &t
+}
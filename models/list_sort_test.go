@@ -0,0 +1,126 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListSortOption(t *testing.T) {
+
+	option, err := ParseListSortOption("", "")
+	assert.Nil(t, err)
+	assert.Equal(t, option, DefaultListSortOption())
+
+	option, err = ParseListSortOption("published", "")
+	assert.Nil(t, err)
+	assert.Equal(t, option, ListSortOption{Key: SortByPublished, Descending: true})
+
+	option, err = ParseListSortOption("title", "")
+	assert.Nil(t, err)
+	assert.Equal(t, option, ListSortOption{Key: SortByTitle, Descending: false})
+
+	option, err = ParseListSortOption("created", "asc")
+	assert.Nil(t, err)
+	assert.Equal(t, option, ListSortOption{Key: SortByCreated, Descending: false})
+
+	_, err = ParseListSortOption("version", "")
+	assert.NotNil(t, err)
+
+	_, err = ParseListSortOption("created", "random")
+	assert.NotNil(t, err)
+}
+
+func TestPotofolioListOrderBy(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:list_sort_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repo := &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	assert.Nil(t, repo.CreateTable())
+
+	firstId, _ := repo.AddPotofolio("b potofolio", nil)
+	secondId, _ := repo.AddPotofolio("a potofolio", nil)
+	thirdId, _ := repo.AddPotofolio("c potofolio", nil)
+
+	db, _ := dbConnection.GetDB()
+	db.Exec("UPDATE potofolio SET publishedAt = $1 WHERE id = $2", 300, firstId)
+	db.Exec("UPDATE potofolio SET publishedAt = $1 WHERE id = $2", 100, secondId)
+	db.Exec("UPDATE potofolio SET publishedAt = $1 WHERE id = $2", 200, thirdId)
+
+	potofolios, err := repo.GetPotofolioListOrderBy(ListSortOption{Key: SortByPublished, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 3)
+	assert.Equal(t, potofolios[0].Id, firstId)
+	assert.Equal(t, potofolios[1].Id, thirdId)
+	assert.Equal(t, potofolios[2].Id, secondId)
+	assert.Equal(t, potofolios[0].PublishedAt.Unix(), int64(300))
+
+	potofolios, err = repo.GetPotofolioListOrderBy(ListSortOption{Key: SortByTitle})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolios[0].Title, "a potofolio")
+	assert.Equal(t, potofolios[2].Title, "c potofolio")
+
+	// 수정하면 updatedAt 만 바뀐다.
+	db.Exec("UPDATE potofolio SET createdAt = 10, updatedAt = 10")
+
+	updateTitle := "a potofolio updated"
+	_, err = repo.UpdatePotofolio(secondId, &updateTitle, nil, nil)
+	assert.Nil(t, err)
+
+	potofolioModel, err := repo.FindPotofolio(secondId)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioModel.CreatedAt.Unix(), int64(10))
+	assert.Greater(t, potofolioModel.UpdatedAt.Unix(), int64(10))
+
+	potofolios, err = repo.GetPotofolioListOrderBy(ListSortOption{Key: SortByUpdated, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolios[0].Id, secondId)
+}
+
+func TestTimestampColumnsBackfill(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:list_sort_backfill_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	// 날짜 column 이 없던 essay, essay_revision table
+	db, _ := dbConnection.GetDB()
+	_, err = db.Exec(`CREATE TABLE "essay" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "title" TEXT, "thumbImage" TEXT, "essayContent" TEXT)`)
+	assert.Nil(t, err)
+
+	db.Exec("INSERT INTO essay (title, thumbImage, essayContent) VALUES ('old essay1', '', '')")
+	db.Exec("INSERT INTO essay (title, thumbImage, essayContent) VALUES ('old essay2', '', '')")
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repo := &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	assert.Nil(t, repo.createRevisionTable(db))
+
+	db.Exec("INSERT INTO essay_revision (essayId, revision, createdAt) VALUES (1, 1, 100)")
+	db.Exec("INSERT INTO essay_revision (essayId, revision, createdAt) VALUES (1, 2, 200)")
+
+	assert.Nil(t, repo.CreateTable())
+
+	essays, err := repo.GetEssayList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(essays), 2)
+
+	// revision 이 있으면 revision 시간으로
+	assert.Equal(t, essays[0].CreatedAt.Unix(), int64(100))
+	assert.Equal(t, essays[0].UpdatedAt.Unix(), int64(200))
+	assert.Equal(t, essays[0].PublishedAt.Unix(), int64(100))
+
+	// 없으면 migration 시간으로
+	assert.NotNil(t, essays[1].PublishedAt)
+	assert.Greater(t, essays[1].CreatedAt.Unix(), int64(200))
+}
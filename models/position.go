@@ -0,0 +1,111 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// 순서 변경 요청이 잘못된 경우 (없는 id, 중복된 id)
+type ReorderError struct {
+	Id     int64
+	Reason string
+}
+
+func (e *ReorderError) Error() string {
+	return fmt.Sprintf("reorder failed [id:%v] (%s)", e.Id, e.Reason)
+}
+
+// 직접 정하는 목록 순서 (작을수록 앞)
+// column 이 새로 추가되면 기존 row 는 추가된 순서 (id) 로 채운다.
+func addPositionColumn(db *sql.DB, table string) error {
+
+	added, err := addColumnIfNotExists(db, table, "position", "INTEGER")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS \"%[1]s_position\" ON \"%[1]s\" (\"position\")", table))
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return nil
+	}
+
+	_, err = db.Exec(fmt.Sprintf("UPDATE \"%s\" SET position = id WHERE position IS NULL", table))
+	return err
+}
+
+// 새 row 는 목록의 마지막에 둔다.
+func appendPositionTransaction(tx *sql.Tx, table string, id int64) error {
+
+	updateQuery := fmt.Sprintf("UPDATE \"%[1]s\" SET position = (SELECT COALESCE(MAX(position), 0) + 1 FROM \"%[1]s\") WHERE id = $1", table)
+	_, err := tx.Exec(updateQuery, id)
+	return err
+}
+
+// ids 를 주어진 순서대로 목록의 앞에 두고, 나머지는 기존 순서대로 그 뒤에 둔다.
+// (휴지통에 있는 row 도 복구될 때를 위해 순서를 유지한다.)
+func reorderPositionTransaction(tx *sql.Tx, table string, ids []int64) error {
+
+	requested := make(map[int64]bool)
+	for _, id := range ids {
+		if requested[id] {
+			return &ReorderError{Id: id, Reason: "duplicated"}
+		}
+
+		requested[id] = true
+
+		var exists bool
+		err := tx.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM \"%s\" WHERE id = $1 AND deletedAt IS NULL)", table), id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return &ReorderError{Id: id, Reason: "not found"}
+		}
+	}
+
+	rows, err := tx.Query(fmt.Sprintf("SELECT id FROM \"%s\" ORDER BY position, id", table))
+	if err != nil {
+		return err
+	}
+
+	orderedIds := append(make([]int64, 0), ids...)
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		if !requested[id] {
+			orderedIds = append(orderedIds, id)
+		}
+	}
+	rows.Close()
+
+	updateQuery := fmt.Sprintf("UPDATE \"%s\" SET position = $1 WHERE id = $2", table)
+	for i, id := range orderedIds {
+		_, err = tx.Exec(updateQuery, i+1, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 첫 화면에 보여줄 content 표시
+func addFeaturedColumn(db *sql.DB, table string) error {
+	_, err := addColumnIfNotExists(db, table, "featured", "INTEGER NOT NULL DEFAULT 0")
+	return err
+}
+
+func setFeaturedTransaction(tx *sql.Tx, table string, id int64, featured bool) error {
+	_, err := tx.Exec(fmt.Sprintf("UPDATE \"%s\" SET featured = $1 WHERE id = $2", table), featured, id)
+	return err
+}
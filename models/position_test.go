@@ -0,0 +1,128 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func potofolioTitles(potofolios []PotofolioModel) []string {
+	titles := make([]string, 0, len(potofolios))
+	for _, potofolio := range potofolios {
+		titles = append(titles, potofolio.Title)
+	}
+
+	return titles
+}
+
+func TestPotofolioPosition(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:position_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+
+	potofolioRepo.AddPotofolio("first", nil)
+	potofolioRepo.AddPotofolio("second", nil)
+	potofolioRepo.AddPotofolio("third", nil)
+	potofolioRepo.AddPotofolio("fourth", nil)
+
+	// 기본 순서는 추가된 순서
+	potofolios, err := potofolioRepo.GetPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(potofolios), []string{"first", "second", "third", "fourth"})
+	assert.Equal(t, potofolios[3].Position, int64(4))
+
+	// 휴지통에 있는 potofolio 도 순서를 유지한다.
+	assert.Nil(t, potofolioRepo.TrashPotofolio(2, nil))
+
+	err = potofolioRepo.ReorderPotofolios([]int64{4, 3})
+	assert.Nil(t, err)
+
+	potofolios, err = potofolioRepo.GetPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(potofolios), []string{"fourth", "third", "first"})
+
+	assert.Nil(t, potofolioRepo.RestorePotofolio(2))
+
+	potofolios, err = potofolioRepo.GetPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(potofolios), []string{"fourth", "third", "first", "second"})
+
+	// 순서를 바꿔도 version 은 그대로
+	assert.Equal(t, potofolios[0].Version, int64(1))
+
+	// 새 potofolio 는 마지막
+	potofolioRepo.AddPotofolio("fifth", nil)
+
+	potofolios, err = potofolioRepo.GetPotofolioListOrderBy(ListSortOption{Key: SortByPosition, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolios[0].Title, "fifth")
+
+	// 잘못된 요청
+	err = potofolioRepo.ReorderPotofolios([]int64{1, 1})
+	assert.Equal(t, err, &ReorderError{Id: 1, Reason: "duplicated"})
+
+	err = potofolioRepo.ReorderPotofolios([]int64{1, 100})
+	assert.Equal(t, err, &ReorderError{Id: 100, Reason: "not found"})
+
+	// cursor 로 이어서 읽기
+	page, nextCursor, err := potofolioRepo.GetPotofolioPage(ListPageOption{Sort: DefaultPotofolioSortOption(), Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(page), []string{"fourth", "third", "first"})
+
+	page, _, err = potofolioRepo.GetPotofolioPage(ListPageOption{Sort: DefaultPotofolioSortOption(), Limit: 3, Cursor: nextCursor})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(page), []string{"second", "fifth"})
+}
+
+func TestFeatured(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:featured_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+	essayRepo := repositoryConfigure.EssayRepository
+
+	featured := true
+	potofolioRepo.AddPotofolioWithOption(&ContentOption{Featured: &featured}, "featured", nil)
+	potofolioRepo.AddPotofolio("normal", nil)
+	essayRepo.AddEssayWithOption("", &ContentOption{Featured: &featured}, "featured essay", "", "", nil)
+
+	potofolio, err := potofolioRepo.FindPotofolio(1)
+	assert.Nil(t, err)
+	assert.True(t, potofolio.Featured)
+
+	essay, err := essayRepo.FindEssay(1)
+	assert.Nil(t, err)
+	assert.True(t, essay.Featured)
+
+	potofolios, _, err := potofolioRepo.GetPotofolioPage(ListPageOption{Filter: ListFilter{Featured: &featured}})
+	assert.Nil(t, err)
+	assert.Equal(t, potofolioTitles(potofolios), []string{"featured"})
+
+	// featured 를 주지 않으면 바뀌지 않는다.
+	title := "renamed"
+	_, err = potofolioRepo.UpdatePotofolioWithOption(1, nil, &ContentOption{}, &title, nil, nil)
+	assert.Nil(t, err)
+
+	potofolio, err = potofolioRepo.FindPotofolio(1)
+	assert.Nil(t, err)
+	assert.True(t, potofolio.Featured)
+
+	notFeatured := false
+	_, err = potofolioRepo.UpdatePotofolioWithOption(1, nil, &ContentOption{Featured: &notFeatured}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	potofolios, _, err = potofolioRepo.GetPotofolioPage(ListPageOption{Filter: ListFilter{Featured: &featured}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 0)
+
+	// essay 는 직접 정한 순서가 없다.
+	_, _, err = essayRepo.GetEssayPage(ListPageOption{Sort: DefaultPotofolioSortOption()})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,341 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxPotofolioMediumLength      = 200
+	maxPotofolioClientLength      = 200
+	maxPotofolioDescriptionLength = 20000
+	maxPotofolioCreditCount       = 50
+	maxPotofolioCreditLength      = 100
+	maxPotofolioLinkCount         = 20
+	maxPotofolioLinkTitleLength   = 100
+)
+
+// 크기 단위
+var potofolioDimensionUnits = []string{"mm", "cm", "m", "in"}
+
+// 작품 크기 (Depth 가 0 이면 평면 작품)
+type PotofolioDimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Depth  float64 `json:"depth"`
+	Unit   string  `json:"unit"`
+}
+
+// 함께 작업한 사람과 역할
+type PotofolioCredit struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// 외부 링크 (전시 페이지, 영상 등)
+type PotofolioLink struct {
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// potofolio 작품 정보
+type PotofolioMetadata struct {
+	// 0 이면 없음
+	Year        int                  `json:"year"`
+	Medium      string               `json:"medium"`
+	Dimensions  *PotofolioDimensions `json:"dimensions"`
+	Description string               `json:"description"`
+	Credits     []PotofolioCredit    `json:"credits"`
+	Client      string               `json:"client"`
+	Links       []PotofolioLink      `json:"links"`
+}
+
+// potofolio 를 추가/수정할 때 작품 정보
+// 값이 없는 항목은 추가할 때 빈 값을 사용하고, 수정할 때는 바꾸지 않는다.
+type PotofolioMetadataOption struct {
+	// 0 이면 지운다.
+	Year   *int
+	Medium *string
+
+	// Unit 이 비어 있으면 지운다.
+	Dimensions *PotofolioDimensions
+
+	Description *string
+	Client      *string
+
+	// nil 이면 바꾸지 않는다. (빈 목록이면 모두 뺀다.)
+	Credits []PotofolioCredit
+	Links   []PotofolioLink
+}
+
+func validateTextLength(field string, value *string, maxLength int) error {
+	if value == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(*value) > maxLength {
+		return fmt.Errorf("%s is too long (max %d)", field, maxLength)
+	}
+
+	return nil
+}
+
+func validatePotofolioDimensions(dimensions *PotofolioDimensions) error {
+
+	// 지우는 요청
+	if len(dimensions.Unit) == 0 && dimensions.Width == 0 && dimensions.Height == 0 && dimensions.Depth == 0 {
+		return nil
+	}
+
+	validUnit := false
+	for _, unit := range potofolioDimensionUnits {
+		if dimensions.Unit == unit {
+			validUnit = true
+			break
+		}
+	}
+
+	if !validUnit {
+		return fmt.Errorf("dimensions.unit is wrong (unit = %s, %s)", dimensions.Unit, strings.Join(potofolioDimensionUnits, ", "))
+	}
+
+	if dimensions.Width <= 0 || dimensions.Height <= 0 || dimensions.Depth < 0 {
+		return fmt.Errorf("dimensions is wrong (width, height > 0, depth >= 0)")
+	}
+
+	return nil
+}
+
+// 작품 정보가 올바른지 확인한다.
+func ValidatePotofolioMetadata(option *PotofolioMetadataOption) error {
+
+	if option == nil {
+		return nil
+	}
+
+	if option.Year != nil && *option.Year != 0 && (*option.Year < 1000 || *option.Year > 9999) {
+		return fmt.Errorf("year is wrong (year = %d)", *option.Year)
+	}
+
+	err := validateTextLength("medium", option.Medium, maxPotofolioMediumLength)
+	if err != nil {
+		return err
+	}
+
+	err = validateTextLength("client", option.Client, maxPotofolioClientLength)
+	if err != nil {
+		return err
+	}
+
+	err = validateTextLength("description", option.Description, maxPotofolioDescriptionLength)
+	if err != nil {
+		return err
+	}
+
+	if option.Dimensions != nil {
+		err = validatePotofolioDimensions(option.Dimensions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(option.Credits) > maxPotofolioCreditCount {
+		return fmt.Errorf("credits are too many (max %d)", maxPotofolioCreditCount)
+	}
+
+	for _, credit := range option.Credits {
+		if len(strings.TrimSpace(credit.Name)) == 0 {
+			return fmt.Errorf("credit name is empty")
+		}
+
+		if utf8.RuneCountInString(credit.Name) > maxPotofolioCreditLength || utf8.RuneCountInString(credit.Role) > maxPotofolioCreditLength {
+			return fmt.Errorf("credit is too long [name:%v] (max %d)", credit.Name, maxPotofolioCreditLength)
+		}
+	}
+
+	if len(option.Links) > maxPotofolioLinkCount {
+		return fmt.Errorf("links are too many (max %d)", maxPotofolioLinkCount)
+	}
+
+	for _, link := range option.Links {
+		linkUrl, err := url.Parse(link.Url)
+		if err != nil || (linkUrl.Scheme != "http" && linkUrl.Scheme != "https") || len(linkUrl.Host) == 0 {
+			return fmt.Errorf("link url is wrong (url = %s, http or https)", link.Url)
+		}
+
+		if utf8.RuneCountInString(link.Title) > maxPotofolioLinkTitleLength {
+			return fmt.Errorf("link title is too long [url:%v] (max %d)", link.Url, maxPotofolioLinkTitleLength)
+		}
+	}
+
+	return nil
+}
+
+// 작품 정보 column
+// credits, links 는 json 목록으로 저장한다.
+func addPotofolioMetadataColumns(db *sql.DB) error {
+
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{"year", "INTEGER"},
+		{"medium", "TEXT NOT NULL DEFAULT ''"},
+		{"width", "REAL"},
+		{"height", "REAL"},
+		{"depth", "REAL"},
+		{"dimensionUnit", "TEXT"},
+		{"description", "TEXT NOT NULL DEFAULT ''"},
+		{"credits", "TEXT NOT NULL DEFAULT '[]'"},
+		{"client", "TEXT NOT NULL DEFAULT ''"},
+		{"links", "TEXT NOT NULL DEFAULT '[]'"},
+	}
+
+	for _, column := range columns {
+		_, err := addColumnIfNotExists(db, "potofolio", column.name, column.definition)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const potofolioMetadataColumns = "year, medium, width, height, depth, dimensionUnit, description, credits, client, links"
+
+// potofolioMetadataColumns 순서대로 읽는다.
+type potofolioMetadataScanner struct {
+	year          sql.NullInt64
+	medium        string
+	width         sql.NullFloat64
+	height        sql.NullFloat64
+	depth         sql.NullFloat64
+	dimensionUnit sql.NullString
+	description   string
+	credits       string
+	client        string
+	links         string
+}
+
+func (scanner *potofolioMetadataScanner) dest() []interface{} {
+	return []interface{}{
+		&scanner.year, &scanner.medium,
+		&scanner.width, &scanner.height, &scanner.depth, &scanner.dimensionUnit,
+		&scanner.description, &scanner.credits, &scanner.client, &scanner.links,
+	}
+}
+
+func (scanner *potofolioMetadataScanner) metadata() (PotofolioMetadata, error) {
+
+	metadata := PotofolioMetadata{
+		Year:        int(scanner.year.Int64),
+		Medium:      scanner.medium,
+		Description: scanner.description,
+		Client:      scanner.client,
+		Credits:     make([]PotofolioCredit, 0),
+		Links:       make([]PotofolioLink, 0),
+	}
+
+	if scanner.dimensionUnit.Valid {
+		metadata.Dimensions = &PotofolioDimensions{
+			Width:  scanner.width.Float64,
+			Height: scanner.height.Float64,
+			Depth:  scanner.depth.Float64,
+			Unit:   scanner.dimensionUnit.String,
+		}
+	}
+
+	err := json.Unmarshal([]byte(scanner.credits), &metadata.Credits)
+	if err != nil {
+		return metadata, err
+	}
+
+	err = json.Unmarshal([]byte(scanner.links), &metadata.Links)
+	if err != nil {
+		return metadata, err
+	}
+
+	return metadata, nil
+}
+
+// option 에 있는 항목만 바꾼다.
+func setPotofolioMetadataTransaction(tx *sql.Tx, potofolioId int64, option *PotofolioMetadataOption) error {
+
+	if option == nil {
+		return nil
+	}
+
+	setClauses := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if option.Year != nil {
+		if *option.Year == 0 {
+			set("year", nil)
+		} else {
+			set("year", *option.Year)
+		}
+	}
+
+	if option.Medium != nil {
+		set("medium", strings.TrimSpace(*option.Medium))
+	}
+
+	if option.Dimensions != nil {
+		if len(option.Dimensions.Unit) == 0 {
+			set("width", nil)
+			set("height", nil)
+			set("depth", nil)
+			set("dimensionUnit", nil)
+		} else {
+			set("width", option.Dimensions.Width)
+			set("height", option.Dimensions.Height)
+			set("depth", option.Dimensions.Depth)
+			set("dimensionUnit", option.Dimensions.Unit)
+		}
+	}
+
+	if option.Description != nil {
+		set("description", *option.Description)
+	}
+
+	if option.Client != nil {
+		set("client", strings.TrimSpace(*option.Client))
+	}
+
+	if option.Credits != nil {
+		creditsJson, err := json.Marshal(option.Credits)
+		if err != nil {
+			return err
+		}
+
+		set("credits", string(creditsJson))
+	}
+
+	if option.Links != nil {
+		linksJson, err := json.Marshal(option.Links)
+		if err != nil {
+			return err
+		}
+
+		set("links", string(linksJson))
+	}
+
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	args = append(args, potofolioId)
+	updateQuery := fmt.Sprintf("UPDATE potofolio SET %s WHERE id = $%d", strings.Join(setClauses, ", "), len(args))
+
+	_, err := tx.Exec(updateQuery, args...)
+	return err
+}
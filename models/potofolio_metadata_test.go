@@ -0,0 +1,92 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPotofolioMetadata(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:potofolio_metadata_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	potofolioRepo := repositoryConfigure.PotofolioRepository
+
+	year := 2021
+	medium := "oil on canvas"
+	description := "바다를 그린 작품"
+	metadataOption := &PotofolioMetadataOption{
+		Year:        &year,
+		Medium:      &medium,
+		Dimensions:  &PotofolioDimensions{Width: 72.7, Height: 60.6, Unit: "cm"},
+		Description: &description,
+		Credits:     []PotofolioCredit{{Name: "kim", Role: "photo"}},
+		Links:       []PotofolioLink{{Title: "전시", Url: "https://example.com/show"}},
+	}
+
+	potofolioId, err := potofolioRepo.AddPotofolioWithMetadata(nil, metadataOption, "sea", nil)
+	assert.Nil(t, err)
+
+	potofolio, err := potofolioRepo.FindPotofolio(potofolioId)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Metadata.Year, 2021)
+	assert.Equal(t, potofolio.Metadata.Medium, "oil on canvas")
+	assert.Equal(t, potofolio.Metadata.Dimensions, &PotofolioDimensions{Width: 72.7, Height: 60.6, Unit: "cm"})
+	assert.Equal(t, potofolio.Metadata.Description, "바다를 그린 작품")
+	assert.Equal(t, potofolio.Metadata.Credits, []PotofolioCredit{{Name: "kim", Role: "photo"}})
+	assert.Equal(t, potofolio.Metadata.Client, "")
+	assert.Equal(t, len(potofolio.Metadata.Links), 1)
+
+	// 없는 항목은 바꾸지 않고, 0 과 빈 크기는 지운다.
+	noYear := 0
+	client := "gallery"
+	_, err = potofolioRepo.UpdatePotofolioWithMetadata(potofolioId, nil, nil, &PotofolioMetadataOption{
+		Year:       &noYear,
+		Dimensions: &PotofolioDimensions{},
+		Client:     &client,
+		Credits:    []PotofolioCredit{},
+	}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	potofolios, err := potofolioRepo.GetPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 1)
+
+	metadata := potofolios[0].Metadata
+	assert.Equal(t, metadata.Year, 0)
+	assert.Nil(t, metadata.Dimensions)
+	assert.Equal(t, metadata.Medium, "oil on canvas")
+	assert.Equal(t, metadata.Client, "gallery")
+	assert.Equal(t, len(metadata.Credits), 0)
+	assert.Equal(t, len(metadata.Links), 1)
+	assert.Equal(t, potofolios[0].Version, int64(2))
+
+	// 작품 정보 없이 추가
+	potofolioId, err = potofolioRepo.AddPotofolio("stone", nil)
+	assert.Nil(t, err)
+
+	potofolio, err = potofolioRepo.FindPotofolio(potofolioId)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Metadata.Year, 0)
+	assert.Nil(t, potofolio.Metadata.Dimensions)
+	assert.Equal(t, len(potofolio.Metadata.Credits), 0)
+}
+
+func TestValidatePotofolioMetadata(t *testing.T) {
+
+	year := 21
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Year: &year}))
+
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Dimensions: &PotofolioDimensions{Width: 10, Height: 10, Unit: "ft"}}))
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Dimensions: &PotofolioDimensions{Width: 10, Unit: "cm"}}))
+	assert.Nil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Dimensions: &PotofolioDimensions{Width: 10, Height: 10, Depth: 3, Unit: "in"}}))
+
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Credits: []PotofolioCredit{{Name: " ", Role: "photo"}}}))
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Links: []PotofolioLink{{Url: "javascript:alert(1)"}}}))
+	assert.NotNil(t, ValidatePotofolioMetadata(&PotofolioMetadataOption{Links: []PotofolioLink{{Url: "/relative"}}}))
+
+	assert.Nil(t, ValidatePotofolioMetadata(nil))
+}
@@ -1,16 +1,40 @@
 package models
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+type PotofolioNotFoundError struct {
+	Id int64
+}
+
+func (e *PotofolioNotFoundError) Error() string {
+	return fmt.Sprintf("potofolio not found [id: %v]", e.Id)
+}
+
 type PotofolioModel struct {
-	Id     int64        `json:"id"`
-	Title  string       `json:"title"`
-	Images []ImageModel `json:"images"`
+	Id          int64             `json:"id"`
+	Slug        string            `json:"slug"`
+	Title       string            `json:"title"`
+	Images      []ImageModel      `json:"images"`
+	Version     int64             `json:"version"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	PublishedAt *time.Time        `json:"publishedAt"`
+	Status      PublishStatus     `json:"status"`
+	PublishAt   *time.Time        `json:"publishAt"`
+	Tags        []TagModel        `json:"tags"`
+	Position    int64             `json:"position"`
+	Featured    bool              `json:"featured"`
+	Metadata    PotofolioMetadata `json:"metadata"`
+
+	// 속한 collection (collection table 이 없으면 비어 있다.)
+	Collections []CollectionRefModel `json:"collections"`
 }
 
 type PotofolioRepository struct {
@@ -38,20 +62,92 @@ func (repo *PotofolioRepository) CreateTable() error {
 		return err
 	}
 
+	// 휴지통 (NULL 이면 삭제되지 않은 상태)
+	_, err = addColumnIfNotExists(db, "potofolio", "deletedAt", "INTEGER")
+	if err != nil {
+		log.Printf("[error] add column potofolio.deletedAt [%v]\n", err)
+		return err
+	}
+
+	// 동시 수정 확인용 (수정될 때 마다 1 씩 증가)
+	_, err = addColumnIfNotExists(db, "potofolio", "version", "INTEGER NOT NULL DEFAULT 1")
+	if err != nil {
+		log.Printf("[error] add column potofolio.version [%v]\n", err)
+		return err
+	}
+
+	err = addTimestampColumns(db, "potofolio", "")
+	if err != nil {
+		log.Printf("[error] add timestamp columns potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addPublishColumns(db, "potofolio")
+	if err != nil {
+		log.Printf("[error] add publish columns potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addSlugColumn(db, "potofolio", PotofolioType)
+	if err != nil {
+		log.Printf("[error] add slug column potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addTagLinks(db, "potofolio", PotofolioType)
+	if err != nil {
+		log.Printf("[error] add tag links potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addPositionColumn(db, "potofolio")
+	if err != nil {
+		log.Printf("[error] add position column potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addFeaturedColumn(db, "potofolio")
+	if err != nil {
+		log.Printf("[error] add featured column potofolio [%v]\n", err)
+		return err
+	}
+
+	err = addPotofolioMetadataColumns(db)
+	if err != nil {
+		log.Printf("[error] add metadata columns potofolio [%v]\n", err)
+		return err
+	}
+
 	return nil
 }
 
 func (repo *PotofolioRepository) GetPotofolioList() ([]PotofolioModel, error) {
+	return repo.GetPotofolioListOrderBy(DefaultPotofolioSortOption())
+}
+
+func (repo *PotofolioRepository) GetPotofolioListOrderBy(sortOption ListSortOption) ([]PotofolioModel, error) {
+	potofolios, _, err := repo.GetPotofolioPage(ListPageOption{Sort: sortOption})
+	return potofolios, err
+}
+
+// pageOption.Limit 만큼 가져온다. 다음 page 가 있으면 다음 page 의 cursor 를 같이 반환한다.
+// image 는 page 의 potofolio 전체를 한번에 조회한다.
+func (repo *PotofolioRepository) GetPotofolioPage(pageOption ListPageOption) ([]PotofolioModel, string, error) {
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	potofolioRows, err := db.Query("SELECT id, title FROM potofolio")
+	selectQuery, args, err := pageOption.buildListQuery("id, COALESCE(slug, ''), title, version, createdAt, updatedAt, publishedAt, status, publishAt, COALESCE(position, 0), featured, "+potofolioMetadataColumns, "potofolio", PotofolioType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	potofolioRows, err := db.Query(selectQuery, args...)
 	if err != nil {
 		log.Printf("[error] potofolio query [%v]\n", err)
-		return nil, err
+		return nil, "", err
 	}
 
 	defer potofolioRows.Close()
@@ -61,23 +157,84 @@ func (repo *PotofolioRepository) GetPotofolioList() ([]PotofolioModel, error) {
 
 		var potofolioId int64
 		var title string
-		err := potofolioRows.Scan(&potofolioId, &title)
+		var version int64
+		var createdAt int64
+		var updatedAt int64
+		var publishedAt sql.NullInt64
+		var status string
+		var publishAt sql.NullInt64
+		var slug string
+		var position int64
+		var featured bool
+		var metadataScanner potofolioMetadataScanner
+		err := potofolioRows.Scan(append([]interface{}{&potofolioId, &slug, &title, &version, &createdAt, &updatedAt, &publishedAt, &status, &publishAt, &position, &featured}, metadataScanner.dest()...)...)
 		if err != nil {
 			log.Printf("[error] potofolio scan [%v]\n", err)
 			continue
 		}
 
-		potofolio := PotofolioModel{Id: potofolioId, Title: title}
-		potofolio.Images, err = repo.ImageRepo.GetImages(PotofolioType, potofolioId)
+		metadata, err := metadataScanner.metadata()
 		if err != nil {
-			log.Printf("[error] potofolio Image Query [%v]\n", err)
+			log.Printf("[error] potofolio metadata [%v]\n", err)
+		}
+
+		potofolio := PotofolioModel{
+			Id:          potofolioId,
+			Slug:        slug,
+			Title:       title,
+			Version:     version,
+			CreatedAt:   time.Unix(createdAt, 0),
+			UpdatedAt:   time.Unix(updatedAt, 0),
+			PublishedAt: unixToTimePtr(publishedAt),
+			Status:      PublishStatus(status),
+			PublishAt:   unixToTimePtr(publishAt),
+			Position:    position,
+			Featured:    featured,
+			Metadata:    metadata,
 		}
 
 		potofolios = append(potofolios, potofolio)
 
 	}
 
-	return potofolios, nil
+	nextCursor := ""
+	if pageOption.Limit > 0 && len(potofolios) > pageOption.Limit {
+		potofolios = potofolios[:pageOption.Limit]
+
+		last := potofolios[len(potofolios)-1]
+		nextCursor = encodeListCursor(pageOption.Sort, last.Id, last.Title, last.CreatedAt, last.UpdatedAt, last.PublishedAt, last.Position)
+	}
+
+	potofolioIds := make([]int64, 0, len(potofolios))
+	for _, potofolio := range potofolios {
+		potofolioIds = append(potofolioIds, potofolio.Id)
+	}
+
+	imagesById, err := repo.ImageRepo.GetImagesByDependencyIds(PotofolioType, potofolioIds)
+	if err != nil {
+		log.Printf("[error] potofolio Image Query [%v]\n", err)
+		imagesById = make(map[int64][]ImageModel)
+	}
+
+	tagsById, err := getTagsByDependencyIds(db, PotofolioType, potofolioIds)
+	if err != nil {
+		log.Printf("[error] potofolio Tag Query [%v]\n", err)
+		tagsById = make(map[int64][]TagModel)
+	}
+
+	collectionsById, err := getCollectionsByPotofolioIds(db, potofolioIds)
+	if err != nil {
+		log.Printf("[error] potofolio Collection Query [%v]\n", err)
+		collectionsById = make(map[int64][]CollectionRefModel)
+	}
+
+	for i := range potofolios {
+		potofolios[i].Images = imagesById[potofolios[i].Id]
+		potofolios[i].Tags = tagsById[potofolios[i].Id]
+		potofolios[i].Collections = collectionsById[potofolios[i].Id]
+	}
+
+	return potofolios, nextCursor, nil
 }
 
 func (repo *PotofolioRepository) FindPotofolio(potofolioId int64) (*PotofolioModel, error) {
@@ -87,7 +244,7 @@ func (repo *PotofolioRepository) FindPotofolio(potofolioId int64) (*PotofolioMod
 		return nil, err
 	}
 
-	potofolioRows, err := db.Query("SELECT title FROM potofolio WHERE id = $1", potofolioId)
+	potofolioRows, err := db.Query("SELECT COALESCE(slug, ''), title, version, createdAt, updatedAt, publishedAt, status, publishAt, COALESCE(position, 0), featured, "+potofolioMetadataColumns+" FROM potofolio WHERE id = $1 AND deletedAt IS NULL", potofolioId)
 	if err != nil {
 		log.Printf("[error] potofolio query [%v]\n", err)
 		return nil, err
@@ -96,16 +253,44 @@ func (repo *PotofolioRepository) FindPotofolio(potofolioId int64) (*PotofolioMod
 	defer potofolioRows.Close()
 
 	if !potofolioRows.Next() {
-		return nil, fmt.Errorf("potofolio not found [id: %v]", potofolioId)
+		return nil, &PotofolioNotFoundError{Id: potofolioId}
 	}
 
 	var title string
-	err = potofolioRows.Scan(&title)
+	var version int64
+	var createdAt int64
+	var updatedAt int64
+	var publishedAt sql.NullInt64
+	var status string
+	var publishAt sql.NullInt64
+	var slug string
+	var position int64
+	var featured bool
+	var metadataScanner potofolioMetadataScanner
+	err = potofolioRows.Scan(append([]interface{}{&slug, &title, &version, &createdAt, &updatedAt, &publishedAt, &status, &publishAt, &position, &featured}, metadataScanner.dest()...)...)
 	if err != nil {
 		return nil, err
 	}
 
-	potofolioModel := PotofolioModel{Id: potofolioId, Title: title}
+	metadata, err := metadataScanner.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	potofolioModel := PotofolioModel{
+		Id:          potofolioId,
+		Slug:        slug,
+		Title:       title,
+		Version:     version,
+		CreatedAt:   time.Unix(createdAt, 0),
+		UpdatedAt:   time.Unix(updatedAt, 0),
+		PublishedAt: unixToTimePtr(publishedAt),
+		Status:      PublishStatus(status),
+		PublishAt:   unixToTimePtr(publishAt),
+		Position:    position,
+		Featured:    featured,
+		Metadata:    metadata,
+	}
 
 	potofolioModel.Images, err = repo.ImageRepo.GetImages(PotofolioType, potofolioId)
 
@@ -113,10 +298,62 @@ func (repo *PotofolioRepository) FindPotofolio(potofolioId int64) (*PotofolioMod
 		log.Printf("[error] potofolio Image Query [%v]\n", err)
 	}
 
+	tagsById, err := getTagsByDependencyIds(db, PotofolioType, []int64{potofolioId})
+	if err != nil {
+		log.Printf("[error] potofolio Tag Query [%v]\n", err)
+	}
+
+	potofolioModel.Tags = tagsById[potofolioId]
+
+	collectionsById, err := getCollectionsByPotofolioIds(db, []int64{potofolioId})
+	if err != nil {
+		log.Printf("[error] potofolio Collection Query [%v]\n", err)
+	}
+
+	potofolioModel.Collections = collectionsById[potofolioId]
+
 	return &potofolioModel, nil
 }
 
+// potofolioIds 순서대로 가져온다. (collection 의 potofolio)
+// 목록 조회와 같이 한번에 조회하고, 없거나 휴지통에 있는 potofolio 는 빠진다.
+func (repo *PotofolioRepository) FindPotofolios(potofolioIds []int64) ([]PotofolioModel, error) {
+
+	if len(potofolioIds) == 0 {
+		return make([]PotofolioModel, 0), nil
+	}
+
+	potofolios, _, err := repo.GetPotofolioPage(ListPageOption{Filter: ListFilter{Ids: potofolioIds}})
+	if err != nil {
+		return nil, err
+	}
+
+	potofolioById := make(map[int64]PotofolioModel, len(potofolios))
+	for _, potofolio := range potofolios {
+		potofolioById[potofolio.Id] = potofolio
+	}
+
+	orderedPotofolios := make([]PotofolioModel, 0, len(potofolios))
+	for _, potofolioId := range potofolioIds {
+		if potofolio, ok := potofolioById[potofolioId]; ok {
+			orderedPotofolios = append(orderedPotofolios, potofolio)
+		}
+	}
+
+	return orderedPotofolios, nil
+}
+
 func (repo *PotofolioRepository) AddPotofolio(title string, images []string) (int64, error) {
+	return repo.AddPotofolioWithOption(nil, title, images)
+}
+
+// contentOption 의 공개 상태, slug, tag, featured 로 추가한다. (목록의 마지막에 둔다.)
+func (repo *PotofolioRepository) AddPotofolioWithOption(contentOption *ContentOption, title string, images []string) (int64, error) {
+	return repo.AddPotofolioWithMetadata(contentOption, nil, title, images)
+}
+
+// metadataOption 의 작품 정보도 같이 추가한다.
+func (repo *PotofolioRepository) AddPotofolioWithMetadata(contentOption *ContentOption, metadataOption *PotofolioMetadataOption, title string, images []string) (int64, error) {
 
 	completed := false
 
@@ -130,11 +367,13 @@ func (repo *PotofolioRepository) AddPotofolio(title string, images []string) (in
 		return 0, err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: PotofolioContent})
 	defer CloseTranstion(transaction, &completed)
 
-	potofolioInsertQuery := "INSERT INTO potofolio (title) VALUES ($1)"
+	now := time.Now().Unix()
+	potofolioInsertQuery := "INSERT INTO potofolio (title, createdAt, updatedAt) VALUES ($1, $2, $3)"
 
-	result, err := transaction.Exec(potofolioInsertQuery, title)
+	result, err := transaction.Exec(potofolioInsertQuery, title, now, now)
 	if err != nil {
 		return 0, err
 	}
@@ -144,6 +383,36 @@ func (repo *PotofolioRepository) AddPotofolio(title string, images []string) (in
 		return 0, err
 	}
 
+	err = setPublishTransaction(transaction, "potofolio", insertId, contentOption.publishOrDefault())
+	if err != nil {
+		return 0, err
+	}
+
+	err = assignSlugTransaction(transaction, "potofolio", PotofolioType, insertId, title, contentOption.slugOrNil())
+	if err != nil {
+		return 0, err
+	}
+
+	err = setTagsTransaction(transaction, PotofolioType, insertId, contentOption.tagsOrNil())
+	if err != nil {
+		return 0, err
+	}
+
+	err = appendPositionTransaction(transaction, "potofolio", insertId)
+	if err != nil {
+		return 0, err
+	}
+
+	err = setFeaturedTransaction(transaction, "potofolio", insertId, contentOption.featuredOrDefault())
+	if err != nil {
+		return 0, err
+	}
+
+	err = setPotofolioMetadataTransaction(transaction, insertId, metadataOption)
+	if err != nil {
+		return 0, err
+	}
+
 	err = repo.ImageRepo.AddImgesTransaction(transaction, PotofolioType, insertId, images)
 	if err != nil {
 		return 0, err
@@ -155,9 +424,23 @@ func (repo *PotofolioRepository) AddPotofolio(title string, images []string) (in
 }
 
 func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *string, removeImageIds []int64, addImages []string) ([]string, error) {
+	return repo.UpdatePotofolioIfMatch(potofolioId, nil, title, removeImageIds, addImages)
+}
+
+// expectedVersion 이 있으면 현재 version 과 같을 때만 수정한다. (다르면 VersionConflictError)
+func (repo *PotofolioRepository) UpdatePotofolioIfMatch(potofolioId int64, expectedVersion *int64, title *string, removeImageIds []int64, addImages []string) ([]string, error) {
+	return repo.UpdatePotofolioWithOption(potofolioId, expectedVersion, nil, title, removeImageIds, addImages)
+}
+
+// contentOption 이 있으면 공개 상태, slug, tag, featured 도 같이 바꾼다.
+func (repo *PotofolioRepository) UpdatePotofolioWithOption(potofolioId int64, expectedVersion *int64, contentOption *ContentOption, title *string, removeImageIds []int64, addImages []string) ([]string, error) {
+	return repo.UpdatePotofolioWithMetadata(potofolioId, expectedVersion, contentOption, nil, title, removeImageIds, addImages)
+}
+
+// metadataOption 이 있으면 작품 정보도 같이 바꾼다.
+func (repo *PotofolioRepository) UpdatePotofolioWithMetadata(potofolioId int64, expectedVersion *int64, contentOption *ContentOption, metadataOption *PotofolioMetadataOption, title *string, removeImageIds []int64, addImages []string) ([]string, error) {
 
 	completed := false
-	removeImagePaths := make([]string, 0)
 
 	db, err := repo.DBConnect.GetDB()
 	if err != nil {
@@ -169,12 +452,71 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 		return nil, err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: PotofolioContent, Id: potofolioId})
 	defer CloseTranstion(transaction, &completed)
 
+	removeImagePaths, err := repo.updatePotofolioTransaction(transaction, potofolioId, expectedVersion, contentOption, metadataOption, title, removeImageIds, addImages)
+	if err != nil {
+		return nil, err
+	}
+
+	completed = true
+
+	return removeImagePaths, nil
+}
+
+// 여러 수정을 한 transaction 으로 처리할 때 (bulk) 사용한다.
+func (repo *PotofolioRepository) updatePotofolioTransaction(tx *sql.Tx, potofolioId int64, expectedVersion *int64, contentOption *ContentOption, metadataOption *PotofolioMetadataOption, title *string, removeImageIds []int64, addImages []string) ([]string, error) {
+
+	removeImagePaths := make([]string, 0)
+
+	err := bumpVersionTransaction(tx, "potofolio", PotofolioType, potofolioId, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	err = touchUpdatedAtTransaction(tx, "potofolio", potofolioId)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentOption != nil && contentOption.Publish != nil {
+		err = setPublishTransaction(tx, "potofolio", potofolioId, contentOption.Publish)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentOption != nil && contentOption.Slug != nil {
+		err = assignSlugTransaction(tx, "potofolio", PotofolioType, potofolioId, "", contentOption.Slug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentOption != nil && contentOption.Tags != nil {
+		err = setTagsTransaction(tx, PotofolioType, potofolioId, contentOption.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentOption != nil && contentOption.Featured != nil {
+		err = setFeaturedTransaction(tx, "potofolio", potofolioId, *contentOption.Featured)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = setPotofolioMetadataTransaction(tx, potofolioId, metadataOption)
+	if err != nil {
+		return nil, err
+	}
+
 	if title != nil {
-		potofolioUpdateQuery := fmt.Sprintf("UPDATE potofolio SET title = \"%v\" WHERE id = $1", *title)
+		potofolioUpdateQuery := "UPDATE potofolio SET title = $1 WHERE id = $2"
 
-		_, err = transaction.Exec(potofolioUpdateQuery, potofolioId)
+		_, err = tx.Exec(potofolioUpdateQuery, *title, potofolioId)
 		if err != nil {
 			return nil, err
 		}
@@ -183,7 +525,7 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 	// 지워질 이미지 찾기
 	if removeImageIds != nil {
 
-		images, err := repo.ImageRepo.GetImages(PotofolioType, potofolioId)
+		images, err := repo.ImageRepo.GetImagesTransaction(tx, PotofolioType, potofolioId)
 		if err != nil {
 			return nil, err
 		}
@@ -202,7 +544,7 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 				continue
 			}
 
-			err = repo.ImageRepo.RemoveImageIdTransaction(transaction, PotofolioType, potofolioId, image.Id)
+			err = repo.ImageRepo.RemoveImageIdTransaction(tx, PotofolioType, potofolioId, image.Id)
 			if err != nil {
 				return nil, err
 			}
@@ -213,7 +555,7 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 
 	if addImages != nil {
 
-		err = repo.ImageRepo.AddImgesTransaction(transaction, PotofolioType, potofolioId, addImages)
+		err = repo.ImageRepo.AddImgesTransaction(tx, PotofolioType, potofolioId, addImages)
 		if err != nil {
 			return nil, err
 		}
@@ -221,7 +563,7 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 
 	if removeImageIds != nil || addImages != nil {
 
-		err = repo.ImageRepo.SortImageOrderTransation(transaction, PotofolioType, potofolioId)
+		err = repo.ImageRepo.SortImageOrderTransation(tx, PotofolioType, potofolioId)
 
 		if err != nil {
 			return nil, err
@@ -229,8 +571,6 @@ func (repo *PotofolioRepository) UpdatePotofolio(potofolioId int64, title *strin
 
 	}
 
-	completed = true
-
 	return removeImagePaths, nil
 }
 
@@ -248,6 +588,7 @@ func (repo *PotofolioRepository) RemovePotofolio(potofolioId int64) error {
 		return err
 	}
 
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: PotofolioContent, Id: potofolioId})
 	defer CloseTranstion(transaction, &completed)
 
 	potofolioDeleteQuery := "DELETE FROM potofolio WHERE id = $1"
@@ -265,3 +606,172 @@ func (repo *PotofolioRepository) RemovePotofolio(potofolioId int64) error {
 
 	return nil
 }
+
+// 휴지통으로 보낸다. image 파일은 영구 삭제 전까지 남겨둔다.
+func (repo *PotofolioRepository) TrashPotofolio(potofolioId int64, expectedVersion *int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	err = trashRow(db, "potofolio", PotofolioType, potofolioId, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	repo.DBConnect.notifyContentChanges(ContentChange{Content: PotofolioContent, Id: potofolioId})
+
+	return nil
+}
+
+func (repo *PotofolioRepository) RestorePotofolio(potofolioId int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	err = restoreRow(db, "potofolio", PotofolioType, potofolioId)
+	if err != nil {
+		return err
+	}
+
+	repo.DBConnect.notifyContentChanges(ContentChange{Content: PotofolioContent, Id: potofolioId})
+
+	return nil
+}
+
+// potofolioIds 를 주어진 순서대로 목록의 앞에 둔다. (version 은 바뀌지 않는다.)
+func (repo *PotofolioRepository) ReorderPotofolios(potofolioIds []int64) error {
+
+	completed := false
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	// 다른 potofolio 의 position 도 바뀐다.
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: PotofolioContent, Id: AllContentIds})
+	defer CloseTranstion(transaction, &completed)
+
+	err = reorderPositionTransaction(transaction, "potofolio", potofolioIds)
+	if err != nil {
+		return err
+	}
+
+	completed = true
+
+	return nil
+}
+
+func (repo *PotofolioRepository) GetTrashedPotofolioList() ([]TrashModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return getTrashList(db, "potofolio", "title", PotofolioType)
+}
+
+// deletedBefore 이전에 휴지통으로 간 potofolio 를 영구 삭제하고, 지워야 할 image 경로를 반환한다.
+func (repo *PotofolioRepository) PurgeTrashedPotofolios(deletedBefore time.Time) ([]string, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	potofolioIds, err := getExpiredTrashIds(db, "potofolio", deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	removeImagePaths := make([]string, 0)
+	if len(potofolioIds) == 0 {
+		return removeImagePaths, nil
+	}
+
+	// transaction 시작 전에 image 경로를 모아둔다.
+	for _, potofolioId := range potofolioIds {
+		images, err := repo.ImageRepo.GetImages(PotofolioType, potofolioId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range images {
+			removeImagePaths = append(removeImagePaths, image.Path)
+		}
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, repositoryContentChanges(PotofolioType, potofolioIds)...)
+	defer CloseTranstion(transaction, &completed)
+
+	for _, potofolioId := range potofolioIds {
+		_, err = transaction.Exec("DELETE FROM potofolio WHERE id = $1", potofolioId)
+		if err != nil {
+			return nil, err
+		}
+
+		err = repo.ImageRepo.RemoveImagesTransaction(transaction, PotofolioType, potofolioId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completed = true
+
+	return removeImagePaths, nil
+}
+
+// 예약 시간이 지난 potofolio 를 공개한다.
+func (repo *PotofolioRepository) PublishDuePotofolios(now time.Time) ([]int64, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	potofolioIds, err := publishDueRows(db, "potofolio", PotofolioType, now)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.DBConnect.notifyContentChanges(repositoryContentChanges(PotofolioType, potofolioIds)...)
+
+	return potofolioIds, nil
+}
+
+func (repo *PotofolioRepository) GetPublishLogs(potofolioId int64) ([]PublishLogModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return getPublishLogs(db, PotofolioType, potofolioId)
+}
+
+// slug 로 potofolio id 를 찾는다. 바뀌기 전 slug 이면 redirected 가 true 이다.
+func (repo *PotofolioRepository) ResolvePotofolioSlug(slug string) (int64, bool, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return resolveSlug(db, "potofolio", PotofolioType, slug)
+}
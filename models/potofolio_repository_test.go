@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -198,3 +199,44 @@ func TestRemovePotofolio(t *testing.T) {
 
 	assert.Nil(t, findPotofolio)
 }
+
+func TestTrashPotofolio(t *testing.T) {
+
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:potofolio_trash_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repo := &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = repo.CreateTable()
+	assert.Nil(t, err)
+
+	potofolioId, err := repo.AddPotofolio("trash potofolio", []string{"trash image1"})
+	assert.Nil(t, err)
+
+	err = repo.TrashPotofolio(potofolioId, nil)
+	assert.Nil(t, err)
+
+	err = repo.TrashPotofolio(potofolioId, nil)
+	assert.NotNil(t, err)
+
+	potofolios, err := repo.GetPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 0)
+
+	trashModels, err := repo.GetTrashedPotofolioList()
+	assert.Nil(t, err)
+	assert.Equal(t, len(trashModels), 1)
+	assert.Equal(t, trashModels[0].Title, "trash potofolio")
+
+	removeImages, err := repo.PurgeTrashedPotofolios(time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, removeImages, []string{"trash image1"})
+
+	err = repo.RestorePotofolio(potofolioId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// content 공개 상태
+type PublishStatus string
+
+const (
+	// 관리자만 볼 수 있다.
+	PublishStatusDraft PublishStatus = "draft"
+	// publishAt 이 되면 scheduler 가 published 로 바꾼다.
+	PublishStatusScheduled PublishStatus = "scheduled"
+	// 누구나 볼 수 있다.
+	PublishStatusPublished PublishStatus = "published"
+	// 목록에는 나오지 않고 id 로 직접 조회할 때만 보인다.
+	PublishStatusUnlisted PublishStatus = "unlisted"
+)
+
+func ParsePublishStatus(name string) (PublishStatus, error) {
+	switch status := PublishStatus(name); status {
+	case PublishStatusDraft, PublishStatusScheduled, PublishStatusPublished, PublishStatusUnlisted:
+		return status, nil
+	}
+
+	return "", fmt.Errorf("unknown publish status [%s]", name)
+}
+
+// 로그인 없이 id 로 볼 수 있는 상태인가
+func (status PublishStatus) IsPublic() bool {
+	return status == PublishStatusPublished || status == PublishStatusUnlisted
+}
+
+type PublishOption struct {
+	Status PublishStatus
+
+	// scheduled 일 때 공개될 시간
+	PublishAt *time.Time
+}
+
+func (option *PublishOption) Validate() error {
+
+	_, err := ParsePublishStatus(string(option.Status))
+	if err != nil {
+		return err
+	}
+
+	if option.Status == PublishStatusScheduled && option.PublishAt == nil {
+		return fmt.Errorf("scheduled status needs publish time")
+	}
+
+	return nil
+}
+
+// scheduler 가 예약된 content 를 공개한 기록
+type PublishLogModel struct {
+	Type        RepositoryType `json:"type"`
+	Id          int64          `json:"id"`
+	ScheduledAt time.Time      `json:"scheduledAt"`
+	PublishedAt time.Time      `json:"publishedAt"`
+}
+
+func createPublishLogTable(db *sql.DB) error {
+
+	createPublishLogTableQuery := `
+		CREATE TABLE IF NOT EXISTS "publish_log"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"dependencyType" INTEGER,
+			"dependencyId" INTEGER,
+			"scheduledAt" INTEGER,
+			"publishedAt" INTEGER
+		)`
+
+	_, err := db.Exec(createPublishLogTableQuery)
+	return err
+}
+
+// status, publishAt column 을 추가한다. (기존 row 는 이미 공개된 상태)
+func addPublishColumns(db *sql.DB, table string) error {
+
+	_, err := addColumnIfNotExists(db, table, "status", fmt.Sprintf("TEXT NOT NULL DEFAULT '%s'", PublishStatusPublished))
+	if err != nil {
+		return err
+	}
+
+	_, err = addColumnIfNotExists(db, table, "publishAt", "INTEGER")
+	if err != nil {
+		return err
+	}
+
+	return createPublishLogTable(db)
+}
+
+// 공개 상태를 바꾼다.
+// 공개될 때 (published, unlisted) 처음 공개된 시간을 publishedAt 에 남기고,
+// 예약 시간이 이미 지났으면 바로 공개한다.
+func setPublishTransaction(tx *sql.Tx, table string, id int64, option *PublishOption) error {
+
+	err := option.Validate()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	status := option.Status
+	publishedAt := now
+	if status == PublishStatusScheduled {
+		if option.PublishAt.After(now) {
+			updateQuery := fmt.Sprintf("UPDATE \"%s\" SET status = $1, publishAt = $2 WHERE id = $3", table)
+			_, err = tx.Exec(updateQuery, status, option.PublishAt.Unix(), id)
+			return err
+		}
+
+		status = PublishStatusPublished
+		publishedAt = *option.PublishAt
+	}
+
+	if status == PublishStatusDraft {
+		updateQuery := fmt.Sprintf("UPDATE \"%s\" SET status = $1, publishAt = NULL WHERE id = $2", table)
+		_, err = tx.Exec(updateQuery, status, id)
+		return err
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE \"%s\" SET status = $1, publishAt = NULL, publishedAt = COALESCE(publishedAt, $2) WHERE id = $3", table)
+	_, err = tx.Exec(updateQuery, status, publishedAt.Unix(), id)
+	return err
+}
+
+// 예약 시간이 지난 content 를 공개하고 publish_log 에 남긴다.
+// 공개된 content 의 id 를 반환한다.
+func publishDueRows(db *sql.DB, table string, repositoryType RepositoryType, now time.Time) ([]int64, error) {
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	completed := false
+	defer CloseTranstion(transaction, &completed)
+
+	selectQuery := fmt.Sprintf("SELECT id, publishAt FROM \"%s\" WHERE status = $1 AND publishAt <= $2 AND deletedAt IS NULL", table)
+	rows, err := transaction.Query(selectQuery, PublishStatusScheduled, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0)
+	scheduledAts := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		var scheduledAt int64
+		err = rows.Scan(&id, &scheduledAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		ids = append(ids, id)
+		scheduledAts = append(scheduledAts, scheduledAt)
+	}
+	rows.Close()
+
+	publishQuery := fmt.Sprintf("UPDATE \"%s\" SET status = $1, publishAt = NULL, publishedAt = $2, version = version + 1 WHERE id = $3", table)
+	logQuery := "INSERT INTO publish_log (dependencyType, dependencyId, scheduledAt, publishedAt) VALUES ($1, $2, $3, $4)"
+	for i, id := range ids {
+		_, err = transaction.Exec(publishQuery, PublishStatusPublished, scheduledAts[i], id)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = transaction.Exec(logQuery, repositoryType, id, scheduledAts[i], now.Unix())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completed = true
+
+	return ids, nil
+}
+
+func getPublishLogs(db *sql.DB, repositoryType RepositoryType, id int64) ([]PublishLogModel, error) {
+
+	selectQuery := "SELECT scheduledAt, publishedAt FROM publish_log WHERE dependencyType = $1 AND dependencyId = $2 ORDER BY id"
+	rows, err := db.Query(selectQuery, repositoryType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	publishLogs := make([]PublishLogModel, 0)
+	for rows.Next() {
+		var scheduledAt int64
+		var publishedAt int64
+		err = rows.Scan(&scheduledAt, &publishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		publishLogs = append(publishLogs, PublishLogModel{
+			Type:        repositoryType,
+			Id:          id,
+			ScheduledAt: time.Unix(scheduledAt, 0),
+			PublishedAt: time.Unix(publishedAt, 0),
+		})
+	}
+
+	return publishLogs, nil
+}
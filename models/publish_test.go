@@ -0,0 +1,149 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestPublishEssayRepo() (*DBConnection, *EssayRepository, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:publish_test?mode=memory&cache=shared")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	essayRepo := &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = essayRepo.CreateTable()
+	if err != nil {
+		dbConnection.Close()
+		return nil, nil, err
+	}
+
+	return dbConnection, essayRepo, nil
+}
+
+func TestPublishOptionValidate(t *testing.T) {
+
+	option := PublishOption{Status: PublishStatusScheduled}
+	assert.NotNil(t, option.Validate())
+
+	option = PublishOption{Status: "hidden"}
+	assert.NotNil(t, option.Validate())
+
+	publishAt := time.Now()
+	option = PublishOption{Status: PublishStatusScheduled, PublishAt: &publishAt}
+	assert.Nil(t, option.Validate())
+
+	assert.True(t, PublishStatusUnlisted.IsPublic())
+	assert.False(t, PublishStatusScheduled.IsPublic())
+}
+
+func TestEssayPublishStatus(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestPublishEssayRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	publishedId, err := repo.AddEssay("published", "", "", nil)
+	assert.Nil(t, err)
+
+	draftId, err := repo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusDraft}}, "draft", "", "", nil)
+	assert.Nil(t, err)
+
+	unlistedId, err := repo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusUnlisted}}, "unlisted", "", "", nil)
+	assert.Nil(t, err)
+
+	_, err = repo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusScheduled}}, "no publish time", "", "", nil)
+	assert.NotNil(t, err)
+
+	draftModel, err := repo.FindEssay(draftId)
+	assert.Nil(t, err)
+	assert.Equal(t, draftModel.Status, PublishStatusDraft)
+	assert.Nil(t, draftModel.PublishedAt)
+
+	publishedModel, err := repo.FindEssay(publishedId)
+	assert.Nil(t, err)
+	assert.Equal(t, publishedModel.Status, PublishStatusPublished)
+	assert.NotNil(t, publishedModel.PublishedAt)
+
+	essays, _, err := repo.GetEssayPage(ListPageOption{Filter: ListFilter{Statuses: []PublishStatus{PublishStatusPublished}}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(essays), 1)
+	assert.Equal(t, essays[0].Id, publishedId)
+
+	essays, _, err = repo.GetEssayPage(ListPageOption{Filter: ListFilter{Statuses: []PublishStatus{PublishStatusDraft, PublishStatusUnlisted}}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(essays), 2)
+	assert.Equal(t, essays[0].Id, draftId)
+	assert.Equal(t, essays[1].Id, unlistedId)
+
+	// draft 를 공개하면 publishedAt 이 채워진다.
+	_, err = repo.UpdateEssayWithOption("", draftId, nil, &ContentOption{Publish: &PublishOption{Status: PublishStatusPublished}}, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	draftModel, err = repo.FindEssay(draftId)
+	assert.Nil(t, err)
+	assert.Equal(t, draftModel.Status, PublishStatusPublished)
+	assert.NotNil(t, draftModel.PublishedAt)
+}
+
+func TestPublishDueEssays(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestPublishEssayRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	now := time.Now()
+	publishAt := now.Add(time.Hour).Truncate(time.Second)
+	scheduledId, err := repo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusScheduled, PublishAt: &publishAt}}, "scheduled", "", "", nil)
+	assert.Nil(t, err)
+
+	// 이미 지난 예약 시간은 바로 공개된다.
+	pastPublishAt := now.Add(-time.Hour).Truncate(time.Second)
+	pastId, err := repo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusScheduled, PublishAt: &pastPublishAt}}, "past", "", "", nil)
+	assert.Nil(t, err)
+
+	pastModel, err := repo.FindEssay(pastId)
+	assert.Nil(t, err)
+	assert.Equal(t, pastModel.Status, PublishStatusPublished)
+	assert.Equal(t, pastModel.PublishedAt.Unix(), pastPublishAt.Unix())
+
+	scheduledModel, err := repo.FindEssay(scheduledId)
+	assert.Nil(t, err)
+	assert.Equal(t, scheduledModel.Status, PublishStatusScheduled)
+	assert.Equal(t, scheduledModel.PublishAt.Unix(), publishAt.Unix())
+	assert.Nil(t, scheduledModel.PublishedAt)
+
+	publishedIds, err := repo.PublishDueEssays(now)
+	assert.Nil(t, err)
+	assert.Equal(t, len(publishedIds), 0)
+
+	publishedIds, err = repo.PublishDueEssays(now.Add(2 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, publishedIds, []int64{scheduledId})
+
+	scheduledModel, err = repo.FindEssay(scheduledId)
+	assert.Nil(t, err)
+	assert.Equal(t, scheduledModel.Status, PublishStatusPublished)
+	assert.Nil(t, scheduledModel.PublishAt)
+	assert.Equal(t, scheduledModel.PublishedAt.Unix(), publishAt.Unix())
+	assert.Equal(t, scheduledModel.Version, int64(2))
+
+	publishLogs, err := repo.GetPublishLogs(scheduledId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(publishLogs), 1)
+	assert.Equal(t, publishLogs[0].ScheduledAt.Unix(), publishAt.Unix())
+	assert.Equal(t, publishLogs[0].PublishedAt.Unix(), now.Add(2*time.Hour).Unix())
+
+	// 한번만 공개된다.
+	publishedIds, err = repo.PublishDueEssays(now.Add(3 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, len(publishedIds), 0)
+}
@@ -3,36 +3,117 @@ package models
 import "time"
 
 type RepositoryConfigure struct {
-	PotofolioRepository *PotofolioRepository
-	EssayRepository     *EssayRepository
-	AboutRepository     *AboutRepository
-	UserRepository      *UserRespository
+	ImageRepository      *ImageRepository
+	PotofolioRepository  *PotofolioRepository
+	EssayRepository      *EssayRepository
+	AboutRepository      *AboutRepository
+	UserRepository       *UserRespository
+	TagRepository        *TagRepository
+	SearchRepository     *SearchRepository
+	CollectionRepository *CollectionRepository
+	BulkRepository       *BulkRepository
+
+	ContentRevisionRepository *ContentRevisionRepository
 
 	AccessTokenExpireTime  time.Duration
 	RefreshTokenExpireTime time.Duration
 
+	// 휴지통에 들어간 뒤 영구 삭제까지의 보관 기간
+	TrashRetentionTime time.Duration
+
 	IsCheckAuthorize bool
+
+	// /api/docs (OpenAPI 문서 화면) 제공 여부
+	IsServeApiDocs bool
+
+	// 공개 조회 route 별 Cache-Control (route 는 /api 다음 경로, 예: /essay/:id)
+	// 없는 route 는 캐시 header 를 보내지 않는다.
+	CacheControlPolicies map[string]string
+
+	// 조회 응답 캐시 (메모리) 에 둘 최대 응답 수 (0 이면 사용하지 않는다.)
+	ResponseCacheSize int
+
+	// 이 크기 (byte) 이상인 응답을 압축한다. (0 이면 압축하지 않는다.)
+	CompressionMinSize int
+
+	// view build 결과 (index.html, js, css) 가 있는 경로 (비어 있으면 view 를 응답하지 않는다.)
+	ViewPath string
+
+	dbConnection *DBConnection
 }
 
 func (repositoryConfigure *RepositoryConfigure) Init(dbConnection *DBConnection) {
 
+	repositoryConfigure.dbConnection = dbConnection
+
 	imageRepository := &ImageRepository{DBConnect: dbConnection}
 	imageRepository.CreateTable()
 
+	repositoryConfigure.ImageRepository = imageRepository
+
 	repositoryConfigure.PotofolioRepository = &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepository}
 	repositoryConfigure.PotofolioRepository.CreateTable()
 
 	repositoryConfigure.EssayRepository = &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepository}
 	repositoryConfigure.EssayRepository.CreateTable()
 
+	repositoryConfigure.BulkRepository = &BulkRepository{DBConnect: dbConnection, PotofolioRepo: repositoryConfigure.PotofolioRepository, EssayRepo: repositoryConfigure.EssayRepository}
+
+	imageRepository.CreateOwnerTriggers("potofolio", PotofolioType)
+	imageRepository.CreateOwnerTriggers("essay", EssayType)
+
+	repositoryConfigure.CollectionRepository = &CollectionRepository{DBConnect: dbConnection}
+	repositoryConfigure.CollectionRepository.CreateTable()
+
+	repositoryConfigure.TagRepository = &TagRepository{DBConnect: dbConnection}
+	repositoryConfigure.TagRepository.CreateTable()
+
 	repositoryConfigure.AboutRepository = &AboutRepository{DBConnect: dbConnection}
 	repositoryConfigure.AboutRepository.CreateTable()
 
+	// potofolio, essay, about_history 에 trigger 를 만들기 때문에 마지막에 만든다.
+	repositoryConfigure.SearchRepository = &SearchRepository{DBConnect: dbConnection}
+	repositoryConfigure.SearchRepository.CreateTable()
+
 	repositoryConfigure.UserRepository = &UserRespository{DBConnect: dbConnection}
 	repositoryConfigure.UserRepository.CreateTable()
 
+	// 공개 content table 에 trigger 를 만들기 때문에 마지막에 만든다.
+	repositoryConfigure.ContentRevisionRepository = &ContentRevisionRepository{DBConnect: dbConnection}
+	repositoryConfigure.ContentRevisionRepository.CreateTable()
+
 	repositoryConfigure.AccessTokenExpireTime = 1 * time.Minute
 	repositoryConfigure.RefreshTokenExpireTime = 14 * 24 * 60 * time.Minute
+	repositoryConfigure.TrashRetentionTime = 30 * 24 * time.Hour
+
+	repositoryConfigure.CacheControlPolicies = DefaultCacheControlPolicies()
+	repositoryConfigure.ResponseCacheSize = 512
+	repositoryConfigure.CompressionMinSize = 1024
 
 	repositoryConfigure.IsCheckAuthorize = true
 }
+
+// 공개 조회는 캐시에 두되 매번 (304 로) 확인한다.
+func DefaultCacheControlPolicies() map[string]string {
+
+	const revalidate = "public, no-cache"
+
+	return map[string]string{
+		"/about":                    revalidate,
+		"/about-history-categories": revalidate,
+		"/potofolio":                revalidate,
+		"/potofolio/:id":            revalidate,
+		"/essay":                    revalidate,
+		"/essay/:id":                revalidate,
+		"/tags":                     revalidate,
+		"/featured":                 revalidate,
+		"/collections":              revalidate,
+		"/collections/:id":          revalidate,
+		"/search":                   revalidate,
+	}
+}
+
+// repository 들이 공개 content 를 바꾸면 (commit 뒤에) listener 를 호출한다.
+func (repositoryConfigure *RepositoryConfigure) AddContentChangeListener(listener ContentChangeListener) {
+	repositoryConfigure.dbConnection.AddContentChangeListener(listener)
+}
@@ -1,9 +1,38 @@
 package models
 
+import "fmt"
+
 type RepositoryType int
 
 const (
 	PotofolioType RepositoryType = 1 + iota
 	EssayType
 	AboutType
+	AboutHistoryType
 )
+
+var repositoryTypeNames = map[RepositoryType]string{
+	PotofolioType:    "potofolio",
+	EssayType:        "essay",
+	AboutType:        "about",
+	AboutHistoryType: "history",
+}
+
+func (repositoryType RepositoryType) String() string {
+	if name, ok := repositoryTypeNames[repositoryType]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", int(repositoryType))
+}
+
+// api url 등에서 사용하는 이름으로 RepositoryType 을 찾는다.
+func ParseRepositoryType(name string) (RepositoryType, error) {
+	for repositoryType, typeName := range repositoryTypeNames {
+		if typeName == name {
+			return repositoryType, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown repository type [%s]", name)
+}
@@ -0,0 +1,635 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	searchHighlightOpen   = "<mark>"
+	searchHighlightClose  = "</mark>"
+	searchSnippetEllipsis = "…"
+
+	// trigram tokenizer 는 3 글자보다 짧은 검색어를 찾지 못한다.
+	minFtsTermLength = 3
+
+	searchSnippetRunes = 64
+)
+
+type SearchResultModel struct {
+	Type           RepositoryType `json:"type"`
+	Id             int64          `json:"id"`
+	Title          string         `json:"title"`
+	TitleHighlight string         `json:"titleHighlight"`
+	Snippet        string         `json:"snippet"`
+
+	// 클수록 검색어와 가깝다.
+	Score float64 `json:"score"`
+}
+
+type SearchOption struct {
+	Query string
+
+	// 비어 있으면 모든 종류
+	Types []RepositoryType
+
+	// 비어 있으면 모든 공개 상태 (about history 는 published 로 본다.)
+	Statuses []PublishStatus
+
+	Limit int
+}
+
+// 검색 대상 table 과 search_document 에 들어갈 값
+// {row} 는 row 이름 (trigger 에서는 NEW, reindex 에서는 table 이름)
+type searchSource struct {
+	table          string
+	repositoryType RepositoryType
+	titleExpr      string
+	bodyExpr       string
+	statusExpr     string
+}
+
+var searchSources = []searchSource{
+	{
+		table:          "potofolio",
+		repositoryType: PotofolioType,
+		titleExpr:      "COALESCE({row}.title, '')",
+		bodyExpr:       "''",
+		statusExpr:     "{row}.status",
+	},
+	{
+		table:          "essay",
+		repositoryType: EssayType,
+		titleExpr:      "COALESCE({row}.title, '')",
+		bodyExpr:       "COALESCE({row}.essayContent, '')",
+		statusExpr:     "{row}.status",
+	},
+	{
+		table:          "about_history",
+		repositoryType: AboutHistoryType,
+		titleExpr:      "COALESCE({row}.category, '')",
+		bodyExpr:       "COALESCE({row}.duration, '') || ' ' || COALESCE({row}.content, '')",
+		statusExpr:     fmt.Sprintf("'%s'", PublishStatusPublished),
+	},
+}
+
+func (source searchSource) expr(expr string, row string) string {
+	return strings.ReplaceAll(expr, "{row}", row)
+}
+
+func (source searchSource) values(row string) string {
+	return fmt.Sprintf("%d, %s.id, %s, %s, %s, %s.deletedAt",
+		source.repositoryType, row,
+		source.expr(source.titleExpr, row), source.expr(source.bodyExpr, row), source.expr(source.statusExpr, row), row)
+}
+
+// potofolio, essay, about history 검색
+// search_document 는 trigger 로 원본 table 과 같이 바뀌고,
+// FTS5 를 사용할 수 있으면 (sqlite_fts5 build tag) search_fts 로 색인한다. 없으면 search_document 를 직접 찾는다.
+type SearchRepository struct {
+	DBConnect *DBConnection
+
+	ftsEnabled bool
+}
+
+// potofolio, essay, about 의 table 이 만들어진 뒤에 호출해야 한다.
+func (repo *SearchRepository) CreateTable() error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	documentExists, err := isSqliteObjectExists(db, "table", "search_document")
+	if err != nil {
+		return err
+	}
+
+	createSearchDocumentTableQuery := `
+		CREATE TABLE IF NOT EXISTS "search_document"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"dependencyType" INTEGER NOT NULL,
+			"dependencyId" INTEGER NOT NULL,
+			"title" TEXT,
+			"body" TEXT,
+			"status" TEXT,
+			"deletedAt" INTEGER,
+			UNIQUE ("dependencyType", "dependencyId")
+		)`
+
+	_, err = db.Exec(createSearchDocumentTableQuery)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range searchSources {
+		err = createSearchSourceTriggers(db, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	rebuildFts, err := repo.createFtsTable(db)
+	if err != nil {
+		return err
+	}
+
+	if !documentExists {
+		_, err = repo.Reindex()
+		return err
+	}
+
+	if rebuildFts {
+		_, err = db.Exec("INSERT INTO search_fts (search_fts) VALUES ('rebuild')")
+		return err
+	}
+
+	return nil
+}
+
+func (repo *SearchRepository) IsFtsEnabled() bool {
+	return repo.ftsEnabled
+}
+
+func isSqliteObjectExists(db *sql.DB, objectType string, name string) (bool, error) {
+
+	var exists bool
+	err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = $1 AND name = $2)", objectType, name).Scan(&exists)
+	return exists, err
+}
+
+func createSearchSourceTriggers(db *sql.DB, source searchSource) error {
+
+	triggerQueries := []string{
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_search_insert"
+		AFTER INSERT ON "%[1]s"
+		BEGIN
+			INSERT INTO search_document (dependencyType, dependencyId, title, body, status, deletedAt) VALUES (%[2]s);
+		END`, source.table, source.values("NEW")),
+
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_search_update"
+		AFTER UPDATE ON "%[1]s"
+		BEGIN
+			UPDATE search_document SET
+				title = %[3]s,
+				body = %[4]s,
+				status = %[5]s,
+				deletedAt = NEW.deletedAt
+			WHERE dependencyType = %[2]d AND dependencyId = NEW.id;
+		END`, source.table, source.repositoryType,
+			source.expr(source.titleExpr, "NEW"), source.expr(source.bodyExpr, "NEW"), source.expr(source.statusExpr, "NEW")),
+
+		fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_search_delete"
+		AFTER DELETE ON "%[1]s"
+		BEGIN
+			DELETE FROM search_document WHERE dependencyType = %[2]d AND dependencyId = OLD.id;
+		END`, source.table, source.repositoryType),
+	}
+
+	for _, triggerQuery := range triggerQueries {
+		_, err := db.Exec(triggerQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var searchFtsTriggers = []string{"search_document_fts_insert", "search_document_fts_delete", "search_document_fts_update"}
+
+// FTS5 를 사용할 수 있으면 search_fts 와 동기화 trigger 를 만든다.
+// trigger 가 새로 만들어졌으면 (처음이거나 FTS5 없이 실행된 적이 있으면) 색인을 다시 만들어야 하므로 true 를 반환한다.
+func (repo *SearchRepository) createFtsTable(db *sql.DB) (bool, error) {
+
+	var ftsCompiled bool
+	err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&ftsCompiled)
+	if err != nil {
+		return false, err
+	}
+
+	repo.ftsEnabled = ftsCompiled
+
+	// FTS5 없이 실행되면 search_document 수정이 실패하지 않도록 trigger 를 지운다.
+	if !ftsCompiled {
+		for _, trigger := range searchFtsTriggers {
+			_, err = db.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS \"%s\"", trigger))
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return false, nil
+	}
+
+	triggerExists, err := isSqliteObjectExists(db, "trigger", searchFtsTriggers[0])
+	if err != nil {
+		return false, err
+	}
+
+	createSearchFtsTableQuery := `
+		CREATE VIRTUAL TABLE IF NOT EXISTS "search_fts" USING fts5
+		(
+			title, body,
+			content = 'search_document', content_rowid = 'id',
+			tokenize = 'trigram'
+		)`
+
+	_, err = db.Exec(createSearchFtsTableQuery)
+	if err != nil {
+		return false, err
+	}
+
+	triggerQueries := []string{
+		`CREATE TRIGGER IF NOT EXISTS "search_document_fts_insert" AFTER INSERT ON search_document
+		BEGIN
+			INSERT INTO search_fts (rowid, title, body) VALUES (NEW.id, NEW.title, NEW.body);
+		END`,
+
+		`CREATE TRIGGER IF NOT EXISTS "search_document_fts_delete" AFTER DELETE ON search_document
+		BEGIN
+			INSERT INTO search_fts (search_fts, rowid, title, body) VALUES ('delete', OLD.id, OLD.title, OLD.body);
+		END`,
+
+		`CREATE TRIGGER IF NOT EXISTS "search_document_fts_update" AFTER UPDATE OF title, body ON search_document
+		BEGIN
+			INSERT INTO search_fts (search_fts, rowid, title, body) VALUES ('delete', OLD.id, OLD.title, OLD.body);
+			INSERT INTO search_fts (rowid, title, body) VALUES (NEW.id, NEW.title, NEW.body);
+		END`,
+	}
+
+	for _, triggerQuery := range triggerQueries {
+		_, err = db.Exec(triggerQuery)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return !triggerExists, nil
+}
+
+// 원본 table 로 search_document 와 색인을 다시 만든다. 색인된 document 수를 반환한다.
+func (repo *SearchRepository) Reindex() (int64, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return 0, err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	completed := false
+	defer CloseTranstion(transaction, &completed)
+
+	_, err = transaction.Exec("DELETE FROM search_document")
+	if err != nil {
+		return 0, err
+	}
+
+	var documentCount int64
+	for _, source := range searchSources {
+		insertQuery := fmt.Sprintf(`
+			INSERT INTO search_document (dependencyType, dependencyId, title, body, status, deletedAt)
+			SELECT %s FROM "%s"`, source.values(fmt.Sprintf("\"%s\"", source.table)), source.table)
+
+		insertResult, err := transaction.Exec(insertQuery)
+		if err != nil {
+			return 0, err
+		}
+
+		affected, err := insertResult.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		documentCount += affected
+	}
+
+	if repo.ftsEnabled {
+		_, err = transaction.Exec("INSERT INTO search_fts (search_fts) VALUES ('rebuild')")
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	completed = true
+
+	return documentCount, nil
+}
+
+// 검색어를 공백으로 나눈다. 모든 검색어가 들어있는 document 를 찾는다.
+func splitSearchTerms(query string) []string {
+	return strings.Fields(query)
+}
+
+func (repo *SearchRepository) Search(option SearchOption) ([]SearchResultModel, error) {
+
+	terms := splitSearchTerms(option.Query)
+	if len(terms) == 0 {
+		return make([]SearchResultModel, 0), nil
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	// 짧은 검색어는 색인으로 찾은 document 안에서 직접 찾는다.
+	ftsTerms := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= minFtsTermLength {
+			ftsTerms = append(ftsTerms, term)
+		}
+	}
+
+	if repo.ftsEnabled && len(ftsTerms) > 0 {
+		return repo.searchFts(db, terms, ftsTerms, option)
+	}
+
+	return repo.searchScan(db, terms, option)
+}
+
+// 검색어가 모두 들어있는 document 조건 (색인 없이)
+func termConditions(terms []string, placeholder func(value interface{}) string) []string {
+
+	conditions := make([]string, 0, len(terms))
+	for _, term := range terms {
+		termPlaceholder := placeholder(term)
+		conditions = append(conditions, fmt.Sprintf("(instr(lower(d.title), lower(%[1]s)) > 0 OR instr(lower(d.body), lower(%[1]s)) > 0)", termPlaceholder))
+	}
+
+	return conditions
+}
+
+// 강조, snippet 은 색인 사용 여부와 관계없이 같은 방법으로 만든다.
+func newSearchResult(repositoryType RepositoryType, id int64, title string, body string, terms []string) SearchResultModel {
+
+	body = strings.Join(strings.Fields(body), " ")
+
+	titleMatches := findTermMatches(title, terms)
+	bodyMatches := findTermMatches(body, terms)
+
+	return SearchResultModel{
+		Type:           repositoryType,
+		Id:             id,
+		Title:          title,
+		TitleHighlight: highlightMatches(title, titleMatches),
+		Snippet:        makeSearchSnippet(body, bodyMatches),
+		Score:          float64(len(titleMatches)*10 + len(bodyMatches)),
+	}
+}
+
+// 종류, 공개 상태, 휴지통 조건 (search_document 를 d 로 부른다.)
+func (option SearchOption) documentConditions(placeholder func(value interface{}) string) []string {
+
+	conditions := []string{"d.deletedAt IS NULL"}
+
+	if len(option.Types) > 0 {
+		typePlaceholders := make([]string, 0, len(option.Types))
+		for _, repositoryType := range option.Types {
+			typePlaceholders = append(typePlaceholders, placeholder(repositoryType))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("d.dependencyType IN (%s)", strings.Join(typePlaceholders, ", ")))
+	}
+
+	if len(option.Statuses) > 0 {
+		statusPlaceholders := make([]string, 0, len(option.Statuses))
+		for _, status := range option.Statuses {
+			statusPlaceholders = append(statusPlaceholders, placeholder(status))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("d.status IN (%s)", strings.Join(statusPlaceholders, ", ")))
+	}
+
+	return conditions
+}
+
+// ftsTerms (3 글자 이상) 는 색인으로, 나머지 검색어는 색인으로 찾은 document 에서 찾는다.
+func (repo *SearchRepository) searchFts(db *sql.DB, terms []string, ftsTerms []string, option SearchOption) ([]SearchResultModel, error) {
+
+	args := make([]interface{}, 0)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// 검색어는 문구로 감싸서 FTS5 문법으로 해석되지 않도록 한다.
+	quotedTerms := make([]string, 0, len(ftsTerms))
+	for _, term := range ftsTerms {
+		quotedTerms = append(quotedTerms, "\""+strings.ReplaceAll(term, "\"", "\"\"")+"\"")
+	}
+
+	conditions := append([]string{fmt.Sprintf("search_fts MATCH %s", placeholder(strings.Join(quotedTerms, " ")))},
+		option.documentConditions(placeholder)...)
+
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) < minFtsTermLength {
+			conditions = append(conditions, termConditions([]string{term}, placeholder)...)
+		}
+	}
+
+	// title 이 맞는 것을 본문보다 10 배 중요하게 본다.
+	selectQuery := fmt.Sprintf(`
+		SELECT d.dependencyType, d.dependencyId, d.title, d.body,
+			bm25(search_fts, 10.0, 1.0) AS rank
+		FROM search_fts JOIN search_document d ON d.id = search_fts.rowid
+		WHERE %s
+		ORDER BY rank, d.id`, strings.Join(conditions, " AND "))
+
+	if option.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %s", placeholder(option.Limit))
+	}
+
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := make([]SearchResultModel, 0)
+	for rows.Next() {
+		var repositoryType RepositoryType
+		var id int64
+		var title, body string
+		var rank float64
+		err = rows.Scan(&repositoryType, &id, &title, &body, &rank)
+		if err != nil {
+			return nil, err
+		}
+
+		result := newSearchResult(repositoryType, id, title, body, terms)
+
+		// bm25 는 작을수록 가깝다.
+		result.Score = -rank
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// FTS5 를 사용할 수 없거나 검색어가 모두 짧으면 search_document 를 직접 찾는다.
+// title 에 나온 횟수를 본문보다 10 배 중요하게 본다.
+func (repo *SearchRepository) searchScan(db *sql.DB, terms []string, option SearchOption) ([]SearchResultModel, error) {
+
+	args := make([]interface{}, 0)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := append(option.documentConditions(placeholder), termConditions(terms, placeholder)...)
+
+	selectQuery := fmt.Sprintf("SELECT d.dependencyType, d.dependencyId, d.title, d.body FROM search_document d WHERE %s ORDER BY d.id",
+		strings.Join(conditions, " AND "))
+
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := make([]SearchResultModel, 0)
+	for rows.Next() {
+		var repositoryType RepositoryType
+		var id int64
+		var title, body string
+		err = rows.Scan(&repositoryType, &id, &title, &body)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, newSearchResult(repositoryType, id, title, body, terms))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if option.Limit > 0 && len(results) > option.Limit {
+		results = results[:option.Limit]
+	}
+
+	return results, nil
+}
+
+// rune 위치 [start, end)
+type searchMatch struct {
+	start int
+	end   int
+}
+
+// text 에서 검색어가 나온 위치 (대소문자 무시, 겹치면 앞의 것)
+func findTermMatches(text string, terms []string) []searchMatch {
+
+	runes := []rune(text)
+	lowerRunes := []rune(strings.ToLower(text))
+
+	// 소문자로 바꾸면서 길이가 바뀌는 문자가 있으면 위치가 맞지 않으므로 대소문자를 구분해서 찾는다.
+	caseSensitive := len(lowerRunes) != len(runes)
+	if caseSensitive {
+		lowerRunes = runes
+	}
+
+	matches := make([]searchMatch, 0)
+	for _, term := range terms {
+		termRunes := []rune(strings.ToLower(term))
+		if caseSensitive {
+			termRunes = []rune(term)
+		}
+
+		for i := 0; i+len(termRunes) <= len(lowerRunes); i++ {
+			if string(lowerRunes[i:i+len(termRunes)]) == string(termRunes) {
+				matches = append(matches, searchMatch{start: i, end: i + len(termRunes)})
+				i += len(termRunes) - 1
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+
+	merged := make([]searchMatch, 0, len(matches))
+	for _, match := range matches {
+		if len(merged) > 0 && match.start < merged[len(merged)-1].end {
+			continue
+		}
+
+		merged = append(merged, match)
+	}
+
+	return merged
+}
+
+func highlightMatches(text string, matches []searchMatch) string {
+	return highlightRunes([]rune(text), matches, 0, len([]rune(text)))
+}
+
+// runes[from:to] 에서 matches 를 감싼다.
+// 저장된 title, body 를 html 로 보여주므로 <mark> 외에는 모두 escape 한다.
+func highlightRunes(runes []rune, matches []searchMatch, from int, to int) string {
+
+	var builder strings.Builder
+	position := from
+	for _, match := range matches {
+		if match.start < from || match.end > to {
+			continue
+		}
+
+		builder.WriteString(html.EscapeString(string(runes[position:match.start])))
+		builder.WriteString(searchHighlightOpen)
+		builder.WriteString(html.EscapeString(string(runes[match.start:match.end])))
+		builder.WriteString(searchHighlightClose)
+		position = match.end
+	}
+
+	builder.WriteString(html.EscapeString(string(runes[position:to])))
+
+	return builder.String()
+}
+
+// 처음 나온 검색어 주변만 잘라서 보여준다.
+func makeSearchSnippet(body string, matches []searchMatch) string {
+
+	runes := []rune(body)
+
+	from := 0
+	if len(matches) > 0 {
+		from = matches[0].start - searchSnippetRunes/4
+		if from < 0 {
+			from = 0
+		}
+	}
+
+	to := from + searchSnippetRunes
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	// 잘린 검색어는 강조하지 않는다.
+	snippet := highlightRunes(runes, matches, from, to)
+
+	if from > 0 {
+		snippet = searchSnippetEllipsis + snippet
+	}
+
+	if to < len(runes) {
+		snippet += searchSnippetEllipsis
+	}
+
+	return snippet
+}
@@ -0,0 +1,42 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// make build, make test 는 sqlite_fts5 tag 로 빌드한다.
+func TestSearchFts(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:search_fts_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	searchRepo := repositoryConfigure.SearchRepository
+	assert.True(t, searchRepo.IsFtsEnabled())
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("제주 바다 풍경", nil)
+	repositoryConfigure.EssayRepository.AddEssay("여름 여행", "", "제주 바다에서 보낸 <b>여름</b> 이야기", nil)
+	repositoryConfigure.EssayRepository.AddEssay("겨울 여행", "", "눈 내린 산에서 보낸 이야기", nil)
+
+	results, err := searchRepo.Search(SearchOption{Query: "이야기"})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 2)
+
+	// 짧은 검색어는 색인으로 찾은 document 안에서 찾는다.
+	results, err = searchRepo.Search(SearchOption{Query: "이야기 바다"})
+	assert.Nil(t, err)
+	assert.Equal(t, searchResultKeys(results), []string{"essay:여름 여행"})
+	assert.Contains(t, results[0].Snippet, "<mark>바다</mark>")
+	assert.Contains(t, results[0].Snippet, "&lt;b&gt;여름&lt;/b&gt; <mark>이야기</mark>")
+
+	// 색인 없이 찾는 것과 결과가 같다.
+	results, err = searchRepo.Search(SearchOption{Query: "바다"})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 2)
+}
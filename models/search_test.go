@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestSearchRepositories(dataSource string) (*DBConnection, *RepositoryConfigure, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open(dataSource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repositoryConfigure := &RepositoryConfigure{}
+	repositoryConfigure.Init(dbConnection)
+
+	return dbConnection, repositoryConfigure, nil
+}
+
+func searchResultKeys(results []SearchResultModel) []string {
+	keys := make([]string, 0, len(results))
+	for _, result := range results {
+		keys = append(keys, result.Type.String()+":"+result.Title)
+	}
+
+	return keys
+}
+
+func TestSearch(t *testing.T) {
+
+	dbConnection, repositoryConfigure, err := prepareTestSearchRepositories("file:search_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	searchRepo := repositoryConfigure.SearchRepository
+	essayRepo := repositoryConfigure.EssayRepository
+
+	repositoryConfigure.PotofolioRepository.AddPotofolio("제주 바다 풍경", nil)
+	essayRepo.AddEssay("여름 여행", "", "제주 바다에서 보낸 여름 이야기", nil)
+	essayRepo.AddEssayWithOption("", &ContentOption{Publish: &PublishOption{Status: PublishStatusDraft}}, "제주 초안", "", "", nil)
+	repositoryConfigure.AboutRepository.UpdateAboutHistory(nil, nil, []AboutHistoryContent{
+		{Category: "전시", Duration: "2021", Content: "제주 바다 개인전"},
+	})
+
+	// 2 글자 검색어
+	results, err := searchRepo.Search(SearchOption{Query: "바다"})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 3)
+
+	// title 에 있는 것이 먼저
+	assert.Equal(t, results[0].Type, PotofolioType)
+	assert.Equal(t, results[0].TitleHighlight, "제주 <mark>바다</mark> 풍경")
+
+	results, err = searchRepo.Search(SearchOption{Query: "여름 이야기", Types: []RepositoryType{EssayType}})
+	assert.Nil(t, err)
+	assert.Equal(t, searchResultKeys(results), []string{"essay:여름 여행"})
+	assert.Contains(t, results[0].Snippet, "<mark>이야기</mark>")
+
+	results, err = searchRepo.Search(SearchOption{Query: "개인전"})
+	assert.Nil(t, err)
+	assert.Equal(t, searchResultKeys(results), []string{"history:전시"})
+	assert.Contains(t, results[0].Snippet, "<mark>개인전</mark>")
+
+	// 공개 상태
+	results, err = searchRepo.Search(SearchOption{Query: "제주"})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 4)
+
+	results, err = searchRepo.Search(SearchOption{Query: "제주", Statuses: []PublishStatus{PublishStatusPublished}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 3)
+	assert.NotContains(t, searchResultKeys(results), "essay:제주 초안")
+
+	// 수정, 휴지통 (초안 essay)
+	title := "가을 여행"
+	_, err = essayRepo.UpdateEssay(2, &title, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	results, err = searchRepo.Search(SearchOption{Query: "가을 여행"})
+	assert.Nil(t, err)
+	assert.Equal(t, searchResultKeys(results), []string{"essay:가을 여행"})
+
+	assert.Nil(t, essayRepo.TrashEssay(2, nil))
+
+	results, err = searchRepo.Search(SearchOption{Query: "가을 여행"})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 0)
+
+	results, err = searchRepo.Search(SearchOption{Query: "   "})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 0)
+
+	// 다시 색인해도 결과는 같다.
+	documentCount, err := searchRepo.Reindex()
+	assert.Nil(t, err)
+	assert.Equal(t, documentCount, int64(4))
+
+	results, err = searchRepo.Search(SearchOption{Query: "제주 바다", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, results[0].Title, "제주 바다 풍경")
+	assert.True(t, results[0].Score > results[1].Score)
+}
+
+func TestSearchSnippet(t *testing.T) {
+
+	body := "가나다라마바사아자차카타파하 가나다라마바사아자차카타파하 가나다라마바사아자차카타파하 Summer 가나다라마바사아자차카타파하 가나다라마바사아자차카타파하"
+
+	matches := findTermMatches(body, []string{"summer"})
+	assert.Equal(t, len(matches), 1)
+
+	snippet := makeSearchSnippet(body, matches)
+	assert.Contains(t, snippet, "<mark>Summer</mark>")
+	assert.True(t, len([]rune(snippet)) < len([]rune(body)))
+	assert.Equal(t, []rune(snippet)[0], '…')
+
+	// 겹치는 검색어는 한번만 감싼다.
+	assert.Equal(t, highlightMatches("summer", findTermMatches("summer", []string{"sum", "umm"})), "<mark>sum</mark>mer")
+	// 저장된 내용은 escape 하고 <mark> 만 남긴다.
+	text := "<img src=x onerror=alert(1)> summer & \"sea\""
+	assert.Equal(t, highlightMatches(text, findTermMatches(text, []string{"summer"})),
+		"&lt;img src=x onerror=alert(1)&gt; <mark>summer</mark> &amp; &#34;sea&#34;")
+	assert.Equal(t, makeSearchSnippet("<b>", nil), "&lt;b&gt;")
+}
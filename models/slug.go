@@ -0,0 +1,290 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const maxSlugLength = 80
+
+type SlugConflictError struct {
+	Type RepositoryType
+	Slug string
+}
+
+func (e *SlugConflictError) Error() string {
+	return fmt.Sprintf("%v slug already used [slug:%v]", e.Type, e.Slug)
+}
+
+type SlugNotFoundError struct {
+	Type RepositoryType
+	Slug string
+}
+
+func (e *SlugNotFoundError) Error() string {
+	return fmt.Sprintf("%v not found [slug:%v]", e.Type, e.Slug)
+}
+
+// title 로 url 에 쓸 slug 를 만든다.
+// 한글 등 unicode 문자와 숫자는 그대로 두고 (소문자로), 나머지는 '-' 하나로 바꾼다.
+func Slugify(title string) string {
+
+	var builder strings.Builder
+	length := 0
+	pendingSeparator := false
+
+	for _, r := range strings.TrimSpace(title) {
+		if length >= maxSlugLength {
+			break
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) {
+			if pendingSeparator && length > 0 {
+				builder.WriteRune('-')
+				length++
+			}
+
+			builder.WriteRune(unicode.ToLower(r))
+			length++
+			pendingSeparator = false
+			continue
+		}
+
+		pendingSeparator = true
+	}
+
+	return strings.TrimRight(builder.String(), "-")
+}
+
+// 숫자만 있는 slug 는 id 와 구분할 수 없으므로 type 이름을 붙인다.
+func normalizeSlug(repositoryType RepositoryType, slug string) string {
+
+	for _, r := range slug {
+		if !unicode.IsDigit(r) {
+			return slug
+		}
+	}
+
+	if len(slug) == 0 {
+		return repositoryType.String()
+	}
+
+	return repositoryType.String() + "-" + slug
+}
+
+func createSlugRedirectTable(db *sql.DB) error {
+
+	// 바뀌기 전 slug 로 들어온 요청을 현재 content 로 보내기 위한 기록
+	createSlugRedirectTableQuery := `
+		CREATE TABLE IF NOT EXISTS "slug_redirect"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"dependencyType" INTEGER,
+			"dependencyId" INTEGER,
+			"slug" TEXT,
+			"createdAt" INTEGER,
+			UNIQUE ("dependencyType", "slug")
+		)`
+
+	_, err := db.Exec(createSlugRedirectTableQuery)
+	return err
+}
+
+// slug column 과 unique index 를 추가한다.
+// column 이 새로 추가되면 기존 row 의 slug 를 title 로 채운다.
+func addSlugColumn(db *sql.DB, table string, repositoryType RepositoryType) error {
+
+	err := createSlugRedirectTable(db)
+	if err != nil {
+		return err
+	}
+
+	added, err := addColumnIfNotExists(db, table, "slug", "TEXT")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS \"%[1]s_slug\" ON \"%[1]s\" (\"slug\")", table))
+	if err != nil {
+		return err
+	}
+
+	// content 가 영구 삭제되면 redirect 기록도 지운다.
+	triggerQuery := fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_slug_redirect_delete"
+		AFTER DELETE ON "%[1]s"
+		BEGIN
+			DELETE FROM slug_redirect WHERE dependencyType = %[2]d AND dependencyId = OLD.id;
+		END`, table, repositoryType)
+
+	_, err = db.Exec(triggerQuery)
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return nil
+	}
+
+	return backfillSlugs(db, table, repositoryType)
+}
+
+func backfillSlugs(db *sql.DB, table string, repositoryType RepositoryType) error {
+
+	rows, err := db.Query(fmt.Sprintf("SELECT id, title FROM \"%s\" WHERE slug IS NULL ORDER BY id", table))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, 0)
+	titles := make([]string, 0)
+	for rows.Next() {
+		var id int64
+		var title sql.NullString
+		err = rows.Scan(&id, &title)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		ids = append(ids, id)
+		titles = append(titles, title.String)
+	}
+	rows.Close()
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	defer CloseTranstion(transaction, &completed)
+
+	for i, id := range ids {
+		err = assignSlugTransaction(transaction, table, repositoryType, id, titles[i], nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	completed = true
+
+	return nil
+}
+
+// 다른 content 가 쓰고 있거나, 다른 content 의 이전 slug 이면 사용중이다.
+func isSlugTakenTransaction(tx *sql.Tx, table string, repositoryType RepositoryType, id int64, slug string) (bool, error) {
+
+	takenQuery := fmt.Sprintf(`
+		SELECT
+			EXISTS (SELECT 1 FROM "%s" WHERE slug = $1 AND id != $2) OR
+			EXISTS (SELECT 1 FROM slug_redirect WHERE dependencyType = $3 AND slug = $4 AND dependencyId != $5)`, table)
+
+	var taken bool
+	err := tx.QueryRow(takenQuery, slug, id, repositoryType, slug, id).Scan(&taken)
+	if err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+// id 의 slug 를 정한다.
+// requestedSlug 가 있으면 그 slug 를 (사용중이면 SlugConflictError), 없으면 title 로 만든 slug 에 -2, -3 을 붙여 비어있는 것을 사용한다.
+// 이전 slug 가 있으면 redirect 로 남긴다.
+func assignSlugTransaction(tx *sql.Tx, table string, repositoryType RepositoryType, id int64, title string, requestedSlug *string) error {
+
+	var slug string
+	if requestedSlug != nil {
+		if len(Slugify(*requestedSlug)) == 0 {
+			return fmt.Errorf("slug is empty [requested:%v]", *requestedSlug)
+		}
+
+		slug = normalizeSlug(repositoryType, Slugify(*requestedSlug))
+
+		taken, err := isSlugTakenTransaction(tx, table, repositoryType, id, slug)
+		if err != nil {
+			return err
+		}
+
+		if taken {
+			return &SlugConflictError{Type: repositoryType, Slug: slug}
+		}
+	} else {
+		baseSlug := normalizeSlug(repositoryType, Slugify(title))
+
+		slug = baseSlug
+		for suffix := 2; ; suffix++ {
+			taken, err := isSlugTakenTransaction(tx, table, repositoryType, id, slug)
+			if err != nil {
+				return err
+			}
+
+			if !taken {
+				break
+			}
+
+			slug = fmt.Sprintf("%s-%d", baseSlug, suffix)
+		}
+	}
+
+	var currentSlug sql.NullString
+	err := tx.QueryRow(fmt.Sprintf("SELECT slug FROM \"%s\" WHERE id = $1", table), id).Scan(&currentSlug)
+	if err != nil {
+		return err
+	}
+
+	if currentSlug.Valid && currentSlug.String == slug {
+		return nil
+	}
+
+	if currentSlug.Valid && len(currentSlug.String) > 0 {
+		redirectQuery := "INSERT OR REPLACE INTO slug_redirect (dependencyType, dependencyId, slug, createdAt) VALUES ($1, $2, $3, $4)"
+		_, err = tx.Exec(redirectQuery, repositoryType, id, currentSlug.String, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+	}
+
+	// 이전에 쓰던 slug 로 되돌리는 경우
+	_, err = tx.Exec("DELETE FROM slug_redirect WHERE dependencyType = $1 AND slug = $2", repositoryType, slug)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE \"%s\" SET slug = $1 WHERE id = $2", table), slug, id)
+	return err
+}
+
+// slug 로 id 를 찾는다. 이전 slug 이면 redirected 가 true 이다.
+func resolveSlug(db *sql.DB, table string, repositoryType RepositoryType, slug string) (int64, bool, error) {
+
+	var id int64
+	err := db.QueryRow(fmt.Sprintf("SELECT id FROM \"%s\" WHERE slug = $1 AND deletedAt IS NULL", table), slug).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	redirectQuery := fmt.Sprintf(`
+		SELECT slug_redirect.dependencyId
+		FROM slug_redirect JOIN "%s" ON "%s".id = slug_redirect.dependencyId
+		WHERE slug_redirect.dependencyType = $1 AND slug_redirect.slug = $2 AND "%s".deletedAt IS NULL`, table, table, table)
+
+	err = db.QueryRow(redirectQuery, repositoryType, slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, &SlugNotFoundError{Type: repositoryType, Slug: slug}
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
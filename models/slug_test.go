@@ -0,0 +1,113 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestSlugPotofolioRepo() (*DBConnection, *PotofolioRepository, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open("file:slug_test?mode=memory&cache=shared")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	potofolioRepo := &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo}
+	err = potofolioRepo.CreateTable()
+	if err != nil {
+		dbConnection.Close()
+		return nil, nil, err
+	}
+
+	return dbConnection, potofolioRepo, nil
+}
+
+func TestSlugify(t *testing.T) {
+
+	assert.Equal(t, Slugify("Hello, World!"), "hello-world")
+	assert.Equal(t, Slugify("  여름 그림  (2021) "), "여름-그림-2021")
+	assert.Equal(t, Slugify("---"), "")
+
+	assert.Equal(t, normalizeSlug(PotofolioType, "2021"), "potofolio-2021")
+	assert.Equal(t, normalizeSlug(EssayType, ""), "essay")
+	assert.Equal(t, normalizeSlug(EssayType, "a-2021"), "a-2021")
+}
+
+func TestPotofolioSlug(t *testing.T) {
+
+	dbConnection, repo, err := prepareTestSlugPotofolioRepo()
+	assert.Nil(t, err)
+
+	defer dbConnection.Close()
+
+	// 같은 title 이면 뒤에 번호를 붙인다.
+	firstId, err := repo.AddPotofolio("여름 그림", nil)
+	assert.Nil(t, err)
+	secondId, err := repo.AddPotofolio("여름 그림", nil)
+	assert.Nil(t, err)
+
+	first, _ := repo.FindPotofolio(firstId)
+	second, _ := repo.FindPotofolio(secondId)
+	assert.Equal(t, first.Slug, "여름-그림")
+	assert.Equal(t, second.Slug, "여름-그림-2")
+
+	numberId, err := repo.AddPotofolio("2021", nil)
+	assert.Nil(t, err)
+	number, _ := repo.FindPotofolio(numberId)
+	assert.Equal(t, number.Slug, "potofolio-2021")
+
+	// 다른 potofolio 가 쓰고 있는 slug
+	usedSlug := "여름-그림"
+	_, err = repo.UpdatePotofolioWithOption(secondId, nil, &ContentOption{Slug: &usedSlug}, nil, nil, nil)
+	assert.IsType(t, &SlugConflictError{}, err)
+
+	_, err = repo.AddPotofolioWithOption(&ContentOption{Slug: &usedSlug}, "겨울", nil)
+	assert.IsType(t, &SlugConflictError{}, err)
+
+	// slug 를 바꾸면 이전 slug 는 redirect 된다.
+	newSlug := "Summer Painting"
+	_, err = repo.UpdatePotofolioWithOption(firstId, nil, &ContentOption{Slug: &newSlug}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	first, _ = repo.FindPotofolio(firstId)
+	assert.Equal(t, first.Slug, "summer-painting")
+
+	id, redirected, err := repo.ResolvePotofolioSlug("summer-painting")
+	assert.Nil(t, err)
+	assert.Equal(t, id, firstId)
+	assert.False(t, redirected)
+
+	id, redirected, err = repo.ResolvePotofolioSlug("여름-그림")
+	assert.Nil(t, err)
+	assert.Equal(t, id, firstId)
+	assert.True(t, redirected)
+
+	// 이전 slug 는 다른 potofolio 가 사용할 수 없다.
+	_, err = repo.UpdatePotofolioWithOption(secondId, nil, &ContentOption{Slug: &usedSlug}, nil, nil, nil)
+	assert.IsType(t, &SlugConflictError{}, err)
+
+	// 이전 slug 로 되돌리기
+	_, err = repo.UpdatePotofolioWithOption(firstId, nil, &ContentOption{Slug: &usedSlug}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	id, redirected, err = repo.ResolvePotofolioSlug("여름-그림")
+	assert.Nil(t, err)
+	assert.Equal(t, id, firstId)
+	assert.False(t, redirected)
+
+	_, redirected, err = repo.ResolvePotofolioSlug("summer-painting")
+	assert.Nil(t, err)
+	assert.True(t, redirected)
+
+	// 휴지통에 있는 potofolio 는 찾을 수 없다.
+	assert.Nil(t, repo.TrashPotofolio(secondId, nil))
+	_, _, err = repo.ResolvePotofolioSlug("여름-그림-2")
+	assert.IsType(t, &SlugNotFoundError{}, err)
+
+	_, _, err = repo.ResolvePotofolioSlug("unknown")
+	assert.IsType(t, &SlugNotFoundError{}, err)
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -93,3 +94,18 @@ func StorageImage(saveDirectory string, prefixUri string, storageImageInfo *Requ
 		ImageStorePath: storageFullPath,
 	}, nil
 }
+
+// StorageImage 로 저장된 image uri 들의 실제 파일을 지운다.
+func RemoveStoredImages(saveDirectory string, prefixUri string, imageUris []string) {
+
+	for _, imageUri := range imageUris {
+		reletivePath := strings.Replace(imageUri, prefixUri, saveDirectory, 1)
+		absPath, err := filepath.Abs(reletivePath)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		os.Remove(absPath)
+	}
+}
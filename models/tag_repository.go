@@ -0,0 +1,474 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const maxTagNameLength = 40
+
+type TagModel struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// tag cloud 용 (tag 가 붙은 content 수)
+type TagCountModel struct {
+	TagModel
+	PotofolioCount int64 `json:"potofolioCount"`
+	EssayCount     int64 `json:"essayCount"`
+}
+
+func (model *TagCountModel) Count() int64 {
+	return model.PotofolioCount + model.EssayCount
+}
+
+type TagConflictError struct {
+	Name string
+}
+
+func (e *TagConflictError) Error() string {
+	return fmt.Sprintf("tag already exists [name:%v]", e.Name)
+}
+
+type TagNotFoundError struct {
+	Id int64
+}
+
+func (e *TagNotFoundError) Error() string {
+	return fmt.Sprintf("tag not found [id:%v]", e.Id)
+}
+
+// 앞뒤 공백을 지우고 연속된 공백은 하나로 만든다.
+func normalizeTagName(name string) (string, error) {
+
+	normalized := strings.Join(strings.Fields(name), " ")
+	if len(normalized) == 0 {
+		return "", fmt.Errorf("tag name is empty")
+	}
+
+	if len([]rune(normalized)) > maxTagNameLength {
+		return "", fmt.Errorf("tag name is too long [name:%v] (max %d)", normalized, maxTagNameLength)
+	}
+
+	return normalized, nil
+}
+
+// content 에 붙일 tag 이름들이 올바른지 확인한다.
+func ValidateTagNames(names []string) error {
+
+	for _, name := range names {
+		_, err := normalizeTagName(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type TagRepository struct {
+	DBConnect *DBConnection
+}
+
+func createTagTables(db *sql.DB) error {
+
+	// tag 이름은 대소문자를 구분하지 않는다.
+	createTagTableQuery := `
+		CREATE TABLE IF NOT EXISTS "tag"
+		(
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"name" TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			"createdAt" INTEGER
+		)`
+
+	_, err := db.Exec(createTagTableQuery)
+	if err != nil {
+		return err
+	}
+
+	// potofolio, essay 와 tag 의 연결 (dependencyType 으로 content 종류를 구분한다.)
+	createTagLinkTableQuery := `
+		CREATE TABLE IF NOT EXISTS "tag_link"
+		(
+			"tagId" INTEGER NOT NULL REFERENCES "tag" ("id") ON DELETE CASCADE,
+			"dependencyType" INTEGER NOT NULL,
+			"dependencyId" INTEGER NOT NULL,
+			"tagOrder" INTEGER,
+			PRIMARY KEY ("tagId", "dependencyType", "dependencyId")
+		)`
+
+	_, err = db.Exec(createTagLinkTableQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS "tag_link_dependency" ON "tag_link" ("dependencyType", "dependencyId")`)
+	return err
+}
+
+// tag table 을 만들고 content 가 영구 삭제되면 tag 연결도 지운다.
+func addTagLinks(db *sql.DB, table string, repositoryType RepositoryType) error {
+
+	err := createTagTables(db)
+	if err != nil {
+		return err
+	}
+
+	triggerQuery := fmt.Sprintf(`
+		CREATE TRIGGER IF NOT EXISTS "%[1]s_tag_link_delete"
+		AFTER DELETE ON "%[1]s"
+		BEGIN
+			DELETE FROM tag_link WHERE dependencyType = %[2]d AND dependencyId = OLD.id;
+		END`, table, repositoryType)
+
+	_, err = db.Exec(triggerQuery)
+	return err
+}
+
+// tag 이름으로 찾고 없으면 만든다.
+func findOrAddTagTransaction(tx *sql.Tx, name string) (int64, error) {
+
+	var tagId int64
+	err := tx.QueryRow("SELECT id FROM tag WHERE name = $1", name).Scan(&tagId)
+	if err == nil {
+		return tagId, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	insertResult, err := tx.Exec("INSERT INTO tag (name, createdAt) VALUES ($1, strftime('%s', 'now'))", name)
+	if err != nil {
+		return 0, err
+	}
+
+	return insertResult.LastInsertId()
+}
+
+// tag 가 연결된 content 의 version 을 올린다.
+// 단건 조회의 ETag 가 version 이라서 tag 이름이 바뀌어도 version 이 같으면 이전 응답으로 304 가 된다.
+func bumpTagLinkedVersionsTransaction(tx *sql.Tx, tagId int64) error {
+
+	for _, repositoryType := range []RepositoryType{PotofolioType, EssayType} {
+		bumpQuery := fmt.Sprintf(`
+			UPDATE "%s" SET version = version + 1
+			WHERE id IN (SELECT dependencyId FROM tag_link WHERE tagId = $1 AND dependencyType = $2)`, repositoryType)
+
+		_, err := tx.Exec(bumpQuery, tagId, repositoryType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// content 의 tag 를 names 로 바꾼다. (없는 tag 는 만든다.)
+func setTagsTransaction(tx *sql.Tx, repositoryType RepositoryType, id int64, names []string) error {
+
+	_, err := tx.Exec("DELETE FROM tag_link WHERE dependencyType = $1 AND dependencyId = $2", repositoryType, id)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := "INSERT OR IGNORE INTO tag_link (tagId, dependencyType, dependencyId, tagOrder) VALUES ($1, $2, $3, $4)"
+	for order, name := range names {
+		normalized, err := normalizeTagName(name)
+		if err != nil {
+			return err
+		}
+
+		tagId, err := findOrAddTagTransaction(tx, normalized)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(insertQuery, tagId, repositoryType, id, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 여러 content 의 tag 를 한번에 가져온다. tag 가 없는 content 도 빈 목록으로 채운다.
+func getTagsByDependencyIds(db *sql.DB, repositoryType RepositoryType, dependencyIds []int64) (map[int64][]TagModel, error) {
+
+	tagsById := make(map[int64][]TagModel)
+	for _, dependencyId := range dependencyIds {
+		tagsById[dependencyId] = make([]TagModel, 0)
+	}
+
+	if len(dependencyIds) == 0 {
+		return tagsById, nil
+	}
+
+	args := []interface{}{repositoryType}
+	placeholders := make([]string, 0, len(dependencyIds))
+	for _, dependencyId := range dependencyIds {
+		args = append(args, dependencyId)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT tag_link.dependencyId, tag.id, tag.name
+		FROM tag_link JOIN tag ON tag.id = tag_link.tagId
+		WHERE tag_link.dependencyType = $1 AND tag_link.dependencyId IN (%s)
+		ORDER BY tag_link.dependencyId, tag_link.tagOrder`, strings.Join(placeholders, ", "))
+
+	tagRows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var dependencyId int64
+		var tag TagModel
+		err = tagRows.Scan(&dependencyId, &tag.Id, &tag.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		tagsById[dependencyId] = append(tagsById[dependencyId], tag)
+	}
+
+	return tagsById, nil
+}
+
+func (repo *TagRepository) CreateTable() error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	return createTagTables(db)
+}
+
+func (repo *TagRepository) FindTag(tagId int64) (*TagModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	tag := TagModel{}
+	err = db.QueryRow("SELECT id, name FROM tag WHERE id = $1", tagId).Scan(&tag.Id, &tag.Name)
+	if err == sql.ErrNoRows {
+		return nil, &TagNotFoundError{Id: tagId}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// 모든 tag 와 tag 가 붙은 content 수 (이름 순)
+// statuses 가 있으면 그 공개 상태인 content 만 센다. (휴지통에 있는 content 는 세지 않는다.)
+func (repo *TagRepository) GetTagCounts(statuses []PublishStatus) ([]TagCountModel, error) {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, 0)
+	countQuery := func(table string, repositoryType RepositoryType) string {
+		args = append(args, repositoryType)
+		query := fmt.Sprintf(`
+			SELECT COUNT(*) FROM tag_link JOIN "%[1]s" ON "%[1]s".id = tag_link.dependencyId
+			WHERE tag_link.tagId = tag.id AND tag_link.dependencyType = $%[2]d AND "%[1]s".deletedAt IS NULL`, table, len(args))
+
+		if len(statuses) > 0 {
+			statusPlaceholders := make([]string, 0, len(statuses))
+			for _, status := range statuses {
+				args = append(args, status)
+				statusPlaceholders = append(statusPlaceholders, fmt.Sprintf("$%d", len(args)))
+			}
+
+			query += fmt.Sprintf(" AND \"%s\".status IN (%s)", table, strings.Join(statusPlaceholders, ", "))
+		}
+
+		return query
+	}
+
+	selectQuery := fmt.Sprintf("SELECT id, name, (%s), (%s) FROM tag ORDER BY name",
+		countQuery("potofolio", PotofolioType), countQuery("essay", EssayType))
+
+	tagRows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tagRows.Close()
+
+	tags := make([]TagCountModel, 0)
+	for tagRows.Next() {
+		var tag TagCountModel
+		err = tagRows.Scan(&tag.Id, &tag.Name, &tag.PotofolioCount, &tag.EssayCount)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (repo *TagRepository) AddTag(name string) (int64, error) {
+
+	normalized, err := normalizeTagName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return 0, err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: TagContent})
+	defer CloseTranstion(transaction, &completed)
+
+	err = repo.checkTagNameTransaction(transaction, 0, normalized)
+	if err != nil {
+		return 0, err
+	}
+
+	tagId, err := findOrAddTagTransaction(transaction, normalized)
+	if err != nil {
+		return 0, err
+	}
+
+	completed = true
+
+	return tagId, nil
+}
+
+// tag 이름을 바꾼다. (연결된 content 는 그대로)
+func (repo *TagRepository) UpdateTag(tagId int64, name string) error {
+
+	normalized, err := normalizeTagName(name)
+	if err != nil {
+		return err
+	}
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: TagContent, Id: tagId})
+	defer CloseTranstion(transaction, &completed)
+
+	err = repo.checkTagNameTransaction(transaction, tagId, normalized)
+	if err != nil {
+		return err
+	}
+
+	err = bumpTagLinkedVersionsTransaction(transaction, tagId)
+	if err != nil {
+		return err
+	}
+
+	updateResult, err := transaction.Exec("UPDATE tag SET name = $1 WHERE id = $2", normalized, tagId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := updateResult.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return &TagNotFoundError{Id: tagId}
+	}
+
+	completed = true
+
+	return nil
+}
+
+// tag 와 content 연결을 지운다. (content 는 그대로)
+func (repo *TagRepository) RemoveTag(tagId int64) error {
+
+	db, err := repo.DBConnect.GetDB()
+	if err != nil {
+		return err
+	}
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	defer repo.DBConnect.notifyContentChangesIfCompleted(&completed, ContentChange{Content: TagContent, Id: tagId})
+	defer CloseTranstion(transaction, &completed)
+
+	err = bumpTagLinkedVersionsTransaction(transaction, tagId)
+	if err != nil {
+		return err
+	}
+
+	_, err = transaction.Exec("DELETE FROM tag_link WHERE tagId = $1", tagId)
+	if err != nil {
+		return err
+	}
+
+	deleteResult, err := transaction.Exec("DELETE FROM tag WHERE id = $1", tagId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := deleteResult.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return &TagNotFoundError{Id: tagId}
+	}
+
+	completed = true
+
+	return nil
+}
+
+// 다른 tag 가 같은 이름 (대소문자 무시) 을 쓰고 있으면 TagConflictError
+func (repo *TagRepository) checkTagNameTransaction(tx *sql.Tx, tagId int64, name string) error {
+
+	var exists bool
+	err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM tag WHERE name = $1 AND id != $2)", name, tagId).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return &TagConflictError{Name: name}
+	}
+
+	return nil
+}
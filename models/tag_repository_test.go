@@ -0,0 +1,189 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTagRepositories struct {
+	dbConnection  *DBConnection
+	potofolioRepo *PotofolioRepository
+	essayRepo     *EssayRepository
+	tagRepo       *TagRepository
+}
+
+func prepareTestTagRepositories(dataSource string) (*testTagRepositories, error) {
+	dbConnection := &DBConnection{}
+	err := dbConnection.Open(dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	imageRepo := &ImageRepository{DBConnect: dbConnection}
+	imageRepo.CreateTable()
+
+	repos := &testTagRepositories{
+		dbConnection:  dbConnection,
+		potofolioRepo: &PotofolioRepository{DBConnect: dbConnection, ImageRepo: imageRepo},
+		essayRepo:     &EssayRepository{DBConnect: dbConnection, ImageRepo: imageRepo},
+		tagRepo:       &TagRepository{DBConnect: dbConnection},
+	}
+
+	for _, createTable := range []func() error{repos.potofolioRepo.CreateTable, repos.essayRepo.CreateTable, repos.tagRepo.CreateTable} {
+		err = createTable()
+		if err != nil {
+			dbConnection.Close()
+			return nil, err
+		}
+	}
+
+	return repos, nil
+}
+
+func TestContentTags(t *testing.T) {
+
+	repos, err := prepareTestTagRepositories("file:tag_content_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer repos.dbConnection.Close()
+
+	sculptureId, err := repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Tags: []string{" Sculpture ", "travel"}}, "stone", nil)
+	assert.Nil(t, err)
+
+	_, err = repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Tags: []string{"Travel"}}, "sea", nil)
+	assert.Nil(t, err)
+
+	essayId, err := repos.essayRepo.AddEssayWithOption("", &ContentOption{Tags: []string{"TRAVEL"}}, "trip", "", "", nil)
+	assert.Nil(t, err)
+
+	// 대소문자가 달라도 같은 tag 이다.
+	potofolio, err := repos.potofolioRepo.FindPotofolio(sculptureId)
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolio.Tags), 2)
+	assert.Equal(t, potofolio.Tags[0].Name, "Sculpture")
+	assert.Equal(t, potofolio.Tags[1].Name, "travel")
+
+	essay, err := repos.essayRepo.FindEssay(essayId)
+	assert.Nil(t, err)
+	assert.Equal(t, essay.Tags, []TagModel{potofolio.Tags[1]})
+
+	potofolios, _, err := repos.potofolioRepo.GetPotofolioPage(ListPageOption{Filter: ListFilter{Tag: "travel"}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 2)
+	assert.Equal(t, len(potofolios[1].Tags), 1)
+
+	potofolios, _, err = repos.potofolioRepo.GetPotofolioPage(ListPageOption{Filter: ListFilter{Tag: "sculpture"}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(potofolios), 1)
+	assert.Equal(t, potofolios[0].Id, sculptureId)
+
+	essaies, _, err := repos.essayRepo.GetEssayPage(ListPageOption{Filter: ListFilter{Tag: "travel"}})
+	assert.Nil(t, err)
+	assert.Equal(t, len(essaies), 1)
+	assert.Equal(t, essaies[0].Tags[0].Name, "travel")
+
+	// tag 를 주지 않으면 바뀌지 않고, 빈 목록이면 모두 뺀다.
+	title := "stone 2"
+	_, err = repos.potofolioRepo.UpdatePotofolioWithOption(sculptureId, nil, &ContentOption{}, &title, nil, nil)
+	assert.Nil(t, err)
+
+	potofolio, _ = repos.potofolioRepo.FindPotofolio(sculptureId)
+	assert.Equal(t, len(potofolio.Tags), 2)
+
+	_, err = repos.potofolioRepo.UpdatePotofolioWithOption(sculptureId, nil, &ContentOption{Tags: []string{}}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	potofolio, _ = repos.potofolioRepo.FindPotofolio(sculptureId)
+	assert.Equal(t, len(potofolio.Tags), 0)
+
+	_, err = repos.potofolioRepo.UpdatePotofolioWithOption(sculptureId, nil, &ContentOption{Tags: []string{"  "}}, nil, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestTagCounts(t *testing.T) {
+
+	repos, err := prepareTestTagRepositories("file:tag_count_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer repos.dbConnection.Close()
+
+	draft := &PublishOption{Status: PublishStatusDraft}
+	repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Tags: []string{"travel"}}, "a", nil)
+	repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Publish: draft, Tags: []string{"travel", "sculpture"}}, "b", nil)
+	trashedId, _ := repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Tags: []string{"travel"}}, "c", nil)
+	repos.essayRepo.AddEssayWithOption("", &ContentOption{Tags: []string{"travel"}}, "d", "", "", nil)
+
+	assert.Nil(t, repos.potofolioRepo.TrashPotofolio(trashedId, nil))
+
+	tags, err := repos.tagRepo.GetTagCounts(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(tags), 2)
+	assert.Equal(t, tags[0].Name, "sculpture")
+	assert.Equal(t, tags[0].Count(), int64(1))
+	assert.Equal(t, tags[1].Name, "travel")
+	assert.Equal(t, tags[1].PotofolioCount, int64(2))
+	assert.Equal(t, tags[1].EssayCount, int64(1))
+
+	tags, err = repos.tagRepo.GetTagCounts([]PublishStatus{PublishStatusPublished})
+	assert.Nil(t, err)
+	assert.Equal(t, tags[0].Count(), int64(0))
+	assert.Equal(t, tags[1].PotofolioCount, int64(1))
+	assert.Equal(t, tags[1].EssayCount, int64(1))
+}
+
+func TestTagCRUD(t *testing.T) {
+
+	repos, err := prepareTestTagRepositories("file:tag_crud_test?mode=memory&cache=shared")
+	assert.Nil(t, err)
+
+	defer repos.dbConnection.Close()
+
+	tagId, err := repos.tagRepo.AddTag("travel")
+	assert.Nil(t, err)
+
+	_, err = repos.tagRepo.AddTag("Travel")
+	assert.IsType(t, &TagConflictError{}, err)
+
+	otherId, err := repos.tagRepo.AddTag("sculpture")
+	assert.Nil(t, err)
+
+	err = repos.tagRepo.UpdateTag(otherId, "TRAVEL")
+	assert.IsType(t, &TagConflictError{}, err)
+
+	// 대소문자만 바꾸는 것은 된다.
+	err = repos.tagRepo.UpdateTag(tagId, "Travel")
+	assert.Nil(t, err)
+
+	tag, err := repos.tagRepo.FindTag(tagId)
+	assert.Nil(t, err)
+	assert.Equal(t, tag.Name, "Travel")
+
+	err = repos.tagRepo.UpdateTag(100, "none")
+	assert.IsType(t, &TagNotFoundError{}, err)
+
+	// tag 를 지우면 content 에서 빠진다.
+	potofolioId, _ := repos.potofolioRepo.AddPotofolioWithOption(&ContentOption{Tags: []string{"travel", "sculpture"}}, "a", nil)
+
+	err = repos.tagRepo.RemoveTag(tagId)
+	assert.Nil(t, err)
+
+	potofolio, err := repos.potofolioRepo.FindPotofolio(potofolioId)
+	assert.Nil(t, err)
+	assert.Equal(t, potofolio.Tags, []TagModel{{Id: otherId, Name: "sculpture"}})
+
+	err = repos.tagRepo.RemoveTag(tagId)
+	assert.IsType(t, &TagNotFoundError{}, err)
+
+	// content 가 영구 삭제되면 tag 연결도 지운다.
+	assert.Nil(t, repos.potofolioRepo.RemovePotofolio(potofolioId))
+
+	tags, err := repos.tagRepo.GetTagCounts(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, tags[0].Count(), int64(0))
+
+	var linkCount int
+	db, _ := repos.dbConnection.GetDB()
+	db.QueryRow("SELECT COUNT(*) FROM tag_link").Scan(&linkCount)
+	assert.Equal(t, linkCount, 0)
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// 휴지통에 있는 content
+type TrashModel struct {
+	Type      RepositoryType `json:"type"`
+	Id        int64          `json:"id"`
+	Title     string         `json:"title"`
+	DeletedAt time.Time      `json:"deleted_at"`
+}
+
+type TrashNotFoundError struct {
+	Type RepositoryType
+	Id   int64
+}
+
+func (e *TrashNotFoundError) Error() string {
+	return fmt.Sprintf("%v not found [id:%v]", e.Type, e.Id)
+}
+
+// deletedAt 을 채워 휴지통으로 보낸다.
+// expectedVersion 이 있으면 같은 version 일 때만 보낸다.
+func trashRow(db *sql.DB, table string, repositoryType RepositoryType, id int64, expectedVersion *int64) error {
+
+	completed := false
+
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer CloseTranstion(transaction, &completed)
+
+	err = trashRowTransaction(transaction, table, repositoryType, id, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	completed = true
+
+	return nil
+}
+
+func trashRowTransaction(tx *sql.Tx, table string, repositoryType RepositoryType, id int64, expectedVersion *int64) error {
+
+	trashQuery := fmt.Sprintf("UPDATE \"%s\" SET deletedAt = $1, version = version + 1 WHERE id = $2 AND deletedAt IS NULL", table)
+	args := []interface{}{time.Now().Unix(), id}
+	if expectedVersion != nil {
+		trashQuery += " AND version = $3"
+		args = append(args, *expectedVersion)
+	}
+
+	result, err := tx.Exec(trashQuery, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var currentVersion int64
+	err = tx.QueryRow(fmt.Sprintf("SELECT version FROM \"%s\" WHERE id = $1 AND deletedAt IS NULL", table), id).Scan(&currentVersion)
+	if err == sql.ErrNoRows {
+		return &TrashNotFoundError{Type: repositoryType, Id: id}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return &VersionConflictError{Type: repositoryType, Id: id, CurrentVersion: currentVersion}
+}
+
+// *sql.DB, *sql.Tx
+type rowExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// 휴지통에 있는 row 의 deletedAt 을 비운다.
+// 다른 변경과 같이 처리해야 하면 (about 의 version) transaction 을 넘긴다.
+func restoreRow(db rowExecutor, table string, repositoryType RepositoryType, id int64) error {
+
+	restoreQuery := fmt.Sprintf("UPDATE \"%s\" SET deletedAt = NULL WHERE id = $1 AND deletedAt IS NOT NULL", table)
+	result, err := db.Exec(restoreQuery, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return &TrashNotFoundError{Type: repositoryType, Id: id}
+	}
+
+	return nil
+}
+
+func getTrashList(db *sql.DB, table string, titleColumn string, repositoryType RepositoryType) ([]TrashModel, error) {
+
+	selectQuery := fmt.Sprintf("SELECT id, %s, deletedAt FROM \"%s\" WHERE deletedAt IS NOT NULL ORDER BY deletedAt DESC", titleColumn, table)
+	rows, err := db.Query(selectQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	trashModels := make([]TrashModel, 0)
+	for rows.Next() {
+		var id int64
+		var title sql.NullString
+		var deletedAt int64
+		err = rows.Scan(&id, &title, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		trashModels = append(trashModels, TrashModel{
+			Type:      repositoryType,
+			Id:        id,
+			Title:     title.String,
+			DeletedAt: time.Unix(deletedAt, 0),
+		})
+	}
+
+	return trashModels, nil
+}
+
+// deletedBefore 이전에 휴지통으로 간 row 의 id 목록
+func getExpiredTrashIds(db *sql.DB, table string, deletedBefore time.Time) ([]int64, error) {
+
+	selectQuery := fmt.Sprintf("SELECT id FROM \"%s\" WHERE deletedAt IS NOT NULL AND deletedAt <= $1", table)
+	rows, err := db.Query(selectQuery, deletedBefore.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
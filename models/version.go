@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// If-Match 로 받은 version 과 db 의 version 이 다를 때
+type VersionConflictError struct {
+	Type           RepositoryType
+	Id             int64
+	CurrentVersion int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v version conflict [id:%v, current version:%v]", e.Type, e.Id, e.CurrentVersion)
+}
+
+// transaction 의 첫 쓰기로 version 을 올린다.
+// expectedVersion 이 있으면 같은 version 일 때만 올리므로 확인과 증가가 한번에 처리된다.
+func bumpVersionTransaction(tx *sql.Tx, table string, repositoryType RepositoryType, id int64, expectedVersion *int64) error {
+
+	var result sql.Result
+	var err error
+	if expectedVersion == nil {
+		bumpQuery := fmt.Sprintf("UPDATE \"%s\" SET version = version + 1 WHERE id = $1", table)
+		result, err = tx.Exec(bumpQuery, id)
+	} else {
+		bumpQuery := fmt.Sprintf("UPDATE \"%s\" SET version = version + 1 WHERE id = $1 AND version = $2", table)
+		result, err = tx.Exec(bumpQuery, id, *expectedVersion)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var currentVersion int64
+	err = tx.QueryRow(fmt.Sprintf("SELECT version FROM \"%s\" WHERE id = $1", table), id).Scan(&currentVersion)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%v not found [id:%v]", repositoryType, id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return &VersionConflictError{Type: repositoryType, Id: id, CurrentVersion: currentVersion}
+}